@@ -1,19 +1,59 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/event"
 	"github.com/pklimuk-eng-thesis/control-station/pkg/http"
 	acHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/ac"
+	analogSensorHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/analog"
+	analyticsHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/analytics"
+	arbitrationHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/arbitration"
+	awayHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/away"
 	deviceHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/device"
-	sensorHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/sensor"
+	exportHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/export"
+	groupHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/group"
+	lightHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/light"
+	maintenanceHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/maintenance"
+	occupancyHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/occupancy"
+	plugHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/plug"
+	roomHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/room"
+	safetyHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/safety"
+	securityHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/security"
+	statusHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/status"
+	thermostatHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/thermostat"
+	timelineHttp "github.com/pklimuk-eng-thesis/control-station/pkg/http/timeline"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
 	acService "github.com/pklimuk-eng-thesis/control-station/pkg/service/ac"
+	alertService "github.com/pklimuk-eng-thesis/control-station/pkg/service/alert"
+	analogSensorService "github.com/pklimuk-eng-thesis/control-station/pkg/service/analog"
+	analyticsService "github.com/pklimuk-eng-thesis/control-station/pkg/service/analytics"
+	arbitrationService "github.com/pklimuk-eng-thesis/control-station/pkg/service/arbitration"
+	awayService "github.com/pklimuk-eng-thesis/control-station/pkg/service/away"
 	deviceService "github.com/pklimuk-eng-thesis/control-station/pkg/service/device"
-	sensorService "github.com/pklimuk-eng-thesis/control-station/pkg/service/sensor"
+	exportService "github.com/pklimuk-eng-thesis/control-station/pkg/service/export"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	lightService "github.com/pklimuk-eng-thesis/control-station/pkg/service/light"
+	maintenanceService "github.com/pklimuk-eng-thesis/control-station/pkg/service/maintenance"
+	occupancyService "github.com/pklimuk-eng-thesis/control-station/pkg/service/occupancy"
+	plugService "github.com/pklimuk-eng-thesis/control-station/pkg/service/plug"
+	roomService "github.com/pklimuk-eng-thesis/control-station/pkg/service/room"
+	safetyService "github.com/pklimuk-eng-thesis/control-station/pkg/service/safety"
+	securityService "github.com/pklimuk-eng-thesis/control-station/pkg/service/security"
+	statusService "github.com/pklimuk-eng-thesis/control-station/pkg/service/status"
+	thermostatService "github.com/pklimuk-eng-thesis/control-station/pkg/service/thermostat"
+	timelineService "github.com/pklimuk-eng-thesis/control-station/pkg/service/timeline"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/state"
 	"github.com/pklimuk-eng-thesis/control-station/utils"
 )
 
@@ -25,45 +65,762 @@ func main() {
 	smartBulbAddress := utils.GetEnvVariableOrDefault("SMART_BULB_ADDRESS", "http://localhost:8084")
 	smartPlugAddress := utils.GetEnvVariableOrDefault("SMART_PLUG_ADDRESS", "http://localhost:8085")
 	acAddress := utils.GetEnvVariableOrDefault("AC_ADDRESS", "http://localhost:8086")
+	doorLockAddress := utils.GetEnvVariableOrDefault("DOOR_LOCK_ADDRESS", "http://localhost:8092")
+	blindsAddress := utils.GetEnvVariableOrDefault("BLINDS_ADDRESS", "http://localhost:8093")
+	temperatureSensorAddress := utils.GetEnvVariableOrDefault("TEMPERATURE_SENSOR_ADDRESS", "http://localhost:8088")
+	humiditySensorAddress := utils.GetEnvVariableOrDefault("HUMIDITY_SENSOR_ADDRESS", "http://localhost:8089")
+	co2SensorAddress := utils.GetEnvVariableOrDefault("CO2_SENSOR_ADDRESS", "http://localhost:8090")
+	lightSensorAddress := utils.GetEnvVariableOrDefault("LIGHT_SENSOR_ADDRESS", "http://localhost:8091")
+	sensorPollInterval, err := utils.GetEnvVariableAsPositiveDurationOrDefault("SENSOR_POLL_INTERVAL", 5*time.Second)
+	if err != nil {
+		log.Fatal(err)
+	}
+	analogSensorPollInterval, err := utils.GetEnvVariableAsPositiveDurationOrDefault("ANALOG_SENSOR_POLL_INTERVAL", 30*time.Second)
+	if err != nil {
+		log.Fatal(err)
+	}
+	coverPollInterval, err := utils.GetEnvVariableAsPositiveDurationOrDefault("COVER_POLL_INTERVAL", time.Second)
+	if err != nil {
+		log.Fatal(err)
+	}
+	unlockTokenTTL, err := utils.GetEnvVariableAsDurationOrDefault("UNLOCK_TOKEN_TTL", 30*time.Second)
+	if err != nil {
+		log.Fatal(err)
+	}
+	energyPrice, err := utils.GetEnvVariableAsFloatOrDefault("ENERGY_PRICE_PER_KWH", 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tariff := domain.Tariff{PricePerKWh: energyPrice, Currency: utils.GetEnvVariableOrDefault("ENERGY_CURRENCY", "PLN")}
+	genericDevices, err := parseGenericDevices(utils.GetEnvVariableOrDefault("GENERIC_DEVICES", "[]"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	rooms, err := parseRooms(utils.GetEnvVariableOrDefault("ROOMS", "[]"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	roomAssignments, err := parseRoomAssignments(utils.GetEnvVariableOrDefault("ROOM_ASSIGNMENTS", "{}"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	groups, err := parseGroups(utils.GetEnvVariableOrDefault("GROUPS", "[]"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	groupMaxConcurrency, err := utils.GetEnvVariableAsIntOrDefault("GROUP_MAX_CONCURRENCY", 4)
+	if err != nil {
+		log.Fatal(err)
+	}
+	deviceRequestTimeout, err := utils.GetEnvVariableAsPositiveDurationOrDefault("DEVICE_REQUEST_TIMEOUT",
+		controlStationUtils.DefaultDeviceRequestTimeout)
+	if err != nil {
+		log.Fatal(err)
+	}
+	dataServiceRequestTimeout, err := utils.GetEnvVariableAsPositiveDurationOrDefault(
+		"DATA_SERVICE_REQUEST_TIMEOUT", controlStationUtils.DefaultDataServiceRequestTimeout)
+	if err != nil {
+		log.Fatal(err)
+	}
+	groupDeviceTimeout, err := utils.GetEnvVariableAsPositiveDurationOrDefault("GROUP_DEVICE_TIMEOUT", 5*time.Second)
+	if err != nil {
+		log.Fatal(err)
+	}
+	statusDeviceTimeout, err := utils.GetEnvVariableAsPositiveDurationOrDefault("STATUS_DEVICE_TIMEOUT", 2*time.Second)
+	if err != nil {
+		log.Fatal(err)
+	}
+	securityPIN := utils.GetEnvVariableOrDefault("SECURITY_PIN", "")
+	alarmWebhookURL := utils.GetEnvVariableOrDefault("ALARM_WEBHOOK_URL", "")
+	securityEntryDelay, err := utils.GetEnvVariableAsDurationOrDefault("SECURITY_ENTRY_DELAY", 30*time.Second)
+	if err != nil {
+		log.Fatal(err)
+	}
+	securityMaxPINAttempts, err := utils.GetEnvVariableAsIntOrDefault("SECURITY_MAX_PIN_ATTEMPTS", 5)
+	if err != nil {
+		log.Fatal(err)
+	}
+	securityPINLockout, err := utils.GetEnvVariableAsPositiveDurationOrDefault("SECURITY_PIN_LOCKOUT", 5*time.Minute)
+	if err != nil {
+		log.Fatal(err)
+	}
+	gasInterlockDevices, err := parseDeviceNames(utils.GetEnvVariableOrDefault("GAS_INTERLOCK_DEVICES", `["smartPlug", "ac"]`))
+	if err != nil {
+		log.Fatal(err)
+	}
+	thermostatMode := domain.ACMode(utils.GetEnvVariableOrDefault("THERMOSTAT_MODE", "off"))
+	if thermostatMode != "off" && thermostatMode != domain.ACModeCool && thermostatMode != domain.ACModeHeat {
+		log.Fatalf("Invalid thermostat mode: %s", thermostatMode)
+	}
+	thermostatTarget, err := utils.GetEnvVariableAsFloatOrDefault("THERMOSTAT_TARGET", 24)
+	if err != nil {
+		log.Fatal(err)
+	}
+	thermostatHysteresis, err := utils.GetEnvVariableAsFloatOrDefault("THERMOSTAT_HYSTERESIS", 0.5)
+	if err != nil {
+		log.Fatal(err)
+	}
+	thermostatMinOnTime, err := utils.GetEnvVariableAsDurationOrDefault("THERMOSTAT_MIN_ON_TIME", 3*time.Minute)
+	if err != nil {
+		log.Fatal(err)
+	}
+	thermostatMinOffTime, err := utils.GetEnvVariableAsDurationOrDefault("THERMOSTAT_MIN_OFF_TIME", 3*time.Minute)
+	if err != nil {
+		log.Fatal(err)
+	}
+	thermostatInterval, err := utils.GetEnvVariableAsPositiveDurationOrDefault("THERMOSTAT_INTERVAL", 30*time.Second)
+	if err != nil {
+		log.Fatal(err)
+	}
+	thermostatSchedule, err := parseThermostatSchedule(utils.GetEnvVariableOrDefault("THERMOSTAT_SCHEDULE", "[]"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	vacancyTimeout, err := utils.GetEnvVariableAsDurationOrDefault("VACANCY_TIMEOUT", 10*time.Minute)
+	if err != nil {
+		log.Fatal(err)
+	}
+	roomVacancyTimeouts, err := parseRoomTimeouts(utils.GetEnvVariableOrDefault("ROOM_VACANCY_TIMEOUTS", "{}"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	awayWindows, err := parseAwayWindows(utils.GetEnvVariableOrDefault("AWAY_WINDOWS", `[{"start": "18:00", "end": "23:30"}]`))
+	if err != nil {
+		log.Fatal(err)
+	}
+	awaySimulationInterval, err := utils.GetEnvVariableAsPositiveDurationOrDefault("AWAY_SIMULATION_INTERVAL", 15*time.Minute)
+	if err != nil {
+		log.Fatal(err)
+	}
+	awayHistoryLimit, err := utils.GetEnvVariableAsIntOrDefault("AWAY_HISTORY_LIMIT", 5000)
+	if err != nil {
+		log.Fatal(err)
+	}
+	awayACSetpoint, err := parseOptionalTemperature(utils.GetEnvVariableOrDefault("AWAY_AC_SETPOINT", ""))
+	if err != nil {
+		log.Fatal(err)
+	}
+	manualOverrideWindow, err := utils.GetEnvVariableAsDurationOrDefault("MANUAL_OVERRIDE_WINDOW", time.Hour)
+	if err != nil {
+		log.Fatal(err)
+	}
+	logQueryMaxLimit, err := utils.GetEnvVariableAsIntOrDefault("LOG_QUERY_MAX_LIMIT", controlStationUtils.DefaultMaxLogQueryLimit)
+	if err != nil {
+		log.Fatal(err)
+	}
+	logQueryScanLimit, err := utils.GetEnvVariableAsIntOrDefault("LOG_QUERY_SCAN_LIMIT", controlStationUtils.DefaultLogQueryScanLimit)
+	if err != nil {
+		log.Fatal(err)
+	}
+	analyticsMaxSampleGap, err := utils.GetEnvVariableAsDurationOrDefault("ANALYTICS_MAX_SAMPLE_GAP", 10*time.Minute)
+	if err != nil {
+		log.Fatal(err)
+	}
+	analyticsMaxRange, err := utils.GetEnvVariableAsDurationOrDefault("ANALYTICS_MAX_RANGE", 90*24*time.Hour)
+	if err != nil {
+		log.Fatal(err)
+	}
+	analyticsLocation, err := time.LoadLocation(utils.GetEnvVariableOrDefault("ANALYTICS_TIMEZONE", "Local"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	acPowerOnPolicy, err := domain.ParseACPowerOnPolicy(utils.GetEnvVariableOrDefault("AC_POWER_ON_POLICY", "always"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	smartBulbCapabilities, err := parseLightCapabilities(utils.GetEnvVariableOrDefault("SMART_BULB_CAPABILITIES",
+		`{"dimmable": true, "min_color_temperature": 2700, "max_color_temperature": 6500, "supports_rgb": true, "max_transition_ms": 10000}`))
+	if err != nil {
+		log.Fatal(err)
+	}
+	acCapabilities, err := parseACCapabilities(utils.GetEnvVariableOrDefault("AC_CAPABILITIES", "{}"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	trustedProxies, err := parseTrustedProxies(utils.GetEnvVariableOrDefault("TRUSTED_PROXIES", "[]"))
+	if err != nil {
+		log.Fatal(err)
+	}
 
+	exposedHeaders := []string{"Content-Length", "Content-Disposition", "ETag", httpUtils.NextCursorHeader,
+		httpUtils.TruncatedHeader, httpUtils.ScannedFromHeader}
 	r := gin.Default()
+	// The client IP identifies the requester, e.g. of unlock confirmations, so
+	// X-Forwarded-For is only honoured from the configured proxies.
+	err = r.SetTrustedProxies(trustedProxies)
+	if err != nil {
+		log.Fatal(err)
+	}
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "PATCH"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "POST", "PATCH"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "If-Match", maintenanceHttp.OverrideHeader},
+		ExposeHeaders:    exposedHeaders,
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
-	initializeSensor("presenceSensor", presenceSensorAddress, "/presenceSensor", r)
-	initializeSensor("gasSensor", gasSensorAddress, "/gasSensor", r)
-	initializeSensor("doorsSensor", doorsSensorAddress, "/doorsSensor", r)
-	initializeDevice("smartBulb", smartBulbAddress, "/smartBulb", r)
-	initializeDevice("smartPlug", smartPlugAddress, "/smartPlug", r)
-	initializeAC("ac", acAddress, "/ac", r)
+	deviceRegistry := registry.NewRegistry()
+	eventBus := event.NewBus()
+	stateCache := state.NewCache()
+	deviceConfig := domain.DeviceConfig{ConfirmationTTL: unlockTokenTTL, PollInterval: coverPollInterval}
+	interlock := safetyService.NewInterlock()
+	maintenance := maintenanceService.NewMaintenanceService(deviceRegistry)
+	controlStationUtils.SetLogTagger(maintenanceService.Tags(maintenance))
+	controlStationUtils.SetLogQueryLimits(logQueryMaxLimit, logQueryScanLimit)
+	controlStationUtils.SetDeviceRequestTimeout(deviceRequestTimeout)
+	controlStationUtils.SetDataServiceRequestTimeout(dataServiceRequestTimeout)
+	alertService := maintenanceService.MuteAlerts(alertService.NewAlertService(alarmWebhookURL), maintenance)
+	r.Use(maintenanceHttp.Guard(maintenance, deviceRegistry))
+	http.SetupMaintenanceRouter(r, maintenanceHttp.NewMaintenanceHandler(maintenance))
+	arbitration := arbitrationService.NewArbitrationService(deviceRegistry, manualOverrideWindow)
+	r.Use(arbitrationHttp.Track(arbitration, deviceRegistry))
+	http.SetupControlRouter(r, arbitrationHttp.NewArbitrationHandler(arbitration))
+	for _, room := range rooms {
+		if err := deviceRegistry.RegisterRoom(room); err != nil {
+			log.Fatalf("Failed to register room '%s': %s", room.ID, err)
+		}
+	}
+
+	sensorCapabilities := []domain.Capability{domain.CapabilitySwitchable, domain.CapabilityDetectable}
+	presenceSensor := initializeDevice(domain.RegisteredDevice{Name: "presenceSensor", Kind: domain.KindSensor,
+		Address: presenceSensorAddress, Route: "/presenceSensor", Capabilities: sensorCapabilities},
+		r, deviceRegistry, eventBus, sensorPollInterval, stateCache, deviceConfig)
+	gasSensor := initializeDevice(domain.RegisteredDevice{Name: "gasSensor", Kind: domain.KindSensor,
+		Address: gasSensorAddress, Route: "/gasSensor", Capabilities: sensorCapabilities},
+		r, deviceRegistry, eventBus, sensorPollInterval, stateCache, deviceConfig)
+	doorsSensor := initializeDevice(domain.RegisteredDevice{Name: "doorsSensor", Kind: domain.KindSensor,
+		Address: doorsSensorAddress, Route: "/doorsSensor", Capabilities: sensorCapabilities},
+		r, deviceRegistry, eventBus, sensorPollInterval, stateCache, deviceConfig)
+	doorLock := initializeDevice(domain.RegisteredDevice{Name: "doorLock", Kind: domain.KindLock,
+		Address: doorLockAddress, Route: "/doorLock", Capabilities: []domain.Capability{domain.CapabilityLockable}},
+		r, deviceRegistry, eventBus, sensorPollInterval, stateCache, deviceConfig)
+	blinds := initializeDevice(domain.RegisteredDevice{Name: "blinds", Kind: domain.KindCover,
+		Address: blindsAddress, Route: "/blinds", Capabilities: []domain.Capability{domain.CapabilityPositionable}},
+		r, deviceRegistry, eventBus, sensorPollInterval, stateCache, deviceConfig)
+	smartBulb := initializeLight("smartBulb", smartBulbAddress, "/smartBulb", smartBulbCapabilities, r, deviceRegistry)
+	smartPlug := initializePlug("smartPlug", smartPlugAddress, "/smartPlug", tariff, r, deviceRegistry, interlock)
+	ac := initializeAC("ac", acAddress, "/ac", acCapabilities, acPowerOnPolicy, r, deviceRegistry, interlock)
+	temperatureSensor := initializeAnalogSensor("temperatureSensor", temperatureSensorAddress, "/temperatureSensor", "°C",
+		domain.AnalogThreshold{Above: float32Ptr(30), Hysteresis: 1}, analogSensorPollInterval, r, deviceRegistry, eventBus, alertService)
+	humiditySensor := initializeAnalogSensor("humiditySensor", humiditySensorAddress, "/humiditySensor", "%",
+		domain.AnalogThreshold{Above: float32Ptr(70), Hysteresis: 3}, analogSensorPollInterval, r, deviceRegistry, eventBus, alertService)
+	co2Sensor := initializeAnalogSensor("co2Sensor", co2SensorAddress, "/co2Sensor", "ppm",
+		domain.AnalogThreshold{Above: float32Ptr(1000), Hysteresis: 100}, analogSensorPollInterval, r, deviceRegistry, eventBus, alertService)
+	lightSensor := initializeAnalogSensor("lightSensor", lightSensorAddress, "/lightSensor", "lx",
+		domain.AnalogThreshold{Below: float32Ptr(10), Hysteresis: 5}, analogSensorPollInterval, r, deviceRegistry, eventBus, alertService)
+
+	statusReaders := map[string]domain.StatusReader{
+		"presenceSensor":    statusReader(presenceSensor.ReadInfo),
+		"gasSensor":         statusReader(gasSensor.ReadInfo),
+		"doorsSensor":       statusReader(doorsSensor.ReadInfo),
+		"doorLock":          statusReader(doorLock.ReadInfo),
+		"blinds":            statusReader(blinds.ReadInfo),
+		"smartBulb":         statusReader(smartBulb.ReadInfo),
+		"smartPlug":         statusReader(smartPlug.ReadInfo),
+		"ac":                statusReader(ac.ReadInfo),
+		"temperatureSensor": statusReader(temperatureSensor.ReadInfo),
+		"humiditySensor":    statusReader(humiditySensor.ReadInfo),
+		"co2Sensor":         statusReader(co2Sensor.ReadInfo),
+		"lightSensor":       statusReader(lightSensor.ReadInfo),
+	}
+	switches := map[string]groupService.Switch{
+		"presenceSensor":    newDeviceSwitch(presenceSensor),
+		"gasSensor":         newDeviceSwitch(gasSensor),
+		"doorsSensor":       newDeviceSwitch(doorsSensor),
+		"smartBulb":         newSwitch(smartBulb.ReadInfo, smartBulb.ToggleEnabled),
+		"smartPlug":         newSwitch(smartPlug.ReadInfo, smartPlug.ToggleEnabled),
+		"blinds":            newCoverSwitch(blinds),
+		"ac":                newSwitch(ac.ReadInfo, ac.ToggleEnabled),
+		"temperatureSensor": newSwitch(temperatureSensor.ReadInfo, temperatureSensor.ToggleEnabled),
+		"humiditySensor":    newSwitch(humiditySensor.ReadInfo, humiditySensor.ToggleEnabled),
+		"co2Sensor":         newSwitch(co2Sensor.ReadInfo, co2Sensor.ToggleEnabled),
+		"lightSensor":       newSwitch(lightSensor.ReadInfo, lightSensor.ToggleEnabled),
+	}
+	for _, device := range genericDevices {
+		genericDevice := initializeDevice(device, r, deviceRegistry, eventBus, sensorPollInterval,
+			stateCache, deviceConfig)
+		statusReaders[device.Name] = statusReader(genericDevice.ReadInfo)
+		if domain.HasCapability(device.Capabilities, domain.CapabilitySwitchable) {
+			switches[device.Name] = newDeviceSwitch(genericDevice)
+		}
+	}
+	http.SetupExportRouter(r, exportHttp.NewExportHandler(exportService.NewExportService(deviceRegistry)),
+		deviceRegistry.List())
+	http.SetupTimelineRouter(r, timelineHttp.NewTimelineHandler(timelineService.NewTimelineService(deviceRegistry)))
+
+	// Automation and bulk commands leave the devices in maintenance alone, the
+	// safety service keeps acting on every device.
+	automationSwitches := maintenanceService.SkipSwitches(switches, maintenance)
+	automationAC := maintenanceService.SkipAC(ac, "ac", maintenance)
+
+	for deviceName, roomID := range roomAssignments {
+		if err := deviceRegistry.AssignRoom(deviceName, roomID); err != nil {
+			log.Fatalf("Failed to assign '%s' to room '%s': %s", deviceName, roomID, err)
+		}
+	}
+	lights := map[string]lightService.LightService{"smartBulb": smartBulb}
+
+	for _, group := range groups {
+		if err := deviceRegistry.RegisterGroup(group); err != nil {
+			log.Fatalf("Failed to register group '%s': %s", group.Name, err)
+		}
+	}
+	userSwitches := arbitrationService.Switches(automationSwitches, arbitration, domain.SourceUser)
+	groupService := groupService.NewGroupService(deviceRegistry, userSwitches, groupMaxConcurrency, groupDeviceTimeout)
+	http.SetupGroupRouter(r, groupHttp.NewGroupHandler(groupService))
+
+	userLights := map[string]lightService.LightService{}
+	for name, light := range lights {
+		userLights[name] = arbitrationService.Light(maintenanceService.SkipLight(light, name, maintenance), name,
+			arbitration, domain.SourceUser)
+	}
+	roomService := roomService.NewRoomService(deviceRegistry, statusReaders, userSwitches, userLights)
+	http.SetupRoomRouter(r, roomHttp.NewRoomHandler(roomService))
+
+	occupancyConfig := domain.OccupancyConfig{
+		PresenceSensors: []string{"presenceSensor"},
+		DoorSensors:     []string{"doorsSensor"},
+		VacancyTimeout:  vacancyTimeout,
+		RoomTimeouts:    roomVacancyTimeouts,
+	}
+	occupancyService := occupancyService.NewOccupancyService(occupancyConfig, deviceRegistry,
+		arbitrationService.Switches(automationSwitches, arbitration, domain.SourceRule), arbitration, eventBus)
+	http.SetupOccupancyRouter(r, occupancyHttp.NewOccupancyHandler(occupancyService, manualOverrideWindow))
+
+	analyticsConfig := domain.AnalyticsConfig{
+		PresenceSensors: occupancyConfig.PresenceSensors,
+		DoorSensors:     occupancyConfig.DoorSensors,
+		MaxSampleGap:    analyticsMaxSampleGap,
+		MaxRange:        analyticsMaxRange,
+		Location:        analyticsLocation,
+	}
+	analyticsService := analyticsService.NewAnalyticsService(analyticsConfig, deviceRegistry)
+	http.SetupAnalyticsRouter(r, analyticsHttp.NewAnalyticsHandler(analyticsService))
+
+	statusService := statusService.NewStatusService(deviceRegistry, statusReaders, stateCache, statusDeviceTimeout)
+	http.SetupStatusRouter(r, statusHttp.NewStatusHandler(statusService))
+
+	for _, device := range gasInterlockDevices {
+		if _, ok := switches[device]; !ok {
+			log.Fatalf("Gas interlock device '%s' can not be switched off", device)
+		}
+		if !interlock.Guards(device) {
+			log.Fatalf("Gas interlock device '%s' can not be kept off by the interlock", device)
+		}
+	}
+	safetyConfig := domain.SafetyConfig{GasSensors: []string{"gasSensor"}, ProtectedDevices: gasInterlockDevices}
+	safetyService := safetyService.NewSafetyService(safetyConfig, interlock,
+		arbitrationService.Switches(switches, arbitration, domain.SourceSafety), eventBus, alertService)
+	http.SetupSafetyRouter(r, safetyHttp.NewSafetyHandler(safetyService))
+
+	var security securityService.SecurityService
+	if securityPIN == "" {
+		log.Println("SECURITY_PIN is not set, the security subsystem is disabled")
+	} else {
+		securityConfig := domain.SecurityConfig{
+			AwaySensors:    []string{"doorsSensor", "presenceSensor"},
+			HomeSensors:    []string{"doorsSensor"},
+			EntryDelay:     securityEntryDelay,
+			MaxPINAttempts: securityMaxPINAttempts,
+			PINLockout:     securityPINLockout,
+		}
+		alarmActions := []securityService.AlarmAction{
+			securityService.LightsOnAction(lights),
+			securityService.NotifyAction(alertService),
+		}
+		security = securityService.NewSecurityService(securityConfig, securityPIN, eventBus, alarmActions)
+		http.SetupSecurityRouter(r, securityHttp.NewSecurityHandler(security))
+	}
+
+	awayConfig := domain.AwayConfig{
+		AC:                 "ac",
+		ACSetpoint:         awayACSetpoint,
+		SimulatedDevices:   []string{"smartBulb", "smartPlug"},
+		Windows:            awayWindows,
+		SimulationInterval: awaySimulationInterval,
+		HistoryLimit:       awayHistoryLimit,
+	}
+	awayService := awayService.NewAwayService(awayConfig,
+		arbitrationService.AC(automationAC, "ac", arbitration, domain.SourceUser), userSwitches,
+		arbitrationService.Switches(automationSwitches, arbitration, domain.SourceSchedule), security)
+	http.SetupAwayRouter(r, awayHttp.NewAwayHandler(awayService))
+
+	if thermostatMode == "off" {
+		log.Println("THERMOSTAT_MODE is off, the thermostat controller is disabled")
+	} else {
+		thermostatConfig := domain.ThermostatConfig{
+			Sensor:         "temperatureSensor",
+			AC:             "ac",
+			ACCapabilities: ac.GetCapabilities(),
+			Mode:           thermostatMode,
+			Target:         float32(thermostatTarget),
+			Hysteresis:     float32(thermostatHysteresis),
+			MinOnTime:      thermostatMinOnTime,
+			MinOffTime:     thermostatMinOffTime,
+			Schedule:       thermostatSchedule,
+		}
+		if err := thermostatService.ValidateConfig(thermostatConfig); err != nil {
+			log.Fatalf("Invalid thermostat configuration for '%s': %s", thermostatConfig.AC, err)
+		}
+		thermostatService := thermostatService.NewThermostatService(thermostatConfig, temperatureSensor,
+			arbitrationService.AC(automationAC, "ac", arbitration, domain.SourceRule))
+		http.SetupThermostatRouter(r, thermostatHttp.NewThermostatHandler(thermostatService))
+		startPolling("thermostat", thermostatInterval, thermostatService.Evaluate)
+	}
 
 	log.Printf("Starting service at %s\n", serviceAddress)
 	log.Fatal(r.Run(serviceAddress))
 }
 
-func initializeSensor(name string, address string, groupName string, r *gin.Engine) {
-	sensor := domain.Sensor{Name: name, Address: address}
-	sensorService := sensorService.NewSensorService(&sensor)
-	sensorHandler := sensorHttp.NewSensorHandler(sensorService)
-	http.SetupSensorRouter(r, sensorHandler, groupName)
+func initializeDevice(registeredDevice domain.RegisteredDevice, r *gin.Engine, deviceRegistry registry.Registry,
+	eventBus event.Bus, pollInterval time.Duration, stateCache state.Cache,
+	config domain.DeviceConfig) deviceService.DeviceService {
+	registerDevice(deviceRegistry, registeredDevice)
+	device := domain.Device{Name: registeredDevice.Name, Address: registeredDevice.Address, Capabilities: registeredDevice.Capabilities,
+		LightCapabilities: registeredDevice.LightCapabilities, ACCapabilities: registeredDevice.ACCapabilities}
+	deviceService := deviceService.NewDeviceService(&device, eventBus, stateCache, config)
+	deviceHandler := deviceHttp.NewDeviceHandler(deviceService)
+	http.SetupDeviceRouter(r, deviceHandler, registeredDevice.Route, registeredDevice.Capabilities)
+	if domain.HasCapability(registeredDevice.Capabilities, domain.CapabilityDetectable) {
+		startPolling(registeredDevice.Name, pollInterval, func() error {
+			_, err := deviceService.GetInfo()
+			return err
+		})
+	}
+	return deviceService
 }
 
-func initializeDevice(name string, address string, groupName string, r *gin.Engine) {
-	device := domain.Device{Name: name, Address: address}
-	deviceService := deviceService.NewDeviceService(&device)
-	deviceHandler := deviceHttp.NewDeviceHandler(deviceService)
-	http.SetupDeviceRouter(r, deviceHandler, groupName)
+func initializeLight(name string, address string, groupName string, capabilities domain.LightCapabilities,
+	r *gin.Engine, deviceRegistry registry.Registry) lightService.LightService {
+	deviceCapabilities := []domain.Capability{domain.CapabilitySwitchable}
+	if capabilities.Dimmable {
+		deviceCapabilities = append(deviceCapabilities, domain.CapabilityDimmable)
+	}
+	if capabilities.SupportsColorTemperature() || capabilities.SupportsRGB || capabilities.SupportsHSV {
+		deviceCapabilities = append(deviceCapabilities, domain.CapabilityColor)
+	}
+	registerDevice(deviceRegistry, domain.RegisteredDevice{Name: name, Kind: domain.KindLight, Address: address, Route: groupName,
+		Capabilities: deviceCapabilities, LightCapabilities: &capabilities})
+	light := domain.Light{Name: name, Address: address}
+	lightService := lightService.NewLightService(&light, capabilities)
+	lightHandler := lightHttp.NewLightHandler(lightService)
+	http.SetupLightRouter(r, lightHandler, groupName)
+	return lightService
+}
+
+func initializePlug(name string, address string, groupName string, tariff domain.Tariff, r *gin.Engine,
+	deviceRegistry registry.Registry, interlock *safetyService.Interlock) plugService.PlugService {
+	registerDevice(deviceRegistry, domain.RegisteredDevice{Name: name, Kind: domain.KindPlug, Address: address, Route: groupName,
+		Capabilities: []domain.Capability{domain.CapabilitySwitchable, domain.CapabilityMetering}})
+	plug := domain.Plug{Name: name, Address: address}
+	plugService := safetyService.GuardPlug(plugService.NewPlugService(&plug, tariff), name, interlock)
+	plugHandler := plugHttp.NewPlugHandler(plugService)
+	http.SetupPlugRouter(r, plugHandler, groupName)
+	return plugService
 }
 
-func initializeAC(name string, address string, groupName string, r *gin.Engine) {
+func initializeAC(name string, address string, groupName string, capabilities domain.ACCapabilities,
+	powerOnPolicy domain.ACPowerOnPolicy, r *gin.Engine, deviceRegistry registry.Registry,
+	interlock *safetyService.Interlock) acService.ACService {
+	registerDevice(deviceRegistry, domain.RegisteredDevice{Name: name, Kind: domain.KindAC, Address: address, Route: groupName,
+		Capabilities:   []domain.Capability{domain.CapabilitySwitchable, domain.CapabilityThermostat},
+		ACCapabilities: &capabilities})
 	ac := domain.AC{Name: name, Address: address}
-	acService := acService.NewACService(&ac)
+	acService := safetyService.GuardAC(acService.NewACService(&ac, capabilities, powerOnPolicy), name, interlock)
 	acHandler := acHttp.NewACHandler(acService)
 	http.SetupACRouter(r, acHandler, groupName)
+	return acService
+}
+
+func initializeAnalogSensor(name string, address string, groupName string, unit string, threshold domain.AnalogThreshold,
+	pollInterval time.Duration, r *gin.Engine, deviceRegistry registry.Registry, eventBus event.Bus,
+	alerts alertService.AlertService) analogSensorService.AnalogSensorService {
+	registerDevice(deviceRegistry, domain.RegisteredDevice{Name: name, Kind: domain.KindAnalogSensor, Address: address, Route: groupName,
+		Capabilities: []domain.Capability{domain.CapabilitySwitchable, domain.CapabilityMeasuring, domain.CapabilityDetectable},
+		Unit:         unit,
+		Threshold:    &threshold})
+	sensor := domain.AnalogSensor{Name: name, Address: address, Unit: unit, Threshold: threshold}
+	analogSensorService := analogSensorService.NewAnalogSensorService(&sensor, eventBus, alerts)
+	analogSensorHandler := analogSensorHttp.NewAnalogSensorHandler(analogSensorService)
+	http.SetupAnalogSensorRouter(r, analogSensorHandler, groupName)
+	startPolling(name, pollInterval, func() error {
+		_, err := analogSensorService.GetInfo()
+		return err
+	})
+	return analogSensorService
+}
+
+func startPolling(name string, interval time.Duration, poll func() error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := poll(); err != nil {
+				log.Printf("Failed to poll '%s': %s\n", name, err)
+			}
+		}
+	}()
+}
+
+// parseGenericDevices reads devices that are served by the generic device stack
+// from a JSON list, e.g. [{"name": "fan", "address": "http://localhost:8094",
+// "route": "/fan", "capabilities": ["switchable"]}]. Dimmable and color
+// devices may describe their features in "light_capabilities" and thermostat
+// devices in "ac_capabilities".
+func parseGenericDevices(value string) ([]domain.RegisteredDevice, error) {
+	var devices []domain.RegisteredDevice
+	err := json.Unmarshal([]byte(value), &devices)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid generic devices: %s", err)
+	}
+
+	for i := range devices {
+		if devices[i].Kind == "" {
+			devices[i].Kind = domain.KindDevice
+		}
+		if devices[i].Route == "" {
+			devices[i].Route = "/" + devices[i].Name
+		}
+		for _, capability := range devices[i].Capabilities {
+			if _, err := domain.ParseCapability(string(capability)); err != nil {
+				return nil, fmt.Errorf("Invalid generic device '%s': %s", devices[i].Name, err)
+			}
+		}
+	}
+	return devices, nil
+}
+
+// parseLightCapabilities reads the capabilities assumed for a bulb that can
+// not be asked for them, e.g. {"dimmable": true, "supports_rgb": true}.
+func parseLightCapabilities(value string) (domain.LightCapabilities, error) {
+	capabilities := domain.DefaultLightCapabilities()
+	if err := json.Unmarshal([]byte(value), &capabilities); err != nil {
+		return capabilities, fmt.Errorf("Invalid light capabilities: %s", err)
+	}
+	if capabilities.MinColorTemperature > capabilities.MaxColorTemperature || capabilities.MaxTransitionMs < 0 {
+		return capabilities, fmt.Errorf("Invalid light capabilities: %+v", capabilities)
+	}
+	return capabilities, nil
+}
+
+// parseACCapabilities reads the features of the AC unit from a JSON object
+// overriding the legacy defaults, e.g. {"modes": ["cool", "heat"],
+// "fan_speeds": 3, "supports_swing": true}.
+func parseACCapabilities(value string) (domain.ACCapabilities, error) {
+	capabilities := domain.DefaultACCapabilities()
+	if err := json.Unmarshal([]byte(value), &capabilities); err != nil {
+		return capabilities, fmt.Errorf("Invalid AC capabilities: %s", err)
+	}
+
+	for _, mode := range capabilities.Modes {
+		if !domain.IsKnownACMode(mode) {
+			return capabilities, fmt.Errorf("Invalid AC capabilities: unknown mode %s", mode)
+		}
+	}
+	if capabilities.FanSpeeds < 0 {
+		return capabilities, fmt.Errorf("Invalid AC capabilities: fan_speeds must not be negative")
+	}
+	if capabilities.MinTemperature > capabilities.MaxTemperature || capabilities.MinHumidity > capabilities.MaxHumidity {
+		return capabilities, fmt.Errorf("Invalid AC capabilities: minimum above maximum")
+	}
+	return capabilities, nil
+}
+
+// parseRooms reads the rooms from a JSON list, e.g. [{"id": "livingRoom",
+// "name": "Living room", "zone": "downstairs"}].
+func parseRooms(value string) ([]domain.Room, error) {
+	var rooms []domain.Room
+	err := json.Unmarshal([]byte(value), &rooms)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid rooms: %s", err)
+	}
+	return rooms, nil
+}
+
+// parseRoomAssignments reads the room of every device from a JSON object, e.g.
+// {"smartBulb": "livingRoom", "ac": "bedroom"}.
+func parseRoomAssignments(value string) (map[string]string, error) {
+	var assignments map[string]string
+	err := json.Unmarshal([]byte(value), &assignments)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid room assignments: %s", err)
+	}
+	return assignments, nil
+}
+
+// parseGroups reads the device groups from a JSON list, e.g. [{"name":
+// "allPlugs", "members": ["smartPlug"]}].
+func parseGroups(value string) ([]domain.Group, error) {
+	var groups []domain.Group
+	err := json.Unmarshal([]byte(value), &groups)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid groups: %s", err)
+	}
+	return groups, nil
+}
+
+// parseDeviceNames reads a JSON list of device names, e.g. ["smartPlug", "ac"].
+func parseDeviceNames(value string) ([]string, error) {
+	var names []string
+	err := json.Unmarshal([]byte(value), &names)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid device names: %s", err)
+	}
+	return names, nil
+}
+
+// parseTrustedProxies reads a JSON list of the IP addresses or CIDR ranges of
+// the proxies whose forwarded client IP is trusted, e.g. ["10.0.0.0/8"].
+func parseTrustedProxies(value string) ([]string, error) {
+	var proxies []string
+	err := json.Unmarshal([]byte(value), &proxies)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid trusted proxies: %s", err)
+	}
+	return proxies, nil
+}
+
+// parseRoomTimeouts reads the vacancy timeouts of the rooms from a JSON object,
+// e.g. {"bathroom": "5m", "livingRoom": "30m"}.
+func parseRoomTimeouts(value string) (map[string]time.Duration, error) {
+	var values map[string]string
+	err := json.Unmarshal([]byte(value), &values)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid room timeouts: %s", err)
+	}
+
+	timeouts := make(map[string]time.Duration, len(values))
+	for roomID, value := range values {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid room timeout of '%s': %s", roomID, err)
+		}
+		timeouts[roomID] = timeout
+	}
+	return timeouts, nil
+}
+
+// parseAwayWindows reads the presence simulation windows from a JSON list, e.g.
+// [{"start": "18:00", "end": "23:30"}].
+func parseAwayWindows(value string) ([]domain.AwayWindow, error) {
+	var windows []domain.AwayWindow
+	err := json.Unmarshal([]byte(value), &windows)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid away windows: %s", err)
+	}
+
+	for _, window := range windows {
+		if _, err := window.Contains(0); err != nil {
+			return nil, fmt.Errorf("Invalid away windows: %s", err)
+		}
+	}
+	return windows, nil
+}
+
+// parseOptionalTemperature returns nil for an empty value.
+func parseOptionalTemperature(value string) (*float32, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	temperature, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid temperature: %s", err)
+	}
+	return float32Ptr(float32(temperature)), nil
+}
+
+// parseThermostatSchedule reads the target temperature periods of the day from a
+// JSON list, e.g. [{"start": "07:00", "target": 22}, {"start": "23:00",
+// "target": 19}].
+func parseThermostatSchedule(value string) ([]domain.ThermostatPeriod, error) {
+	var schedule []domain.ThermostatPeriod
+	err := json.Unmarshal([]byte(value), &schedule)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid thermostat schedule: %s", err)
+	}
+
+	for _, period := range schedule {
+		if _, err := period.StartMinute(); err != nil {
+			return nil, fmt.Errorf("Invalid thermostat schedule: %s", err)
+		}
+	}
+	return schedule, nil
+}
+
+// newSwitch reads the state without logging it. Both the read and the toggle
+// are bound to the context, so that a caller that stops waiting also aborts
+// the request to the device.
+func newSwitch[V domain.Switchable](readInfo func(ctx context.Context) (V, error),
+	toggleEnabled func(ctx context.Context) (V, error)) groupService.Switch {
+	return groupService.Switch{
+		GetInfo: func(ctx context.Context) (domain.Switchable, error) {
+			info, err := readInfo(ctx)
+			return info, err
+		},
+		ToggleEnabled: func(ctx context.Context) (domain.Switchable, error) {
+			info, err := toggleEnabled(ctx)
+			return info, err
+		},
+	}
+}
+
+func newDeviceSwitch(device deviceService.DeviceService) groupService.Switch {
+	return newSwitch(device.ReadInfo, func(ctx context.Context) (domain.DeviceState, error) {
+		return device.Invoke(ctx, domain.CapabilitySwitchable, controlStationUtils.EnabledEndpoint, nil, "")
+	})
+}
+
+// newCoverSwitch closes a cover that is open or opening and opens it otherwise,
+// so that covers can be switched like the other devices.
+func newCoverSwitch(device deviceService.DeviceService) groupService.Switch {
+	readInfo := func(ctx context.Context) (domain.CoverInfo, error) {
+		deviceState, err := device.ReadInfo(ctx)
+		if err != nil {
+			return domain.CoverInfo{}, err
+		}
+		return decodeState[domain.CoverInfo](deviceState)
+	}
+	return newSwitch(readInfo, func(ctx context.Context) (domain.CoverInfo, error) {
+		coverInfo, err := readInfo(ctx)
+		if err != nil {
+			return coverInfo, err
+		}
+		endpoint := controlStationUtils.OpenEndpoint
+		if coverInfo.IsEnabled() {
+			endpoint = controlStationUtils.CloseEndpoint
+		}
+		deviceState, err := device.Invoke(ctx, domain.CapabilityPositionable, endpoint, nil, "")
+		if err != nil {
+			return domain.CoverInfo{}, err
+		}
+		return decodeState[domain.CoverInfo](deviceState)
+	})
+}
+
+func decodeState[V any](deviceState domain.DeviceState) (V, error) {
+	var value V
+	encoded, err := json.Marshal(deviceState)
+	if err == nil {
+		err = json.Unmarshal(encoded, &value)
+	}
+	return value, err
+}
+
+func statusReader[V any](readInfo func(ctx context.Context) (V, error)) domain.StatusReader {
+	return func(ctx context.Context) (any, error) {
+		info, err := readInfo(ctx)
+		return info, err
+	}
+}
+
+func float32Ptr(v float32) *float32 {
+	return &v
+}
+
+func registerDevice(deviceRegistry registry.Registry, device domain.RegisteredDevice) {
+	if err := deviceRegistry.Register(device); err != nil {
+		log.Fatalf("Failed to register '%s': %s", device.Name, err)
+	}
 }
@@ -1,16 +1,41 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type AC struct {
 	Name    string `json:"name"`
 	Address string `json:"address"`
 }
 
+type ACMode string
+
+const (
+	ACModeCool ACMode = "cool"
+	ACModeHeat ACMode = "heat"
+	ACModeDry  ACMode = "dry"
+	ACModeFan  ACMode = "fan"
+	ACModeAuto ACMode = "auto"
+)
+
+// Mode, FanSpeed, Swing, Eco and Turbo are optional, so that units running
+// firmware which reports only the power state, temperature and humidity are
+// still understood, and are never sent settings they do not know about.
 type ACInfo struct {
 	Enabled     bool    `json:"enabled"`
 	Temperature float32 `json:"temperature"`
 	Humidity    float32 `json:"humidity"`
+	Mode        ACMode  `json:"mode,omitempty"`
+	FanSpeed    int     `json:"fan_speed,omitempty"`
+	Swing       *bool   `json:"swing,omitempty"`
+	Eco         *bool   `json:"eco,omitempty"`
+	Turbo       *bool   `json:"turbo,omitempty"`
+}
+
+func (i ACInfo) IsEnabled() bool {
+	return i.Enabled
 }
 
 type ACData struct {
@@ -19,4 +44,89 @@ type ACData struct {
 	IsEnabled   bool      `json:"is_enabled" db:"is_enabled"`
 	Temperature float32   `json:"temperature" db:"temperature"`
 	Humidity    float32   `json:"humidity" db:"humidity"`
+	Mode        ACMode    `json:"mode,omitempty" db:"mode"`
+	FanSpeed    int       `json:"fan_speed,omitempty" db:"fan_speed"`
+	Swing       *bool     `json:"swing,omitempty" db:"swing"`
+	Eco         *bool     `json:"eco,omitempty" db:"eco"`
+	Turbo       *bool     `json:"turbo,omitempty" db:"turbo"`
+}
+
+type ACCapabilities struct {
+	MinTemperature  float32  `json:"min_temperature"`
+	MaxTemperature  float32  `json:"max_temperature"`
+	TemperatureStep float32  `json:"temperature_step"`
+	MinHumidity     float32  `json:"min_humidity"`
+	MaxHumidity     float32  `json:"max_humidity"`
+	HumidityStep    float32  `json:"humidity_step"`
+	Modes           []ACMode `json:"modes"`
+	FanSpeeds       int      `json:"fan_speeds"`
+	SupportsSwing   bool     `json:"supports_swing"`
+	SupportsEco     bool     `json:"supports_eco"`
+	SupportsTurbo   bool     `json:"supports_turbo"`
+}
+
+// DefaultACCapabilities describes what every unit supports, including those
+// running firmware that knows only the power state, temperature and humidity.
+// Modes, fan speeds, swing, eco and turbo have to be enabled explicitly.
+func DefaultACCapabilities() ACCapabilities {
+	return ACCapabilities{
+		MinTemperature:  16,
+		MaxTemperature:  30,
+		TemperatureStep: 0.5,
+		MinHumidity:     30,
+		MaxHumidity:     70,
+		HumidityStep:    1,
+	}
+}
+
+// ExtendedACCapabilities describes a unit supporting all modes, three fan
+// speeds, swing, eco and turbo.
+func ExtendedACCapabilities() ACCapabilities {
+	capabilities := DefaultACCapabilities()
+	capabilities.Modes = []ACMode{ACModeCool, ACModeHeat, ACModeDry, ACModeFan, ACModeAuto}
+	capabilities.FanSpeeds = 3
+	capabilities.SupportsSwing = true
+	capabilities.SupportsEco = true
+	capabilities.SupportsTurbo = true
+	return capabilities
+}
+
+// ACPowerOnPolicy decides what happens to the power state of the unit when
+// its settings are updated.
+type ACPowerOnPolicy string
+
+const (
+	// ACPowerOnAlways turns the unit on with every settings update.
+	ACPowerOnAlways ACPowerOnPolicy = "always"
+	// ACPowerOnKeep leaves the power state of the unit unchanged.
+	ACPowerOnKeep ACPowerOnPolicy = "keep"
+	// ACPowerOnRequest applies the enabled field of the submitted settings.
+	ACPowerOnRequest ACPowerOnPolicy = "request"
+)
+
+func (c ACCapabilities) SupportsMode(mode ACMode) bool {
+	for _, supportedMode := range c.Modes {
+		if supportedMode == mode {
+			return true
+		}
+	}
+	return false
+}
+
+func IsKnownACMode(mode ACMode) bool {
+	switch mode {
+	case ACModeCool, ACModeHeat, ACModeDry, ACModeFan, ACModeAuto:
+		return true
+	default:
+		return false
+	}
+}
+
+func ParseACPowerOnPolicy(value string) (ACPowerOnPolicy, error) {
+	switch policy := ACPowerOnPolicy(value); policy {
+	case ACPowerOnAlways, ACPowerOnKeep, ACPowerOnRequest:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("Unknown AC power on policy: %s", value)
+	}
 }
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type AlertSeverity string
+
+const (
+	AlertInfo     AlertSeverity = "info"
+	AlertWarning  AlertSeverity = "warning"
+	AlertCritical AlertSeverity = "critical"
+)
+
+type Alert struct {
+	Severity AlertSeverity `json:"severity"`
+	Source   string        `json:"source"`
+	Message  string        `json:"message"`
+	Time     time.Time     `json:"time"`
+}
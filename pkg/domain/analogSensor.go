@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+type AnalogSensor struct {
+	Name      string          `json:"name"`
+	Address   string          `json:"address"`
+	Unit      string          `json:"unit"`
+	Threshold AnalogThreshold `json:"threshold"`
+}
+
+// AnalogThreshold describes when a reading counts as detected. A reading is
+// detected once it rises above Above or falls below Below, and is cleared only
+// after it is back by more than Hysteresis, so that readings hovering around
+// the threshold do not produce a burst of events.
+type AnalogThreshold struct {
+	Above      *float32 `json:"above,omitempty"`
+	Below      *float32 `json:"below,omitempty"`
+	Hysteresis float32  `json:"hysteresis"`
+}
+
+type AnalogSensorInfo struct {
+	Enabled   bool      `json:"enabled"`
+	Value     float32   `json:"value"`
+	Unit      string    `json:"unit"`
+	Timestamp time.Time `json:"timestamp"`
+	Detected  bool      `json:"detected"`
+}
+
+func (i AnalogSensorInfo) IsEnabled() bool {
+	return i.Enabled
+}
+
+type AnalogSensorData struct {
+	ID        int       `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	IsEnabled bool      `json:"is_enabled" db:"is_enabled"`
+	Value     float32   `json:"value" db:"value"`
+	Unit      string    `json:"unit" db:"unit"`
+	Timestamp time.Time `json:"timestamp" db:"timestamp"`
+	Detected  bool      `json:"detected" db:"detected"`
+}
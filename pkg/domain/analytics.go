@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type AnalyticsMetric string
+
+const (
+	// MetricPresence is the share of the time a presence sensor detected
+	// someone.
+	MetricPresence AnalyticsMetric = "presence"
+	// MetricDoorOpenings counts how often a door sensor went from closed to
+	// open.
+	MetricDoorOpenings AnalyticsMetric = "door_openings"
+	// MetricOnTime is the time a light or a plug was on.
+	MetricOnTime AnalyticsMetric = "on_time"
+	// MetricACRuntime is the time an AC was running and the setpoint it ran
+	// at on average.
+	MetricACRuntime AnalyticsMetric = "ac_runtime"
+)
+
+type AnalyticsBucket string
+
+const (
+	BucketHour AnalyticsBucket = "hour"
+	BucketDay  AnalyticsBucket = "day"
+	BucketWeek AnalyticsBucket = "week"
+	// BucketHourOfDay adds up the hours of all days in the range, e.g. for the
+	// daily profile of the presence.
+	BucketHourOfDay AnalyticsBucket = "hour_of_day"
+)
+
+var knownAnalyticsBuckets = []AnalyticsBucket{BucketHour, BucketDay, BucketWeek, BucketHourOfDay}
+
+func ParseAnalyticsBucket(value string) (AnalyticsBucket, error) {
+	for _, bucket := range knownAnalyticsBuckets {
+		if string(bucket) == value {
+			return bucket, nil
+		}
+	}
+	return "", fmt.Errorf("Unknown analytics bucket: %s", value)
+}
+
+// AnalyticsConfig lists the sensors the presence and door analytics are
+// computed for. A logged state is taken to hold until the next log of the
+// device, at most for MaxSampleGap, the time after that counts as not
+// observed. Days and weeks start at midnight in Location.
+type AnalyticsConfig struct {
+	PresenceSensors []string       `json:"presence_sensors"`
+	DoorSensors     []string       `json:"door_sensors"`
+	MaxSampleGap    time.Duration  `json:"max_sample_gap"`
+	MaxRange        time.Duration  `json:"max_range"`
+	Location        *time.Location `json:"-"`
+}
+
+// AnalyticsQuery selects the range and the buckets of the analytics. Unset
+// fields fall back to the defaults of the metric.
+type AnalyticsQuery struct {
+	Bucket AnalyticsBucket
+	From   *time.Time
+	To     *time.Time
+}
+
+// AnalyticsPoint is a bucket of a series. Value is the presence as a share of
+// the observed time, the number of door openings or the on-time in seconds.
+type AnalyticsPoint struct {
+	Start           *time.Time `json:"start,omitempty"`
+	HourOfDay       *int       `json:"hour_of_day,omitempty"`
+	Value           float64    `json:"value"`
+	ObservedSeconds float64    `json:"observed_seconds"`
+	AverageSetpoint *float64   `json:"average_setpoint,omitempty"`
+}
+
+type AnalyticsSeries struct {
+	Metric AnalyticsMetric  `json:"metric"`
+	Device string           `json:"device"`
+	Bucket AnalyticsBucket  `json:"bucket"`
+	Unit   string           `json:"unit"`
+	From   time.Time        `json:"from"`
+	To     time.Time        `json:"to"`
+	Points []AnalyticsPoint `json:"points"`
+	// Truncated is set when the logs of the device could only be searched since
+	// ScannedFrom, the time before it counts as not observed.
+	Truncated   bool       `json:"truncated,omitempty"`
+	ScannedFrom *time.Time `json:"scanned_from,omitempty"`
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// AwayWindow is a part of the day, given as HH:MM, in which presence is
+// simulated. A window ending before it starts lasts over midnight.
+type AwayWindow struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Contains tells whether the minute of the day falls into the window.
+func (w AwayWindow) Contains(minute int) (bool, error) {
+	start, err := parseMinuteOfDay(w.Start)
+	if err != nil {
+		return false, err
+	}
+	end, err := parseMinuteOfDay(w.End)
+	if err != nil {
+		return false, err
+	}
+
+	if start <= end {
+		return minute >= start && minute < end, nil
+	}
+	return minute >= start || minute < end, nil
+}
+
+func parseMinuteOfDay(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid time of day '%s', expected HH:MM", value)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// AwayConfig describes the away mode. The AC is switched off, or kept at
+// ACSetpoint if one is set, and the simulated devices are switched within the
+// windows following the hourly patterns learned from their last HistoryLimit
+// logs.
+type AwayConfig struct {
+	AC                 string        `json:"ac"`
+	ACSetpoint         *float32      `json:"ac_setpoint,omitempty"`
+	SimulatedDevices   []string      `json:"simulated_devices"`
+	Windows            []AwayWindow  `json:"windows"`
+	SimulationInterval time.Duration `json:"simulation_interval"`
+	HistoryLimit       int           `json:"history_limit"`
+}
+
+// AwaySnapshot is the state of the house when the away mode was entered, which
+// is restored when it is left.
+type AwaySnapshot struct {
+	AC           *ACInfo         `json:"ac,omitempty"`
+	Devices      map[string]bool `json:"devices"`
+	SecurityMode SecurityMode    `json:"security_mode,omitempty"`
+}
+
+// ActivityPattern holds the probability of a device being on for every hour
+// of the day.
+type ActivityPattern [24]float64
+
+type AwayState struct {
+	Active   bool                       `json:"active"`
+	Since    *time.Time                 `json:"since,omitempty"`
+	Source   string                     `json:"source,omitempty"`
+	Snapshot *AwaySnapshot              `json:"snapshot,omitempty"`
+	Patterns map[string]ActivityPattern `json:"patterns,omitempty"`
+}
+
+type AwayLeaveRequest struct {
+	PIN string `json:"pin"`
+}
+
+// AwayRecord is persisted whenever the away mode is entered or left, so that
+// an away mode lasting for days survives a restart together with the snapshot
+// to restore.
+type AwayRecord struct {
+	Active   bool          `json:"active"`
+	Snapshot *AwaySnapshot `json:"snapshot,omitempty"`
+	Source   string        `json:"source"`
+	Time     time.Time     `json:"time"`
+}
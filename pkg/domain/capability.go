@@ -0,0 +1,59 @@
+package domain
+
+import "fmt"
+
+// Capability is a feature of a device that can be served without knowing
+// which kind of device it is. Devices declare the capabilities they support
+// and get the matching endpoints and validation from the generic device
+// stack. Metering and measuring are read-only there, served through the device
+// state and its logs. Lockable devices get a confirmed unlock with an audit
+// trail and positionable devices have their movement tracked.
+//
+// The built-in kinds light, plug, AC and analog sensor keep dedicated
+// services, as they add behaviour no capability describes: light capability
+// discovery, energy totals, the AC power-on policy and analog thresholds. They
+// validate their capabilities with the same validators as the generic device
+// stack.
+type Capability string
+
+const (
+	CapabilitySwitchable   Capability = "switchable"
+	CapabilityDetectable   Capability = "detectable"
+	CapabilityThermostat   Capability = "thermostat"
+	CapabilityDimmable     Capability = "dimmable"
+	CapabilityColor        Capability = "color"
+	CapabilityMetering     Capability = "metering"
+	CapabilityMeasuring    Capability = "measuring"
+	CapabilityLockable     Capability = "lockable"
+	CapabilityPositionable Capability = "positionable"
+)
+
+var knownCapabilities = []Capability{
+	CapabilitySwitchable,
+	CapabilityDetectable,
+	CapabilityThermostat,
+	CapabilityDimmable,
+	CapabilityColor,
+	CapabilityMetering,
+	CapabilityMeasuring,
+	CapabilityLockable,
+	CapabilityPositionable,
+}
+
+func ParseCapability(value string) (Capability, error) {
+	for _, capability := range knownCapabilities {
+		if string(capability) == value {
+			return capability, nil
+		}
+	}
+	return "", fmt.Errorf("Unknown capability: %s", value)
+}
+
+func HasCapability(capabilities []Capability, capability Capability) bool {
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// CommandSource tells who issued a command to a device.
+type CommandSource string
+
+const (
+	SourceUser     CommandSource = "user"
+	SourceRule     CommandSource = "rule"
+	SourceSchedule CommandSource = "schedule"
+	SourceSafety   CommandSource = "safety"
+)
+
+// Automated reports whether the commands of the source give way to a manual
+// override.
+func (s CommandSource) Automated() bool {
+	return s == SourceRule || s == SourceSchedule
+}
+
+// DeviceControl tells which source commanded the device last. OverrideUntil is
+// set while a user override keeps automation off the device.
+type DeviceControl struct {
+	Device        string        `json:"device"`
+	Source        CommandSource `json:"source,omitempty"`
+	Since         *time.Time    `json:"since,omitempty"`
+	OverrideUntil *time.Time    `json:"override_until,omitempty"`
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+type CoverDirection string
+
+const (
+	CoverOpening CoverDirection = "opening"
+	CoverClosing CoverDirection = "closing"
+)
+
+const (
+	CoverPositionClosed = 0
+	CoverPositionOpen   = 100
+)
+
+// CoverInfo is the state reported by a device with the positionable
+// capability. Positions are in percent, where 0 is fully closed and 100 is
+// fully open.
+type CoverInfo struct {
+	CurrentPosition int            `json:"current_position"`
+	TargetPosition  int            `json:"target_position"`
+	Moving          bool           `json:"moving"`
+	Direction       CoverDirection `json:"direction,omitempty"`
+}
+
+// IsEnabled reports whether the cover is open or opening, so that covers can be
+// switched like the other devices by groups and automation.
+func (i CoverInfo) IsEnabled() bool {
+	return i.TargetPosition > CoverPositionClosed
+}
+
+type CoverData struct {
+	ID              int            `json:"id" db:"id"`
+	CreatedAt       time.Time      `json:"created_at" db:"created_at"`
+	CurrentPosition int            `json:"current_position" db:"current_position"`
+	TargetPosition  int            `json:"target_position" db:"target_position"`
+	Moving          bool           `json:"moving" db:"moving"`
+	Direction       CoverDirection `json:"direction,omitempty" db:"direction"`
+}
+
+type CoverPositionRequest struct {
+	Position *int `json:"position" binding:"required"`
+}
@@ -2,17 +2,94 @@ package domain
 
 import "time"
 
+// LightCapabilities and ACCapabilities describe what the device supports for
+// the dimmable and color, and the thermostat capabilities respectively.
 type Device struct {
-	Name    string `json:"name"`
-	Address string `json:"address"`
+	Name              string             `json:"name"`
+	Address           string             `json:"address"`
+	Capabilities      []Capability       `json:"capabilities"`
+	LightCapabilities *LightCapabilities `json:"light_capabilities,omitempty"`
+	ACCapabilities    *ACCapabilities    `json:"ac_capabilities,omitempty"`
+}
+
+// DeviceConfig configures the behaviour the generic device stack adds to some
+// capabilities: how long the token of a confirmed command, e.g. unlocking,
+// stays valid, and how often a moving device is polled until it stops.
+type DeviceConfig struct {
+	ConfirmationTTL time.Duration
+	PollInterval    time.Duration
+}
+
+// DeviceState is the state reported by a device whose kind is known to the
+// control station only through its capabilities.
+type DeviceState map[string]interface{}
+
+func (s DeviceState) Bool(key string) bool {
+	value, _ := s[key].(bool)
+	return value
+}
+
+// Int returns the number under the key, which JSON decodes as a float.
+func (s DeviceState) Int(key string) (int, bool) {
+	value, ok := s[key].(float64)
+	return int(value), ok
+}
+
+func (s DeviceState) String(key string) string {
+	value, _ := s[key].(string)
+	return value
+}
+
+func (s DeviceState) IsEnabled() bool {
+	return s.Bool("enabled")
 }
 
 type DeviceInfo struct {
 	Enabled bool `json:"enabled"`
 }
 
+func (i DeviceInfo) IsEnabled() bool {
+	return i.Enabled
+}
+
 type DeviceData struct {
 	ID        int       `json:"id" db:"id"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	IsEnabled bool      `json:"is_enabled" db:"is_enabled"`
 }
+
+// CommandChallenge is issued for a command that needs confirmation, e.g.
+// unlocking. The command is carried out only when the token is sent back
+// before it expires.
+type CommandChallenge struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type CommandConfirmation struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type AuditResult string
+
+// AuditAttempt is recorded before a command is carried out, so that no command
+// goes unaudited; its outcome is recorded afterwards.
+const (
+	AuditAttempt  AuditResult = "attempt"
+	AuditSuccess  AuditResult = "success"
+	AuditFailure  AuditResult = "failure"
+	AuditRejected AuditResult = "rejected"
+)
+
+// AuditEntry records a command of a capability that is audited, e.g. locking.
+// Requesting a confirmed command is recorded as the command with the
+// "_request" suffix.
+type AuditEntry struct {
+	Device  string      `json:"device"`
+	Command string      `json:"command"`
+	Result  AuditResult `json:"result"`
+	Source  string      `json:"source"`
+	State   string      `json:"state,omitempty"`
+	Detail  string      `json:"detail,omitempty"`
+	Time    time.Time   `json:"time"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+type EventType string
+
+const (
+	EventDetected EventType = "detected"
+	EventCleared  EventType = "cleared"
+)
+
+type DeviceEvent struct {
+	Device string    `json:"device"`
+	Type   EventType `json:"type"`
+	Value  *float32  `json:"value,omitempty"`
+	Time   time.Time `json:"time"`
+}
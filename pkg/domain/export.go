@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+type ExportFormat string
+
+const (
+	ExportCSV    ExportFormat = "csv"
+	ExportNDJSON ExportFormat = "ndjson"
+	// ExportColumnar is the binary columnar format of the export service, meant
+	// for pulls too large for CSV.
+	ExportColumnar ExportFormat = "columnar"
+)
+
+// ExportRequest selects the logs to export. Without devices the logs of all
+// registered devices are exported. From is inclusive and To exclusive.
+type ExportRequest struct {
+	Devices []string
+	Format  ExportFormat
+	From    *time.Time
+	To      *time.Time
+}
+
+// ExportSummary tells whether the export is incomplete because some logs could
+// only be searched since ScannedFrom, although the request reaches further
+// back. ScannedFrom is the latest such limit over the exported devices.
+type ExportSummary struct {
+	Truncated   bool
+	ScannedFrom *time.Time
+}
@@ -0,0 +1,62 @@
+package domain
+
+// Switchable is implemented by the states of devices that can be switched on
+// and off.
+type Switchable interface {
+	IsEnabled() bool
+}
+
+type Group struct {
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+type GroupAggregate string
+
+const (
+	GroupAllOn   GroupAggregate = "all_on"
+	GroupAllOff  GroupAggregate = "all_off"
+	GroupMixed   GroupAggregate = "mixed"
+	GroupUnknown GroupAggregate = "unknown"
+)
+
+// AggregateOf summarizes the power states of the group members that could be
+// read.
+func AggregateOf(enabled []bool) GroupAggregate {
+	if len(enabled) == 0 {
+		return GroupUnknown
+	}
+
+	on := 0
+	for _, e := range enabled {
+		if e {
+			on++
+		}
+	}
+	switch on {
+	case 0:
+		return GroupAllOff
+	case len(enabled):
+		return GroupAllOn
+	default:
+		return GroupMixed
+	}
+}
+
+type GroupMemberResult struct {
+	Name    string `json:"name"`
+	Enabled *bool  `json:"enabled,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type GroupState struct {
+	Name      string              `json:"name"`
+	State     GroupAggregate      `json:"state"`
+	Succeeded []string            `json:"succeeded"`
+	Failed    []string            `json:"failed"`
+	Members   []GroupMemberResult `json:"members"`
+}
+
+type GroupEnabledRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+type Light struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+type RGBColor struct {
+	Red   int `json:"red"`
+	Green int `json:"green"`
+	Blue  int `json:"blue"`
+}
+
+type HSVColor struct {
+	Hue        float32 `json:"hue"`
+	Saturation float32 `json:"saturation"`
+	Value      float32 `json:"value"`
+}
+
+// Everything except Enabled is optional, as plain on/off bulbs report only
+// their power state.
+type LightInfo struct {
+	Enabled          bool      `json:"enabled"`
+	Brightness       *int      `json:"brightness,omitempty"`
+	ColorTemperature *int      `json:"color_temperature,omitempty"`
+	RGB              *RGBColor `json:"rgb,omitempty"`
+	HSV              *HSVColor `json:"hsv,omitempty"`
+}
+
+func (i LightInfo) IsEnabled() bool {
+	return i.Enabled
+}
+
+type LightData struct {
+	ID               int       `json:"id" db:"id"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	IsEnabled        bool      `json:"is_enabled" db:"is_enabled"`
+	Brightness       *int      `json:"brightness,omitempty" db:"brightness"`
+	ColorTemperature *int      `json:"color_temperature,omitempty" db:"color_temperature"`
+	RGB              *RGBColor `json:"rgb,omitempty" db:"rgb"`
+	HSV              *HSVColor `json:"hsv,omitempty" db:"hsv"`
+}
+
+type LightBrightnessRequest struct {
+	Brightness   int `json:"brightness"`
+	TransitionMs int `json:"transition_ms,omitempty"`
+}
+
+type LightColorRequest struct {
+	ColorTemperature *int      `json:"color_temperature,omitempty"`
+	RGB              *RGBColor `json:"rgb,omitempty"`
+	HSV              *HSVColor `json:"hsv,omitempty"`
+	TransitionMs     int       `json:"transition_ms,omitempty"`
+}
+
+type LightCapabilities struct {
+	Dimmable            bool `json:"dimmable"`
+	MinColorTemperature int  `json:"min_color_temperature,omitempty"`
+	MaxColorTemperature int  `json:"max_color_temperature,omitempty"`
+	SupportsRGB         bool `json:"supports_rgb"`
+	SupportsHSV         bool `json:"supports_hsv"`
+	MaxTransitionMs     int  `json:"max_transition_ms"`
+}
+
+func (c LightCapabilities) SupportsColorTemperature() bool {
+	return c.MaxColorTemperature > 0
+}
+
+// DefaultLightCapabilities describes a plain on/off bulb, which is what is
+// assumed for bulbs that do not expose their capabilities.
+func DefaultLightCapabilities() LightCapabilities {
+	return LightCapabilities{}
+}
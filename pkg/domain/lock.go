@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+type LockState string
+
+const (
+	LockStateLocked   LockState = "locked"
+	LockStateUnlocked LockState = "unlocked"
+	LockStateJammed   LockState = "jammed"
+)
+
+// LockData is a log of a device with the lockable capability. BatteryLevel is
+// in percent, locks without a battery gauge report only the LowBattery flag.
+type LockData struct {
+	ID           int       `json:"id" db:"id"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	State        LockState `json:"state" db:"state"`
+	BatteryLevel *int      `json:"battery_level,omitempty" db:"battery_level"`
+	LowBattery   bool      `json:"low_battery" db:"low_battery"`
+}
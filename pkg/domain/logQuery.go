@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+type SortOrder string
+
+const (
+	SortDesc SortOrder = "desc"
+	SortAsc  SortOrder = "asc"
+)
+
+// LogQuery selects device logs. Unset filters match every log, From is
+// inclusive and To exclusive. Cursor continues the page it was returned with
+// and must be used with the same filters and order.
+type LogQuery struct {
+	From      *time.Time
+	To        *time.Time
+	IsEnabled *bool
+	Detected  *bool
+	Order     SortOrder
+	Limit     int
+	Cursor    string
+}
+
+// LogPage is a page of logs. NextCursor is empty on the last page. Truncated
+// is set when only the logs since ScannedFrom could be searched, although the
+// query reaches further back.
+type LogPage[K any] struct {
+	Logs        []K
+	NextCursor  string
+	Truncated   bool
+	ScannedFrom *time.Time
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// MaintenanceWindow puts a device in maintenance from Start until End. A window
+// without End lasts until it is ended.
+type MaintenanceWindow struct {
+	ID     string     `json:"id"`
+	Device string     `json:"device"`
+	Start  time.Time  `json:"start"`
+	End    *time.Time `json:"end,omitempty"`
+	Reason string     `json:"reason,omitempty"`
+	Source string     `json:"source"`
+}
+
+func (w MaintenanceWindow) ActiveAt(t time.Time) bool {
+	return !t.Before(w.Start) && (w.End == nil || t.Before(*w.End))
+}
+
+// MaintenanceRequest schedules a maintenance window. Without Start it begins
+// immediately.
+type MaintenanceRequest struct {
+	Device string     `json:"device" binding:"required"`
+	Start  *time.Time `json:"start"`
+	End    *time.Time `json:"end"`
+	Reason string     `json:"reason"`
+}
+
+// MaintenanceRecord is the snapshot of the scheduled windows persisted after
+// every change, so that they survive a restart.
+type MaintenanceRecord struct {
+	Windows []MaintenanceWindow `json:"windows"`
+	Source  string              `json:"source"`
+	Time    time.Time           `json:"time"`
+}
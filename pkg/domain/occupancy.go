@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+type OccupancyState string
+
+const (
+	RoomOccupied OccupancyState = "occupied"
+	RoomVacant   OccupancyState = "vacant"
+)
+
+// OccupancyConfig lists the sensors the occupancy of the rooms is derived from.
+// A room is occupied while a presence sensor in it detects someone, and for
+// the vacancy timeout after that or after a door in it was opened. Rooms
+// missing from RoomTimeouts use VacancyTimeout.
+type OccupancyConfig struct {
+	PresenceSensors []string                 `json:"presence_sensors"`
+	DoorSensors     []string                 `json:"door_sensors"`
+	VacancyTimeout  time.Duration            `json:"vacancy_timeout"`
+	RoomTimeouts    map[string]time.Duration `json:"room_timeouts,omitempty"`
+}
+
+type RoomOccupancy struct {
+	Room          string         `json:"room"`
+	State         OccupancyState `json:"state"`
+	Since         time.Time      `json:"since"`
+	TriggeredBy   string         `json:"triggered_by,omitempty"`
+	LastActivity  *time.Time     `json:"last_activity,omitempty"`
+	VacantAt      *time.Time     `json:"vacant_at,omitempty"`
+	OverrideUntil *time.Time     `json:"override_until,omitempty"`
+}
+
+// OccupancyOverrideRequest stops the automation of a room for the given number
+// of minutes, or for the default window if Minutes is not set. Zero minutes end
+// the override.
+type OccupancyOverrideRequest struct {
+	Minutes *int `json:"minutes"`
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+type Plug struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// The metering readings are optional, as plugs without a power meter report
+// only their power state. Power is in watts, Voltage in volts, Current in
+// amperes and Energy is the cumulative consumption in kWh.
+type PlugInfo struct {
+	Enabled bool     `json:"enabled"`
+	Power   *float32 `json:"power,omitempty"`
+	Voltage *float32 `json:"voltage,omitempty"`
+	Current *float32 `json:"current,omitempty"`
+	Energy  *float32 `json:"energy,omitempty"`
+}
+
+func (i PlugInfo) IsEnabled() bool {
+	return i.Enabled
+}
+
+type PlugData struct {
+	ID        int       `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	IsEnabled bool      `json:"is_enabled" db:"is_enabled"`
+	Power     *float32  `json:"power,omitempty" db:"power"`
+	Voltage   *float32  `json:"voltage,omitempty" db:"voltage"`
+	Current   *float32  `json:"current,omitempty" db:"current"`
+	Energy    *float32  `json:"energy,omitempty" db:"energy"`
+}
+
+type Tariff struct {
+	PricePerKWh float64 `json:"price_per_kwh"`
+	Currency    string  `json:"currency"`
+}
+
+type EnergyPeriod string
+
+const (
+	EnergyPeriodDaily   EnergyPeriod = "daily"
+	EnergyPeriodMonthly EnergyPeriod = "monthly"
+)
+
+// EnergyTotal is the consumption in a period. Partial is set when the readings
+// do not cover the whole period. Cost and Currency are only set when an energy
+// price is configured.
+type EnergyTotal struct {
+	PeriodStart time.Time `json:"period_start"`
+	Energy      float64   `json:"energy"`
+	Partial     bool      `json:"partial"`
+	Cost        *float64  `json:"cost,omitempty"`
+	Currency    string    `json:"currency,omitempty"`
+}
+
+// EnergyTotals are the totals of a range. Truncated is set when the readings
+// do not cover the whole range, because the logs could only be searched since
+// ScannedFrom or the readings were cut off after too many pages.
+type EnergyTotals struct {
+	Totals      []EnergyTotal
+	Truncated   bool
+	ScannedFrom *time.Time
+}
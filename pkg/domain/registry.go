@@ -0,0 +1,49 @@
+package domain
+
+import "fmt"
+
+type DeviceKind string
+
+const (
+	KindSensor       DeviceKind = "sensor"
+	KindDevice       DeviceKind = "device"
+	KindAC           DeviceKind = "ac"
+	KindLight        DeviceKind = "light"
+	KindPlug         DeviceKind = "plug"
+	KindAnalogSensor DeviceKind = "analog_sensor"
+	KindLock         DeviceKind = "lock"
+	KindCover        DeviceKind = "cover"
+)
+
+var knownDeviceKinds = []DeviceKind{
+	KindSensor,
+	KindDevice,
+	KindAC,
+	KindLight,
+	KindPlug,
+	KindAnalogSensor,
+	KindLock,
+	KindCover,
+}
+
+func ParseDeviceKind(value string) (DeviceKind, error) {
+	for _, kind := range knownDeviceKinds {
+		if string(kind) == value {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("Unknown device kind: %s", value)
+}
+
+type RegisteredDevice struct {
+	Name              string             `json:"name"`
+	Kind              DeviceKind         `json:"kind"`
+	Address           string             `json:"address"`
+	Route             string             `json:"route"`
+	Capabilities      []Capability       `json:"capabilities"`
+	Room              string             `json:"room,omitempty"`
+	ACCapabilities    *ACCapabilities    `json:"ac_capabilities,omitempty"`
+	LightCapabilities *LightCapabilities `json:"light_capabilities,omitempty"`
+	Unit              string             `json:"unit,omitempty"`
+	Threshold         *AnalogThreshold   `json:"threshold,omitempty"`
+}
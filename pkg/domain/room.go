@@ -0,0 +1,36 @@
+package domain
+
+// Room groups the devices that are assigned to it. Rooms sharing a Zone, e.g.
+// "upstairs", can be addressed together.
+type Room struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Zone string `json:"zone,omitempty"`
+}
+
+type DeviceStatus struct {
+	Name  string     `json:"name"`
+	Kind  DeviceKind `json:"kind"`
+	Room  string     `json:"room,omitempty"`
+	State any        `json:"state,omitempty"`
+	Error string     `json:"error,omitempty"`
+}
+
+type RoomStatus struct {
+	ID      string         `json:"id"`
+	Rooms   []Room         `json:"rooms"`
+	Devices []DeviceStatus `json:"devices"`
+}
+
+// RoomLightsRequest sets the power state and brightness of all lights in a
+// room. Fields that are not set are left unchanged.
+type RoomLightsRequest struct {
+	Enabled    *bool `json:"enabled"`
+	Brightness *int  `json:"brightness"`
+}
+
+type LightResult struct {
+	Name  string     `json:"name"`
+	State *LightInfo `json:"state,omitempty"`
+	Error string     `json:"error,omitempty"`
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+type SafetyStatus string
+
+const (
+	SafetyNormal SafetyStatus = "normal"
+	// SafetyAlarm lasts until an operator acknowledges it, even if the sensor
+	// cleared in the meantime.
+	SafetyAlarm SafetyStatus = "alarm"
+)
+
+// SafetyConfig lists the gas sensors that trigger the safety alarm and the
+// switchable devices that are switched off and kept off while it lasts.
+type SafetyConfig struct {
+	GasSensors       []string `json:"gas_sensors"`
+	ProtectedDevices []string `json:"protected_devices"`
+}
+
+type ProtectiveActionResult struct {
+	Device string `json:"device"`
+	Error  string `json:"error,omitempty"`
+}
+
+type SafetyState struct {
+	Status         SafetyStatus             `json:"status"`
+	GasDetected    bool                     `json:"gas_detected"`
+	TriggeredBy    string                   `json:"triggered_by,omitempty"`
+	TriggeredAt    *time.Time               `json:"triggered_at,omitempty"`
+	Actions        []ProtectiveActionResult `json:"actions,omitempty"`
+	AcknowledgedBy string                   `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time               `json:"acknowledged_at,omitempty"`
+}
+
+type SafetyEventType string
+
+const (
+	SafetyEventTriggered    SafetyEventType = "triggered"
+	SafetyEventAcknowledged SafetyEventType = "acknowledged"
+)
+
+// SafetyEvent is the record of a safety alarm being raised or acknowledged.
+// The latest one is used to restore the alarm after a restart.
+type SafetyEvent struct {
+	Type   SafetyEventType `json:"type"`
+	Source string          `json:"source"`
+	Time   time.Time       `json:"time"`
+}
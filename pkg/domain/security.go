@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+type SecurityMode string
+
+const (
+	SecurityDisarmed  SecurityMode = "disarmed"
+	SecurityArmedAway SecurityMode = "armed_away"
+	SecurityArmedHome SecurityMode = "armed_home"
+)
+
+type AlarmStatus string
+
+const (
+	AlarmReady      AlarmStatus = "ready"
+	AlarmEntryDelay AlarmStatus = "entry_delay"
+	AlarmTriggered  AlarmStatus = "triggered"
+)
+
+type SecurityState struct {
+	Mode          SecurityMode `json:"mode"`
+	Status        AlarmStatus  `json:"status"`
+	TriggeredBy   string       `json:"triggered_by,omitempty"`
+	EntryDeadline *time.Time   `json:"entry_deadline,omitempty"`
+	Since         time.Time    `json:"since"`
+	// PINLockedUntil is set while disarming is refused after too many wrong
+	// PINs.
+	PINLockedUntil *time.Time `json:"pin_locked_until,omitempty"`
+}
+
+// SecurityConfig lists the sensors that start the entry delay in every armed
+// mode. In the home mode the presence inside is usually expected, so it
+// watches fewer sensors than the away mode. After MaxPINAttempts wrong PINs in
+// a row disarming is refused for PINLockout, whoever asks.
+type SecurityConfig struct {
+	AwaySensors    []string      `json:"away_sensors"`
+	HomeSensors    []string      `json:"home_sensors"`
+	EntryDelay     time.Duration `json:"entry_delay"`
+	MaxPINAttempts int           `json:"max_pin_attempts"`
+	PINLockout     time.Duration `json:"pin_lockout"`
+}
+
+type SecurityArmRequest struct {
+	Mode SecurityMode `json:"mode" binding:"required"`
+}
+
+type SecurityDisarmRequest struct {
+	PIN string `json:"pin" binding:"required"`
+}
+
+type SecurityTransitionResult string
+
+const (
+	SecurityTransitionApplied  SecurityTransitionResult = "applied"
+	SecurityTransitionRejected SecurityTransitionResult = "rejected"
+)
+
+// SecurityTransition is the audit record of a requested or automatic change of
+// the security state. The applied transitions are also kept in a dedicated
+// log, whose latest entry restores the state after a restart.
+type SecurityTransition struct {
+	FromMode   SecurityMode             `json:"from_mode"`
+	FromStatus AlarmStatus              `json:"from_status"`
+	ToMode     SecurityMode             `json:"to_mode"`
+	ToStatus   AlarmStatus              `json:"to_status"`
+	Result     SecurityTransitionResult `json:"result"`
+	Source     string                   `json:"source"`
+	Reason     string                   `json:"reason,omitempty"`
+	Time       time.Time                `json:"time"`
+	// EntryDeadline is set on the transitions to the entry delay, so that an
+	// entry delay interrupted by a restart keeps its deadline.
+	EntryDeadline *time.Time `json:"entry_deadline,omitempty"`
+}
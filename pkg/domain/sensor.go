@@ -2,16 +2,15 @@ package domain
 
 import "time"
 
-type Sensor struct {
-	Name    string `json:"name"`
-	Address string `json:"address"`
-}
-
 type SensorInfo struct {
 	Enabled  bool `json:"enabled"`
 	Detected bool `json:"detected"`
 }
 
+func (i SensorInfo) IsEnabled() bool {
+	return i.Enabled
+}
+
 type SensorData struct {
 	ID        int       `json:"id" db:"id"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// CachedState is the last known state of a device, as read from or reported
+// by the device at UpdatedAt.
+type CachedState struct {
+	Device    string    `json:"device"`
+	State     any       `json:"state"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// StatusReader reads the current state of a single device through the service
+// of its kind, without logging it. The read is abandoned when the context is
+// done.
+type StatusReader func(ctx context.Context) (any, error)
+
+// DeviceReport is the status of a single device in the whole-home status. A
+// device that could not be read is reported unreachable together with its last
+// known state, if there is one.
+type DeviceReport struct {
+	Name      string     `json:"name"`
+	Kind      DeviceKind `json:"kind"`
+	Room      string     `json:"room,omitempty"`
+	Reachable bool       `json:"reachable"`
+	Cached    bool       `json:"cached"`
+	LatencyMs *int64     `json:"latency_ms,omitempty"`
+	State     any        `json:"state,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+type HomeStatus struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	Devices     []DeviceReport `json:"devices"`
+}
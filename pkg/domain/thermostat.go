@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+// ThermostatPeriod sets the target temperature from Start, given as HH:MM,
+// until the start of the next period. The last period of the day lasts until
+// the first one of the next day.
+type ThermostatPeriod struct {
+	Start  string  `json:"start"`
+	Target float32 `json:"target"`
+}
+
+// StartMinute returns the minute of the day the period starts at.
+func (p ThermostatPeriod) StartMinute() (int, error) {
+	return parseMinuteOfDay(p.Start)
+}
+
+// ThermostatConfig describes how the controller holds the temperature. The AC
+// is switched on once the temperature is more than Hysteresis past the target
+// and off once it is more than Hysteresis back, and it is never switched again
+// before MinOnTime or MinOffTime passed, to protect the compressor. The mode is
+// only sent to an AC whose capabilities include modes.
+type ThermostatConfig struct {
+	Sensor         string             `json:"sensor"`
+	AC             string             `json:"ac"`
+	ACCapabilities ACCapabilities     `json:"ac_capabilities"`
+	Mode           ACMode             `json:"mode"`
+	Target         float32            `json:"target"`
+	Hysteresis     float32            `json:"hysteresis"`
+	MinOnTime      time.Duration      `json:"min_on_time"`
+	MinOffTime     time.Duration      `json:"min_off_time"`
+	Schedule       []ThermostatPeriod `json:"schedule"`
+}
+
+type ThermostatDecision string
+
+const (
+	ThermostatPending ThermostatDecision = "pending"
+	ThermostatTurnOn  ThermostatDecision = "turn_on"
+	ThermostatTurnOff ThermostatDecision = "turn_off"
+	ThermostatStayOn  ThermostatDecision = "stay_on"
+	ThermostatStayOff ThermostatDecision = "stay_off"
+	// ThermostatHold means the AC should be switched, but the minimum on or off
+	// time has not passed yet.
+	ThermostatHold  ThermostatDecision = "hold"
+	ThermostatError ThermostatDecision = "error"
+)
+
+type ThermostatStatus struct {
+	Mode         ACMode             `json:"mode"`
+	Target       float32            `json:"target"`
+	Period       string             `json:"period,omitempty"`
+	Temperature  *float32           `json:"temperature,omitempty"`
+	ACEnabled    bool               `json:"ac_enabled"`
+	LastSwitchAt *time.Time         `json:"last_switch_at,omitempty"`
+	NextSwitchAt *time.Time         `json:"next_switch_at,omitempty"`
+	Decision     ThermostatDecision `json:"decision"`
+	Reason       string             `json:"reason"`
+	EvaluatedAt  *time.Time         `json:"evaluated_at,omitempty"`
+}
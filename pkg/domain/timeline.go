@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TimelineQuery selects the logs merged into the timeline. Without kinds the
+// logs of all devices are merged.
+type TimelineQuery struct {
+	From  *time.Time
+	To    *time.Time
+	Kinds []DeviceKind
+	Order SortOrder
+	Limit int
+}
+
+// TimelineEntry is a device log as sent by the device, placed on the timeline
+// at its creation time.
+type TimelineEntry struct {
+	Time   time.Time       `json:"time"`
+	Device string          `json:"device"`
+	Kind   DeviceKind      `json:"kind"`
+	Log    json.RawMessage `json:"log"`
+}
+
+// Timeline is the merged timeline. Devices whose logs could not be fetched are
+// left out and listed in Errors with the reason.
+type Timeline struct {
+	Entries []TimelineEntry   `json:"entries"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
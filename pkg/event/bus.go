@@ -0,0 +1,41 @@
+package event
+
+import (
+	"sync"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+)
+
+// Bus delivers device events to every subscriber synchronously, in the order
+// of subscription. Subscribers must therefore return quickly and hand longer
+// work off to their own goroutines.
+type Bus interface {
+	Publish(event domain.DeviceEvent)
+	Subscribe(handler func(domain.DeviceEvent))
+}
+
+type bus struct {
+	mu       sync.RWMutex
+	handlers []func(domain.DeviceEvent)
+}
+
+func NewBus() Bus {
+	return &bus{}
+}
+
+func (b *bus) Publish(event domain.DeviceEvent) {
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+func (b *bus) Subscribe(handler func(domain.DeviceEvent)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+}
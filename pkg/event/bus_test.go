@@ -0,0 +1,29 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublish(t *testing.T) {
+	b := NewBus()
+	var first, second []domain.DeviceEvent
+	b.Subscribe(func(e domain.DeviceEvent) { first = append(first, e) })
+	b.Subscribe(func(e domain.DeviceEvent) { second = append(second, e) })
+
+	event := domain.DeviceEvent{Device: "co2Sensor", Type: domain.EventDetected, Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b.Publish(event)
+
+	assert.Equal(t, []domain.DeviceEvent{event}, first)
+	assert.Equal(t, []domain.DeviceEvent{event}, second)
+}
+
+func TestPublish_NoSubscribers(t *testing.T) {
+	b := NewBus()
+	assert.NotPanics(t, func() {
+		b.Publish(domain.DeviceEvent{Device: "co2Sensor", Type: domain.EventCleared})
+	})
+}
@@ -2,15 +2,17 @@ package http
 
 import (
 	"net/http"
-	"strconv"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
 	acService "github.com/pklimuk-eng-thesis/control-station/pkg/service/ac"
 )
 
 type ACHandler struct {
 	service acService.ACService
+	mu      sync.Mutex
 }
 
 func NewACHandler(service acService.ACService) *ACHandler {
@@ -20,53 +22,73 @@ func NewACHandler(service acService.ACService) *ACHandler {
 func (h *ACHandler) GetInfo(c *gin.Context) {
 	acInfo, err := h.service.GetInfo()
 	if err != nil {
-		c.String(http.StatusInternalServerError, err.Error())
+		httpUtils.WriteServiceError(c, err)
 		return
 	}
 
+	httpUtils.SetETag(c, acInfo)
 	c.IndentedJSON(http.StatusOK, &acInfo)
 }
 
 func (h *ACHandler) ToggleEnabled(c *gin.Context) {
-	acInfo, err := h.service.ToggleEnabled()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !httpUtils.CheckIfMatch(c, h.service.ReadInfo) {
+		return
+	}
+
+	acInfo, err := h.service.ToggleEnabled(c.Request.Context())
 	if err != nil {
-		c.String(http.StatusInternalServerError, err.Error())
+		httpUtils.WriteServiceError(c, err)
 		return
 	}
 
+	httpUtils.SetETag(c, acInfo)
 	c.IndentedJSON(http.StatusOK, &acInfo)
 }
 
 func (h *ACHandler) UpdateACSettings(c *gin.Context) {
 	var desiredSettings domain.ACInfo
-	err := c.BindJSON(&desiredSettings)
+	err := c.ShouldBindJSON(&desiredSettings)
 	if err != nil {
-		c.String(http.StatusBadRequest, err.Error())
+		httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	acInfo, err := h.service.UpdateACSettings(desiredSettings.Temperature, desiredSettings.Humidity)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !httpUtils.CheckIfMatch(c, h.service.ReadInfo) {
+		return
+	}
+
+	acInfo, err := h.service.UpdateACSettings(desiredSettings)
 	if err != nil {
-		c.String(http.StatusInternalServerError, err.Error())
+		httpUtils.WriteServiceError(c, err)
 		return
 	}
 
+	httpUtils.SetETag(c, acInfo)
 	c.IndentedJSON(http.StatusOK, &acInfo)
 }
 
+func (h *ACHandler) GetCapabilities(c *gin.Context) {
+	capabilities := h.service.GetCapabilities()
+	c.IndentedJSON(http.StatusOK, &capabilities)
+}
+
 func (h *ACHandler) GetACLogsLimitN(c *gin.Context) {
-	limitStr := c.Query("limit")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
-		c.String(http.StatusBadRequest, "Invalid limit parameter")
+	query, ok := httpUtils.ParseLogQuery(c)
+	if !ok {
 		return
 	}
 
-	acLogs, err := h.service.GetACLogsFromDataServiceLimitN(limit)
+	acLogs, err := h.service.QueryACLogsFromDataService(query)
 	if err != nil {
-		c.String(http.StatusInternalServerError, err.Error())
+		httpUtils.WriteServiceError(c, err)
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, &acLogs)
+	httpUtils.WriteLogPage(c, acLogs)
 }
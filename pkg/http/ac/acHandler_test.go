@@ -10,9 +10,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
 	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/ac"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
 	"github.com/pklimuk-eng-thesis/control-station/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetInfo_Success(t *testing.T) {
@@ -40,18 +43,20 @@ func TestGetInfo_ParsingFailure(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	acHandler.GetInfo(c)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	assert.Equal(t, "Parsing failed", w.Body.String())
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Gateway", "status": 502, "code": "INVALID_RESPONSE", "detail": "Parsing failed"}`,
+		w.Body.String())
 }
 
 func TestToggleEnabled_Success(t *testing.T) {
 	acService := new(service.MockACService)
-	acService.EXPECT().ToggleEnabled().Return(domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50}, nil)
+	acService.EXPECT().ToggleEnabled(mock.Anything).Return(domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50}, nil)
 
 	acHandler := NewACHandler(acService)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
 	acHandler.ToggleEnabled(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -60,16 +65,18 @@ func TestToggleEnabled_Success(t *testing.T) {
 
 func TestToggleEnabled_ParsingFailure(t *testing.T) {
 	acService := new(service.MockACService)
-	acService.EXPECT().ToggleEnabled().Return(domain.ACInfo{Enabled: false, Temperature: 0, Humidity: 0}, utils.ErrParsingFailed)
+	acService.EXPECT().ToggleEnabled(mock.Anything).Return(domain.ACInfo{Enabled: false, Temperature: 0, Humidity: 0}, utils.ErrParsingFailed)
 
 	acHandler := NewACHandler(acService)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
 	acHandler.ToggleEnabled(c)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	assert.Equal(t, "Parsing failed", w.Body.String())
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Gateway", "status": 502, "code": "INVALID_RESPONSE", "detail": "Parsing failed"}`,
+		w.Body.String())
 }
 
 func TestGetACLogsLimitN_Success(t *testing.T) {
@@ -78,7 +85,7 @@ func TestGetACLogsLimitN_Success(t *testing.T) {
 		{ID: 1, CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), IsEnabled: true, Temperature: 20, Humidity: 50},
 		{ID: 2, CreatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), IsEnabled: false, Temperature: 25, Humidity: 45},
 	}
-	acService.EXPECT().GetACLogsFromDataServiceLimitN(2).Return(expectedLogs, nil)
+	acService.EXPECT().QueryACLogsFromDataService(domain.LogQuery{Limit: 2}).Return(domain.LogPage[domain.ACData]{Logs: expectedLogs}, nil)
 
 	acHandler := NewACHandler(acService)
 
@@ -108,7 +115,7 @@ func TestGetACLogsLimitN_Success(t *testing.T) {
 
 func TestGetACLogsLimitN_ParsingFailure(t *testing.T) {
 	acService := new(service.MockACService)
-	acService.EXPECT().GetACLogsFromDataServiceLimitN(2).Return([]domain.ACData{}, utils.ErrParsingFailed)
+	acService.EXPECT().QueryACLogsFromDataService(domain.LogQuery{Limit: 2}).Return(domain.LogPage[domain.ACData]{Logs: []domain.ACData{}}, utils.ErrParsingFailed)
 
 	acHandler := NewACHandler(acService)
 
@@ -117,8 +124,9 @@ func TestGetACLogsLimitN_ParsingFailure(t *testing.T) {
 	c.Request, _ = http.NewRequest(http.MethodGet, "/?limit=2", nil)
 	acHandler.GetACLogsLimitN(c)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	assert.Equal(t, "Parsing failed", w.Body.String())
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Gateway", "status": 502, "code": "INVALID_RESPONSE", "detail": "Parsing failed"}`,
+		w.Body.String())
 }
 
 func TestGetACLogsLimitN_InvalidLimit(t *testing.T) {
@@ -132,14 +140,15 @@ func TestGetACLogsLimitN_InvalidLimit(t *testing.T) {
 	acHandler.GetACLogsLimitN(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Equal(t, "Invalid limit parameter", w.Body.String())
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Request", "status": 400, "code": "BAD_REQUEST", "detail": "Invalid limit parameter"}`,
+		w.Body.String())
 }
 
 func TestUpdateACSettings_Success(t *testing.T) {
 	desiredTemp := float32(20)
 	desiredHum := float32(50)
 	acService := new(service.MockACService)
-	acService.EXPECT().UpdateACSettings(desiredTemp, desiredHum).Return(domain.ACInfo{Enabled: true, Temperature: desiredTemp, Humidity: desiredHum}, nil)
+	acService.EXPECT().UpdateACSettings(domain.ACInfo{Enabled: true, Temperature: desiredTemp, Humidity: desiredHum}).Return(domain.ACInfo{Enabled: true, Temperature: desiredTemp, Humidity: desiredHum}, nil)
 
 	acHandler := NewACHandler(acService)
 
@@ -174,7 +183,7 @@ func TestUpdateACSettings_Error(t *testing.T) {
 	desiredTemp := float32(20)
 	desiredHum := float32(50)
 	acService := new(service.MockACService)
-	acService.EXPECT().UpdateACSettings(desiredTemp, desiredHum).Return(domain.ACInfo{}, errors.New("error"))
+	acService.EXPECT().UpdateACSettings(domain.ACInfo{Enabled: true, Temperature: desiredTemp, Humidity: desiredHum}).Return(domain.ACInfo{}, errors.New("error"))
 
 	acHandler := NewACHandler(acService)
 
@@ -185,5 +194,154 @@ func TestUpdateACSettings_Error(t *testing.T) {
 	acHandler.UpdateACSettings(c)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	assert.Equal(t, "error", w.Body.String())
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Internal Server Error", "status": 500, "code": "INTERNAL_ERROR", "detail": "error"}`,
+		w.Body.String())
+}
+
+func TestGetInfo_SetsETag(t *testing.T) {
+	acService := new(service.MockACService)
+	acInfo := domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50}
+	acService.EXPECT().GetInfo().Return(acInfo, nil)
+	expectedETag, _ := httpUtils.ComputeETag(acInfo)
+
+	acHandler := NewACHandler(acService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	acHandler.GetInfo(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, expectedETag, w.Header().Get(httpUtils.ETagHeader))
+}
+
+func TestUpdateACSettings_IfMatchSuccess(t *testing.T) {
+	desiredTemp := float32(22)
+	desiredHum := float32(45)
+	currentInfo := domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50}
+	updatedInfo := domain.ACInfo{Enabled: true, Temperature: desiredTemp, Humidity: desiredHum}
+	currentETag, _ := httpUtils.ComputeETag(currentInfo)
+	updatedETag, _ := httpUtils.ComputeETag(updatedInfo)
+	acService := new(service.MockACService)
+	acService.EXPECT().ReadInfo(mock.Anything).Return(currentInfo, nil)
+	acService.EXPECT().UpdateACSettings(domain.ACInfo{Enabled: true, Temperature: desiredTemp, Humidity: desiredHum}).Return(updatedInfo, nil)
+
+	acHandler := NewACHandler(acService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"enabled":true,"temperature":22,"humidity":45}`))
+	c.Request.Header.Set(httpUtils.IfMatchHeader, currentETag)
+
+	acHandler.UpdateACSettings(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, updatedETag, w.Header().Get(httpUtils.ETagHeader))
+}
+
+func TestUpdateACSettings_PreconditionFailed(t *testing.T) {
+	acService := new(service.MockACService)
+	acService.EXPECT().ReadInfo(mock.Anything).Return(domain.ACInfo{Enabled: true, Temperature: 24, Humidity: 50}, nil)
+
+	acHandler := NewACHandler(acService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"enabled":true,"temperature":22,"humidity":45}`))
+	c.Request.Header.Set(httpUtils.IfMatchHeader, `"stale"`)
+
+	acHandler.UpdateACSettings(c)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	acService.AssertNotCalled(t, "UpdateACSettings", mock.Anything)
+}
+
+func TestToggleEnabled_PreconditionFailed(t *testing.T) {
+	acService := new(service.MockACService)
+	acService.EXPECT().ReadInfo(mock.Anything).Return(domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50}, nil)
+
+	acHandler := NewACHandler(acService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	c.Request.Header.Set(httpUtils.IfMatchHeader, `"stale"`)
+	acHandler.ToggleEnabled(c)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	acService.AssertNotCalled(t, "ToggleEnabled", mock.Anything)
+}
+
+func TestUpdateACSettings_ValidationFailure(t *testing.T) {
+	acService := new(service.MockACService)
+	validationErr := &controlStationUtils.ValidationError{}
+	validationErr.Add("temperature", "must be between 16 and 30")
+	acService.EXPECT().UpdateACSettings(domain.ACInfo{Enabled: true, Temperature: 80, Humidity: 50}).Return(domain.ACInfo{}, validationErr)
+
+	acHandler := NewACHandler(acService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"enabled":true,"temperature":80,"humidity":50}`))
+
+	acHandler.UpdateACSettings(c)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Unprocessable Entity", "status": 422, "code": "VALIDATION_FAILED",
+		"detail": "Validation failed: temperature: must be between 16 and 30", "errors": [{"field": "temperature", "message": "must be between 16 and 30"}]}`, w.Body.String())
+}
+
+func TestGetCapabilities(t *testing.T) {
+	acService := new(service.MockACService)
+	acService.EXPECT().GetCapabilities().Return(domain.ACCapabilities{
+		MinTemperature: 16, MaxTemperature: 30, TemperatureStep: 0.5,
+		MinHumidity: 30, MaxHumidity: 70, HumidityStep: 1,
+		Modes: []domain.ACMode{domain.ACModeCool}, FanSpeeds: 3, SupportsSwing: true,
+	})
+
+	acHandler := NewACHandler(acService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	acHandler.GetCapabilities(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"min_temperature": 16,
+		"max_temperature": 30,
+		"temperature_step": 0.5,
+		"min_humidity": 30,
+		"max_humidity": 70,
+		"humidity_step": 1,
+		"modes": ["cool"],
+		"fan_speeds": 3,
+		"supports_swing": true,
+		"supports_eco": false,
+		"supports_turbo": false
+	}`, w.Body.String())
+}
+
+func TestUpdateACSettings_ExtendedSettings(t *testing.T) {
+	swing := true
+	desiredSettings := domain.ACInfo{Enabled: true, Temperature: 22, Humidity: 45, Mode: domain.ACModeHeat, FanSpeed: 2, Swing: &swing}
+	acService := new(service.MockACService)
+	acService.EXPECT().UpdateACSettings(desiredSettings).Return(desiredSettings, nil)
+
+	acHandler := NewACHandler(acService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/",
+		strings.NewReader(`{"enabled":true,"temperature":22,"humidity":45,"mode":"heat","fan_speed":2,"swing":true}`))
+
+	acHandler.UpdateACSettings(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"enabled": true,
+		"temperature": 22,
+		"humidity": 45,
+		"mode": "heat",
+		"fan_speed": 2,
+		"swing": true
+	}`, w.Body.String())
 }
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	analogSensorService "github.com/pklimuk-eng-thesis/control-station/pkg/service/analog"
+)
+
+type AnalogSensorHandler struct {
+	service analogSensorService.AnalogSensorService
+	mu      sync.Mutex
+}
+
+func NewAnalogSensorHandler(service analogSensorService.AnalogSensorService) *AnalogSensorHandler {
+	return &AnalogSensorHandler{service: service}
+}
+
+func (h *AnalogSensorHandler) GetInfo(c *gin.Context) {
+	sensorInfo, err := h.service.GetInfo()
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	httpUtils.SetETag(c, sensorInfo)
+	c.IndentedJSON(http.StatusOK, &sensorInfo)
+}
+
+func (h *AnalogSensorHandler) ToggleEnabled(c *gin.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !httpUtils.CheckIfMatch(c, h.service.ReadInfo) {
+		return
+	}
+
+	sensorInfo, err := h.service.ToggleEnabled(c.Request.Context())
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	httpUtils.SetETag(c, sensorInfo)
+	c.IndentedJSON(http.StatusOK, &sensorInfo)
+}
+
+func (h *AnalogSensorHandler) GetThreshold(c *gin.Context) {
+	threshold := h.service.GetThreshold()
+	c.IndentedJSON(http.StatusOK, &threshold)
+}
+
+func (h *AnalogSensorHandler) GetAnalogSensorLogsLimitN(c *gin.Context) {
+	query, ok := httpUtils.ParseLogQuery(c)
+	if !ok {
+		return
+	}
+
+	sensorLogs, err := h.service.QueryAnalogSensorLogsFromDataService(query)
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	httpUtils.WriteLogPage(c, sensorLogs)
+}
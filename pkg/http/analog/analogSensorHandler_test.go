@@ -0,0 +1,140 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/analog"
+	"github.com/pklimuk-eng-thesis/control-station/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var readingTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestGetInfo_Success(t *testing.T) {
+	analogSensorService := new(service.MockAnalogSensorService)
+	analogSensorService.EXPECT().GetInfo().Return(domain.AnalogSensorInfo{
+		Enabled: true, Value: 21.5, Unit: "°C", Timestamp: readingTime, Detected: false,
+	}, nil)
+
+	analogSensorHandler := NewAnalogSensorHandler(analogSensorService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	analogSensorHandler.GetInfo(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"enabled": true,
+		"value": 21.5,
+		"unit": "°C",
+		"timestamp": "2023-01-01T00:00:00Z",
+		"detected": false
+	}`, w.Body.String())
+}
+
+func TestGetInfo_ParsingFailure(t *testing.T) {
+	analogSensorService := new(service.MockAnalogSensorService)
+	analogSensorService.EXPECT().GetInfo().Return(domain.AnalogSensorInfo{}, utils.ErrParsingFailed)
+
+	analogSensorHandler := NewAnalogSensorHandler(analogSensorService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	analogSensorHandler.GetInfo(c)
+
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Gateway", "status": 502, "code": "INVALID_RESPONSE", "detail": "Parsing failed"}`,
+		w.Body.String())
+}
+
+func TestToggleEnabled_Success(t *testing.T) {
+	analogSensorService := new(service.MockAnalogSensorService)
+	analogSensorService.EXPECT().ToggleEnabled(mock.Anything).Return(domain.AnalogSensorInfo{Enabled: false, Unit: "°C", Timestamp: readingTime}, nil)
+
+	analogSensorHandler := NewAnalogSensorHandler(analogSensorService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	analogSensorHandler.ToggleEnabled(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": false, "value": 0, "unit": "°C", "timestamp": "2023-01-01T00:00:00Z", "detected": false}`, w.Body.String())
+}
+
+func TestToggleEnabled_PreconditionFailed(t *testing.T) {
+	analogSensorService := new(service.MockAnalogSensorService)
+	analogSensorService.EXPECT().ReadInfo(mock.Anything).Return(domain.AnalogSensorInfo{Enabled: true, Value: 20, Unit: "°C"}, nil)
+
+	analogSensorHandler := NewAnalogSensorHandler(analogSensorService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	c.Request.Header.Set(httpUtils.IfMatchHeader, `"stale"`)
+	analogSensorHandler.ToggleEnabled(c)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	analogSensorService.AssertNotCalled(t, "ToggleEnabled", mock.Anything)
+}
+
+func TestGetThreshold(t *testing.T) {
+	above := float32(30)
+	analogSensorService := new(service.MockAnalogSensorService)
+	analogSensorService.EXPECT().GetThreshold().Return(domain.AnalogThreshold{Above: &above, Hysteresis: 1})
+
+	analogSensorHandler := NewAnalogSensorHandler(analogSensorService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	analogSensorHandler.GetThreshold(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"above": 30, "hysteresis": 1}`, w.Body.String())
+}
+
+func TestGetAnalogSensorLogsLimitN_Success(t *testing.T) {
+	analogSensorService := new(service.MockAnalogSensorService)
+	analogSensorService.EXPECT().QueryAnalogSensorLogsFromDataService(domain.LogQuery{Limit: 1}).Return(domain.LogPage[domain.AnalogSensorData]{Logs: []domain.AnalogSensorData{
+		{ID: 1, CreatedAt: readingTime, IsEnabled: true, Value: 31, Unit: "°C", Timestamp: readingTime, Detected: true},
+	}}, nil)
+
+	analogSensorHandler := NewAnalogSensorHandler(analogSensorService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?limit=1", nil)
+	analogSensorHandler.GetAnalogSensorLogsLimitN(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{
+		"id": 1,
+		"created_at": "2023-01-01T00:00:00Z",
+		"is_enabled": true,
+		"value": 31,
+		"unit": "°C",
+		"timestamp": "2023-01-01T00:00:00Z",
+		"detected": true
+	}]`, w.Body.String())
+}
+
+func TestGetAnalogSensorLogsLimitN_InvalidLimit(t *testing.T) {
+	analogSensorService := new(service.MockAnalogSensorService)
+	analogSensorHandler := NewAnalogSensorHandler(analogSensorService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?limit=invalid", nil)
+	analogSensorHandler.GetAnalogSensorLogsLimitN(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Request", "status": 400, "code": "BAD_REQUEST", "detail": "Invalid limit parameter"}`,
+		w.Body.String())
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	analyticsService "github.com/pklimuk-eng-thesis/control-station/pkg/service/analytics"
+)
+
+type AnalyticsHandler struct {
+	service analyticsService.AnalyticsService
+}
+
+func NewAnalyticsHandler(service analyticsService.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{service: service}
+}
+
+// GetAnalytics returns the series of the metric for the bucket, from and to
+// parameters, each of which falls back to the default of the metric.
+func (h *AnalyticsHandler) GetAnalytics(metric domain.AnalyticsMetric) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		from, to, ok := httpUtils.ParseTimeRange(c)
+		if !ok {
+			return
+		}
+
+		series, err := h.service.GetAnalytics(metric,
+			domain.AnalyticsQuery{Bucket: domain.AnalyticsBucket(c.Query("bucket")), From: from, To: to})
+		if err != nil {
+			httpUtils.WriteServiceError(c, err)
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, &series)
+	}
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/analytics"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAnalytics_Success(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	analyticsService := new(service.MockAnalyticsService)
+	analyticsService.EXPECT().GetAnalytics(domain.MetricOnTime,
+		domain.AnalyticsQuery{Bucket: domain.BucketDay, From: &from, To: &to},
+	).Return([]domain.AnalyticsSeries{{Metric: domain.MetricOnTime, Device: "smartBulb", Bucket: domain.BucketDay,
+		Unit: "seconds", From: from, To: to,
+		Points: []domain.AnalyticsPoint{{Start: &from, Value: 1800, ObservedSeconds: 86400}}}}, nil)
+
+	analyticsHandler := NewAnalyticsHandler(analyticsService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet,
+		"/analytics/on-time?bucket=day&from=2023-01-01T00:00:00Z&to=2023-01-02T00:00:00Z", nil)
+	analyticsHandler.GetAnalytics(domain.MetricOnTime)(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{
+		"metric": "on_time",
+		"device": "smartBulb",
+		"bucket": "day",
+		"unit": "seconds",
+		"from": "2023-01-01T00:00:00Z",
+		"to": "2023-01-02T00:00:00Z",
+		"points": [{"start": "2023-01-01T00:00:00Z", "value": 1800, "observed_seconds": 86400}]
+	}]`, w.Body.String())
+}
+
+func TestGetAnalytics_ValidationFailure(t *testing.T) {
+	validationErr := &controlStationUtils.ValidationError{}
+	validationErr.Add("bucket", "Unknown analytics bucket: month")
+	analyticsService := new(service.MockAnalyticsService)
+	analyticsService.EXPECT().GetAnalytics(domain.MetricPresence, domain.AnalyticsQuery{Bucket: "month"}).
+		Return(nil, validationErr)
+
+	analyticsHandler := NewAnalyticsHandler(analyticsService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/analytics/presence?bucket=month", nil)
+	analyticsHandler.GetAnalytics(domain.MetricPresence)(c)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestGetAnalytics_InvalidTo(t *testing.T) {
+	analyticsService := new(service.MockAnalyticsService)
+	analyticsHandler := NewAnalyticsHandler(analyticsService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/analytics/presence?to=now", nil)
+	analyticsHandler.GetAnalytics(domain.MetricPresence)(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	analyticsService.AssertNotCalled(t, "GetAnalytics")
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	arbitrationService "github.com/pklimuk-eng-thesis/control-station/pkg/service/arbitration"
+)
+
+type ArbitrationHandler struct {
+	service arbitrationService.ArbitrationService
+}
+
+func NewArbitrationHandler(service arbitrationService.ArbitrationService) *ArbitrationHandler {
+	return &ArbitrationHandler{service: service}
+}
+
+func (h *ArbitrationHandler) ListControls(c *gin.Context) {
+	controls := h.service.ListControls()
+	c.IndentedJSON(http.StatusOK, &controls)
+}
+
+func (h *ArbitrationHandler) GetControl(c *gin.Context) {
+	control, err := h.service.GetControl(c.Param("name"))
+	if err != nil {
+		writeArbitrationError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &control)
+}
+
+func (h *ArbitrationHandler) Release(c *gin.Context) {
+	control, err := h.service.Release(c.Param("name"))
+	if err != nil {
+		writeArbitrationError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &control)
+}
+
+// Track records the successful PATCH requests to the endpoints of a device as
+// user commands, which start a manual override of the device. It has to be used
+// before the device routes are set up.
+func Track(service arbitrationService.ArbitrationService, deviceRegistry registry.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if c.Request.Method != http.MethodPatch || c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+
+		path := c.Request.URL.Path
+		for _, device := range deviceRegistry.List() {
+			if device.Route != "" && strings.HasPrefix(path, device.Route+"/") {
+				service.Command(device.Name, domain.SourceUser)
+				return
+			}
+		}
+	}
+}
+
+func writeArbitrationError(c *gin.Context, err error) {
+	if errors.Is(err, registry.ErrDeviceNotRegistered) {
+		httpUtils.WriteProblem(c, http.StatusNotFound, err.Error())
+		return
+	}
+	httpUtils.WriteServiceError(c, err)
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/arbitration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListControls(t *testing.T) {
+	since := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	arbitrationService := new(service.MockArbitrationService)
+	arbitrationService.EXPECT().ListControls().Return([]domain.DeviceControl{
+		{Device: "smartBulb", Source: domain.SourceUser, Since: &since},
+	})
+
+	arbitrationHandler := NewArbitrationHandler(arbitrationService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	arbitrationHandler.ListControls(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"device": "smartBulb", "source": "user", "since": "2024-03-01T12:00:00Z"}]`, w.Body.String())
+}
+
+func TestGetControl(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", wantCode: http.StatusOK},
+		{name: "NotRegistered", err: registry.ErrDeviceNotRegistered, wantCode: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			arbitrationService := new(service.MockArbitrationService)
+			arbitrationService.EXPECT().GetControl("smartBulb").Return(domain.DeviceControl{}, test.err)
+
+			arbitrationHandler := NewArbitrationHandler(arbitrationService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "smartBulb"}}
+			arbitrationHandler.GetControl(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestRelease(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", wantCode: http.StatusOK},
+		{name: "NotRegistered", err: registry.ErrDeviceNotRegistered, wantCode: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			arbitrationService := new(service.MockArbitrationService)
+			arbitrationService.EXPECT().Release("smartBulb").Return(domain.DeviceControl{}, test.err)
+
+			arbitrationHandler := NewArbitrationHandler(arbitrationService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+			c.Params = gin.Params{{Key: "name", Value: "smartBulb"}}
+			arbitrationHandler.Release(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestTrack(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		status      int
+		wantCommand bool
+	}{
+		{name: "Command", method: http.MethodPatch, path: "/smartBulb/enabled", status: http.StatusOK, wantCommand: true},
+		{name: "Failed", method: http.MethodPatch, path: "/smartBulb/enabled", status: http.StatusBadGateway},
+		{name: "Read", method: http.MethodGet, path: "/smartBulb/info", status: http.StatusOK},
+		{name: "OtherRoute", method: http.MethodPatch, path: "/away/enter", status: http.StatusOK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			arbitrationService := new(service.MockArbitrationService)
+			if test.wantCommand {
+				arbitrationService.EXPECT().Command("smartBulb", domain.SourceUser).Return(nil).Once()
+			}
+			deviceRegistry := registry.NewRegistry()
+			deviceRegistry.Register(domain.RegisteredDevice{Name: "smartBulb", Kind: domain.KindLight, Route: "/smartBulb"})
+
+			r := gin.New()
+			r.Use(Track(arbitrationService, deviceRegistry))
+			r.Handle(test.method, "/*path", func(c *gin.Context) { c.Status(test.status) })
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(test.method, test.path, nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.status, w.Code)
+			arbitrationService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	awayService "github.com/pklimuk-eng-thesis/control-station/pkg/service/away"
+	securityService "github.com/pklimuk-eng-thesis/control-station/pkg/service/security"
+)
+
+type AwayHandler struct {
+	service awayService.AwayService
+}
+
+func NewAwayHandler(service awayService.AwayService) *AwayHandler {
+	return &AwayHandler{service: service}
+}
+
+func (h *AwayHandler) GetState(c *gin.Context) {
+	awayState := h.service.GetState()
+	c.IndentedJSON(http.StatusOK, &awayState)
+}
+
+func (h *AwayHandler) Enter(c *gin.Context) {
+	awayState, err := h.service.Enter(c.ClientIP())
+	if err != nil {
+		writeAwayError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &awayState)
+}
+
+// Leave accepts an empty body when the security subsystem is disabled or the
+// house was armed away before.
+func (h *AwayHandler) Leave(c *gin.Context) {
+	var request domain.AwayLeaveRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil && !errors.Is(err, io.EOF) {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	awayState, err := h.service.Leave(request.PIN, c.ClientIP())
+	if err != nil {
+		writeAwayError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &awayState)
+}
+
+func writeAwayError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, awayService.ErrAlreadyAway), errors.Is(err, awayService.ErrNotAway),
+		errors.Is(err, securityService.ErrDisarmRequired):
+		httpUtils.WriteProblem(c, http.StatusConflict, err.Error())
+	case errors.Is(err, securityService.ErrInvalidPIN):
+		httpUtils.WriteProblem(c, http.StatusForbidden, err.Error())
+	default:
+		httpUtils.WriteServiceError(c, err)
+	}
+}
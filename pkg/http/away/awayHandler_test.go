@@ -0,0 +1,103 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/away"
+	securityService "github.com/pklimuk-eng-thesis/control-station/pkg/service/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetState(t *testing.T) {
+	awayService := new(service.MockAwayService)
+	awayService.EXPECT().GetState().Return(domain.AwayState{Active: false})
+
+	awayHandler := NewAwayHandler(awayService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	awayHandler.GetState(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"active": false}`, w.Body.String())
+}
+
+func TestEnter(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", wantCode: http.StatusOK},
+		{name: "AlreadyAway", err: service.ErrAlreadyAway, wantCode: http.StatusConflict},
+		{name: "AlarmTriggered", err: securityService.ErrDisarmRequired, wantCode: http.StatusConflict},
+		{name: "Failure", err: errors.New("failure"), wantCode: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			awayService := new(service.MockAwayService)
+			awayService.EXPECT().Enter(mock.Anything).Return(domain.AwayState{}, test.err)
+
+			awayHandler := NewAwayHandler(awayService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+			awayHandler.Enter(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestLeave(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantPIN  string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", body: `{"pin": "1234"}`, wantPIN: "1234", wantCode: http.StatusOK},
+		{name: "EmptyBody", body: ``, wantPIN: "", wantCode: http.StatusOK},
+		{name: "NotAway", body: `{}`, err: service.ErrNotAway, wantCode: http.StatusConflict},
+		{name: "InvalidPIN", body: `{"pin": "0000"}`, wantPIN: "0000", err: securityService.ErrInvalidPIN,
+			wantCode: http.StatusForbidden},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			awayService := new(service.MockAwayService)
+			awayService.EXPECT().Leave(test.wantPIN, mock.Anything).Return(domain.AwayState{}, test.err)
+
+			awayHandler := NewAwayHandler(awayService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(test.body))
+			awayHandler.Leave(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestLeave_InvalidBody(t *testing.T) {
+	awayService := new(service.MockAwayService)
+
+	awayHandler := NewAwayHandler(awayService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"pin": 1234}`))
+	awayHandler.Leave(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	awayService.AssertNotCalled(t, "Leave", mock.Anything, mock.Anything)
+}
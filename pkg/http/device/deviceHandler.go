@@ -1,15 +1,22 @@
 package http
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"sync"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
 	deviceService "github.com/pklimuk-eng-thesis/control-station/pkg/service/device"
 )
 
 type DeviceHandler struct {
 	service deviceService.DeviceService
+	mu      sync.Mutex
 }
 
 func NewDeviceHandler(service deviceService.DeviceService) *DeviceHandler {
@@ -19,36 +26,159 @@ func NewDeviceHandler(service deviceService.DeviceService) *DeviceHandler {
 func (h *DeviceHandler) GetInfo(c *gin.Context) {
 	deviceInfo, err := h.service.GetInfo()
 	if err != nil {
-		c.String(http.StatusInternalServerError, err.Error())
+		httpUtils.WriteServiceError(c, err)
 		return
 	}
 
+	httpUtils.SetETag(c, deviceInfo)
 	c.IndentedJSON(http.StatusOK, &deviceInfo)
 }
 
-func (h *DeviceHandler) ToggleEnabled(c *gin.Context) {
-	deviceInfo, err := h.service.ToggleEnabled()
-	if err != nil {
-		c.String(http.StatusInternalServerError, err.Error())
+// Invoke returns a handler that changes the device state through the endpoint
+// of the capability. The request body is optional and validated for the
+// capabilities that take one.
+func (h *DeviceHandler) Invoke(capability domain.Capability, endpoint string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reqBody, err := readOptionalBody(c)
+		if err != nil {
+			httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if !httpUtils.CheckIfMatch(c, h.service.ReadInfo) {
+			return
+		}
+
+		deviceInfo, err := h.service.Invoke(c.Request.Context(), capability, endpoint, reqBody, c.ClientIP())
+		h.writeState(c, deviceInfo, err)
+	}
+}
+
+// RequestConfirmation returns a handler that starts a command that needs
+// confirmation. The device state does not change until the returned token is
+// sent to the handler returned by Confirm.
+func (h *DeviceHandler) RequestConfirmation(capability domain.Capability, endpoint string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		challenge, err := h.service.RequestConfirmation(capability, endpoint, c.ClientIP())
+		if err != nil {
+			writeDeviceError(c, err)
+			return
+		}
+
+		c.IndentedJSON(http.StatusAccepted, &challenge)
+	}
+}
+
+func (h *DeviceHandler) Confirm(capability domain.Capability, endpoint string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var confirmation domain.CommandConfirmation
+		err := c.ShouldBindJSON(&confirmation)
+		if err != nil {
+			httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if !httpUtils.CheckIfMatch(c, h.service.ReadInfo) {
+			return
+		}
+
+		deviceInfo, err := h.service.Confirm(c.Request.Context(), capability, endpoint, confirmation.Token, c.ClientIP())
+		h.writeState(c, deviceInfo, err)
+	}
+}
+
+// GetState returns the cached device state, which follows the progress of a
+// movement without querying the device on every request.
+func (h *DeviceHandler) GetState(c *gin.Context) {
+	deviceState, ok := h.service.GetState()
+	if !ok {
+		httpUtils.WriteProblem(c, http.StatusNotFound, "No state available yet")
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, &deviceInfo)
+	c.IndentedJSON(http.StatusOK, &deviceState)
+}
+
+func (h *DeviceHandler) GetCapabilities(c *gin.Context) {
+	capabilities := h.service.GetCapabilities()
+	c.IndentedJSON(http.StatusOK, &capabilities)
 }
 
 func (h *DeviceHandler) GetDeviceLogsLimitN(c *gin.Context) {
-	limitStr := c.Query("limit")
-	limit, err := strconv.Atoi(limitStr)
+	query, ok := httpUtils.ParseLogQuery(c)
+	if !ok {
+		return
+	}
+
+	deviceLogs, err := h.service.QueryDeviceLogsFromDataService(query)
 	if err != nil {
-		c.String(http.StatusBadRequest, "Invalid limit parameter")
+		httpUtils.WriteServiceError(c, err)
 		return
 	}
 
-	deviceLogs, err := h.service.GetDeviceLogsFromDataServiceLimitN(limit)
+	httpUtils.WriteLogPage(c, deviceLogs)
+}
+
+func (h *DeviceHandler) GetAuditLogsLimitN(c *gin.Context) {
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, "Invalid limit parameter")
+		return
+	}
+
+	auditLogs, err := h.service.GetAuditLogsFromDataServiceLimitN(limit)
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &auditLogs)
+}
+
+func (h *DeviceHandler) writeState(c *gin.Context, deviceInfo domain.DeviceState, err error) {
 	if err != nil {
-		c.String(http.StatusInternalServerError, err.Error())
+		writeDeviceError(c, err)
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, &deviceLogs)
+	httpUtils.SetETag(c, deviceInfo)
+	c.IndentedJSON(http.StatusOK, &deviceInfo)
+}
+
+func writeDeviceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, deviceService.ErrCapabilityNotSupported):
+		httpUtils.WriteProblem(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, deviceService.ErrConfirmationRequired),
+		errors.Is(err, deviceService.ErrInvalidConfirmationToken):
+		httpUtils.WriteProblem(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, deviceService.ErrJammed):
+		httpUtils.WriteProblem(c, http.StatusConflict, err.Error())
+	default:
+		httpUtils.WriteServiceError(c, err)
+	}
+}
+
+func readOptionalBody(c *gin.Context) (domain.DeviceState, error) {
+	if c.Request.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil || len(body) == 0 {
+		return nil, err
+	}
+
+	var reqBody domain.DeviceState
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		return nil, err
+	}
+	return reqBody, nil
 }
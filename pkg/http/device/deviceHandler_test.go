@@ -1,21 +1,25 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
 	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/device"
 	"github.com/pklimuk-eng-thesis/control-station/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetInfo_Success(t *testing.T) {
 	deviceService := new(service.MockDeviceService)
-	expectedDeviceInfo := domain.DeviceInfo{Enabled: true}
+	expectedDeviceInfo := domain.DeviceState{"enabled": true}
 	deviceService.EXPECT().GetInfo().Return(expectedDeviceInfo, nil)
 
 	deviceHandler := NewDeviceHandler(deviceService)
@@ -30,7 +34,7 @@ func TestGetInfo_Success(t *testing.T) {
 
 func TestGetInfo_ParsingFailure(t *testing.T) {
 	deviceService := new(service.MockDeviceService)
-	deviceService.EXPECT().GetInfo().Return(domain.DeviceInfo{Enabled: false}, utils.ErrParsingFailed)
+	deviceService.EXPECT().GetInfo().Return(domain.DeviceState{}, utils.ErrParsingFailed)
 
 	deviceHandler := NewDeviceHandler(deviceService)
 
@@ -38,45 +42,49 @@ func TestGetInfo_ParsingFailure(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 	deviceHandler.GetInfo(c)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	assert.Equal(t, "Parsing failed", w.Body.String())
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Gateway", "status": 502, "code": "INVALID_RESPONSE", "detail": "Parsing failed"}`,
+		w.Body.String())
 }
 
-func TestToggleEnabled_Success(t *testing.T) {
+func TestInvoke_Success(t *testing.T) {
 	deviceService := new(service.MockDeviceService)
-	deviceService.EXPECT().ToggleEnabled().Return(domain.DeviceInfo{Enabled: true}, nil)
+	deviceService.EXPECT().Invoke(mock.Anything, domain.CapabilitySwitchable, "/enabled", domain.DeviceState(nil), mock.Anything).Return(domain.DeviceState{"enabled": true}, nil)
 
 	deviceHandler := NewDeviceHandler(deviceService)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
-	deviceHandler.ToggleEnabled(c)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	deviceHandler.Invoke(domain.CapabilitySwitchable, "/enabled")(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.JSONEq(t, `{"enabled": true}`, w.Body.String())
 }
 
-func TestToggleEnabled_ParsingFailure(t *testing.T) {
+func TestInvoke_ParsingFailure(t *testing.T) {
 	deviceService := new(service.MockDeviceService)
-	deviceService.EXPECT().ToggleEnabled().Return(domain.DeviceInfo{Enabled: false}, utils.ErrParsingFailed)
+	deviceService.EXPECT().Invoke(mock.Anything, domain.CapabilitySwitchable, "/enabled", domain.DeviceState(nil), mock.Anything).Return(domain.DeviceState{}, utils.ErrParsingFailed)
 
 	deviceHandler := NewDeviceHandler(deviceService)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
-	deviceHandler.ToggleEnabled(c)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	deviceHandler.Invoke(domain.CapabilitySwitchable, "/enabled")(c)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	assert.Equal(t, "Parsing failed", w.Body.String())
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Gateway", "status": 502, "code": "INVALID_RESPONSE", "detail": "Parsing failed"}`,
+		w.Body.String())
 }
 
 func TestGetDeviceLogsFromDataServiceLimitN_Success(t *testing.T) {
 	deviceService := new(service.MockDeviceService)
-	expectedLogs := []domain.DeviceData{
-		{ID: 1, CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), IsEnabled: true},
-		{ID: 2, CreatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), IsEnabled: false},
+	expectedLogs := []domain.DeviceState{
+		{"id": 1, "created_at": "2023-01-01T00:00:00Z", "is_enabled": true},
+		{"id": 2, "created_at": "2023-01-02T00:00:00Z", "is_enabled": false},
 	}
-	deviceService.EXPECT().GetDeviceLogsFromDataServiceLimitN(2).Return(expectedLogs, nil)
+	deviceService.EXPECT().QueryDeviceLogsFromDataService(domain.LogQuery{Limit: 2}).Return(domain.LogPage[domain.DeviceState]{Logs: expectedLogs}, nil)
 
 	deviceHandler := NewDeviceHandler(deviceService)
 
@@ -101,7 +109,7 @@ func TestGetDeviceLogsFromDataServiceLimitN_Success(t *testing.T) {
 
 func TestGetDeviceLogsFromDataServiceLimitN_ParsingFailure(t *testing.T) {
 	deviceService := new(service.MockDeviceService)
-	deviceService.EXPECT().GetDeviceLogsFromDataServiceLimitN(2).Return([]domain.DeviceData{}, utils.ErrParsingFailed)
+	deviceService.EXPECT().QueryDeviceLogsFromDataService(domain.LogQuery{Limit: 2}).Return(domain.LogPage[domain.DeviceState]{Logs: []domain.DeviceState{}}, utils.ErrParsingFailed)
 
 	deviceHandler := NewDeviceHandler(deviceService)
 
@@ -110,8 +118,9 @@ func TestGetDeviceLogsFromDataServiceLimitN_ParsingFailure(t *testing.T) {
 	c.Request, _ = http.NewRequest(http.MethodGet, "/?limit=2", nil)
 	deviceHandler.GetDeviceLogsLimitN(c)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	assert.Equal(t, "Parsing failed", w.Body.String())
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Gateway", "status": 502, "code": "INVALID_RESPONSE", "detail": "Parsing failed"}`,
+		w.Body.String())
 }
 
 func TestGetDeviceLogsFromDataServiceLimitN_InvalidLimit(t *testing.T) {
@@ -125,5 +134,267 @@ func TestGetDeviceLogsFromDataServiceLimitN_InvalidLimit(t *testing.T) {
 	deviceHandler.GetDeviceLogsLimitN(c)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Equal(t, "Invalid limit parameter", w.Body.String())
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Request", "status": 400, "code": "BAD_REQUEST", "detail": "Invalid limit parameter"}`,
+		w.Body.String())
+}
+
+func TestInvoke_PreconditionFailed(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+	deviceService.EXPECT().ReadInfo(mock.Anything).Return(domain.DeviceState{"enabled": true}, nil)
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	c.Request.Header.Set(httpUtils.IfMatchHeader, `"stale"`)
+	deviceHandler.Invoke(domain.CapabilitySwitchable, "/enabled")(c)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	deviceService.AssertNotCalled(t, "Invoke", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInvoke_IfMatchSuccess(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+	currentInfo := domain.DeviceState{"enabled": false}
+	currentETag, _ := httpUtils.ComputeETag(currentInfo)
+	deviceService.EXPECT().ReadInfo(mock.Anything).Return(currentInfo, nil)
+	deviceService.EXPECT().Invoke(mock.Anything, domain.CapabilitySwitchable, "/enabled", domain.DeviceState(nil), mock.Anything).Return(domain.DeviceState{"enabled": true}, nil)
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	c.Request.Header.Set(httpUtils.IfMatchHeader, currentETag)
+	deviceHandler.Invoke(domain.CapabilitySwitchable, "/enabled")(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": true}`, w.Body.String())
+}
+
+func TestInvoke_Detectable(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+	deviceService.EXPECT().Invoke(mock.Anything, domain.CapabilityDetectable, "/detected", domain.DeviceState(nil), mock.Anything).
+		Return(domain.DeviceState{"enabled": true, "detected": true}, nil)
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	deviceHandler.Invoke(domain.CapabilityDetectable, "/detected")(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": true, "detected": true}`, w.Body.String())
+	assert.NotEmpty(t, w.Header().Get(httpUtils.ETagHeader))
+}
+
+func TestInvoke_WithBody(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+	deviceService.EXPECT().Invoke(mock.Anything, domain.CapabilityDimmable, "/brightness", domain.DeviceState{"brightness": float64(40)}, mock.Anything).
+		Return(domain.DeviceState{"brightness": 40}, nil)
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"brightness": 40}`))
+	deviceHandler.Invoke(domain.CapabilityDimmable, "/brightness")(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"brightness": 40}`, w.Body.String())
+}
+
+func TestInvoke_InvalidBody(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`invalid`))
+	deviceHandler.Invoke(domain.CapabilitySwitchable, "/enabled")(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	deviceService.AssertNotCalled(t, "Invoke", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInvoke_CapabilityNotSupported(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+	deviceService.EXPECT().Invoke(mock.Anything, domain.CapabilityColor, "/color", domain.DeviceState(nil), mock.Anything).
+		Return(domain.DeviceState{}, service.ErrCapabilityNotSupported)
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	deviceHandler.Invoke(domain.CapabilityColor, "/color")(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetCapabilities(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+	deviceService.EXPECT().GetCapabilities().
+		Return([]domain.Capability{domain.CapabilitySwitchable, domain.CapabilityDetectable})
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	deviceHandler.GetCapabilities(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `["switchable", "detectable"]`, w.Body.String())
+}
+
+func TestInvoke_ConfirmationRequired(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+	deviceService.EXPECT().Invoke(mock.Anything, domain.CapabilityLockable, "/unlock", domain.DeviceState(nil), mock.Anything).
+		Return(domain.DeviceState{}, service.ErrConfirmationRequired)
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	deviceHandler.Invoke(domain.CapabilityLockable, "/unlock")(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestRequestConfirmation(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+	deviceService.EXPECT().RequestConfirmation(domain.CapabilityLockable, "/unlock", mock.Anything).
+		Return(domain.CommandChallenge{Token: "abc", ExpiresAt: time.Date(2023, 1, 1, 0, 0, 30, 0, time.UTC)}, nil)
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	deviceHandler.RequestConfirmation(domain.CapabilityLockable, "/unlock")(c)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.JSONEq(t, `{"token": "abc", "expires_at": "2023-01-01T00:00:30Z"}`, w.Body.String())
+}
+
+func TestRequestConfirmation_Failure(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+	deviceService.EXPECT().RequestConfirmation(domain.CapabilityLockable, "/unlock", mock.Anything).
+		Return(domain.CommandChallenge{}, errors.New("audit unavailable"))
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	deviceHandler.RequestConfirmation(domain.CapabilityLockable, "/unlock")(c)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    domain.DeviceState
+		err      error
+		wantCode int
+	}{
+		{name: "Success", state: domain.DeviceState{"state": "unlocked"}, wantCode: http.StatusOK},
+		{name: "InvalidToken", err: service.ErrInvalidConfirmationToken, wantCode: http.StatusForbidden},
+		{name: "Jammed", state: domain.DeviceState{"state": "jammed"}, err: service.ErrJammed,
+			wantCode: http.StatusConflict},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deviceService := new(service.MockDeviceService)
+			deviceService.EXPECT().Confirm(mock.Anything, domain.CapabilityLockable, "/unlock", "abc", mock.Anything).
+				Return(test.state, test.err)
+
+			deviceHandler := NewDeviceHandler(deviceService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"token": "abc"}`))
+			deviceHandler.Confirm(domain.CapabilityLockable, "/unlock")(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestConfirm_MissingToken(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{}`))
+	deviceHandler.Confirm(domain.CapabilityLockable, "/unlock")(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	deviceService.AssertNotCalled(t, "Confirm", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetState(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    domain.CachedState
+		ok       bool
+		wantCode int
+	}{
+		{name: "Cached", state: domain.CachedState{Device: "test", State: domain.DeviceState{"moving": true}},
+			ok: true, wantCode: http.StatusOK},
+		{name: "Empty", wantCode: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deviceService := new(service.MockDeviceService)
+			deviceService.EXPECT().GetState().Return(test.state, test.ok)
+
+			deviceHandler := NewDeviceHandler(deviceService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			deviceHandler.GetState(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestGetAuditLogsLimitN_Success(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+	deviceService.EXPECT().GetAuditLogsFromDataServiceLimitN(1).Return([]domain.AuditEntry{
+		{Device: "doorLock", Command: "lock", Result: domain.AuditSuccess, Source: "10.0.0.1",
+			Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil)
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?limit=1", nil)
+	deviceHandler.GetAuditLogsLimitN(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"device": "doorLock", "command": "lock", "result": "success", "source": "10.0.0.1",
+		"time": "2023-01-01T00:00:00Z"}]`, w.Body.String())
+}
+
+func TestGetAuditLogsLimitN_InvalidLimit(t *testing.T) {
+	deviceService := new(service.MockDeviceService)
+
+	deviceHandler := NewDeviceHandler(deviceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?limit=invalid", nil)
+	deviceHandler.GetAuditLogsLimitN(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
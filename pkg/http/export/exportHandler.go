@@ -0,0 +1,109 @@
+package http
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	exportService "github.com/pklimuk-eng-thesis/control-station/pkg/service/export"
+)
+
+var contentTypes = map[domain.ExportFormat]string{
+	domain.ExportCSV:      "text/csv; charset=utf-8",
+	domain.ExportNDJSON:   "application/x-ndjson",
+	domain.ExportColumnar: "application/octet-stream",
+}
+
+type ExportHandler struct {
+	service exportService.ExportService
+}
+
+func NewExportHandler(service exportService.ExportService) *ExportHandler {
+	return &ExportHandler{service: service}
+}
+
+// ExportAll exports the logs of the devices listed in the comma separated
+// devices parameter, or of all devices without it.
+func (h *ExportHandler) ExportAll(c *gin.Context) {
+	var devices []string
+	if value := c.Query("devices"); value != "" {
+		devices = strings.Split(value, ",")
+	}
+	h.export(c, "devices", devices)
+}
+
+func (h *ExportHandler) ExportDevice(deviceName string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.export(c, deviceName, []string{deviceName})
+	}
+}
+
+// export streams the export as the response, gzip compressed when the client
+// accepts it or asks for it with compression=gzip. An export that fails once
+// streaming has started can only be cut off; the gzip stream is then left
+// unterminated, so that the client notices. Whether the export is truncated is
+// only known at its end, so it is reported in the trailers.
+func (h *ExportHandler) export(c *gin.Context, fileName string, devices []string) {
+	from, to, ok := httpUtils.ParseTimeRange(c)
+	if !ok {
+		return
+	}
+	format := domain.ExportFormat(c.DefaultQuery("format", string(domain.ExportCSV)))
+	request := domain.ExportRequest{Devices: devices, Format: format, From: from, To: to}
+
+	c.Header("Content-Type", contentTypes[format])
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-logs.%s"`, fileName, format))
+	c.Header("Trailer", httpUtils.TruncatedHeader+", "+httpUtils.ScannedFromHeader)
+	var w io.Writer = c.Writer
+	var gz *gzip.Writer
+	if acceptsGzip(c) {
+		c.Header("Content-Encoding", "gzip")
+		c.Header("Vary", "Accept-Encoding")
+		gz = gzip.NewWriter(c.Writer)
+		w = gz
+	}
+	c.Status(http.StatusOK)
+
+	summary, err := h.service.Export(w, request)
+	if err != nil && !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Encoding")
+		c.Writer.Header().Del("Content-Disposition")
+		c.Writer.Header().Del("Trailer")
+		writeExportError(c, err)
+		return
+	}
+	if err != nil {
+		log.Printf("Export of '%s' cut off: %s\n", fileName, err)
+		return
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			log.Printf("Export of '%s' cut off: %s\n", fileName, err)
+			return
+		}
+	}
+	if summary.Truncated {
+		log.Printf("Export of '%s' truncated, the logs could only be searched since %v\n", fileName, summary.ScannedFrom)
+		httpUtils.SetTruncationHeaders(c, summary.Truncated, summary.ScannedFrom)
+	}
+}
+
+func acceptsGzip(c *gin.Context) bool {
+	return c.Query("compression") == "gzip" || strings.Contains(c.GetHeader("Accept-Encoding"), "gzip")
+}
+
+func writeExportError(c *gin.Context, err error) {
+	if errors.Is(err, registry.ErrDeviceNotRegistered) {
+		httpUtils.WriteProblem(c, http.StatusNotFound, err.Error())
+		return
+	}
+	httpUtils.WriteServiceError(c, err)
+}
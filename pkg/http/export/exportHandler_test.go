@@ -0,0 +1,118 @@
+package http
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportAll_Gzip(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	exportService := new(service.MockExportService)
+	exportService.EXPECT().Export(
+		mock.Anything, domain.ExportRequest{Devices: []string{"ac", "smartPlug"}, Format: domain.ExportNDJSON, From: &from},
+	).RunAndReturn(func(w io.Writer, request domain.ExportRequest) (domain.ExportSummary, error) {
+		_, err := fmt.Fprintln(w, `{"device":"ac","id":1}`)
+		return domain.ExportSummary{}, err
+	})
+
+	exportHandler := NewExportHandler(exportService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet,
+		"/export?devices=ac,smartPlug&format=ndjson&from=2023-01-01T00:00:00Z&compression=gzip", nil)
+	exportHandler.ExportAll(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="devices-logs.ndjson"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	reader, err := gzip.NewReader(w.Body)
+	assert.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"device":"ac","id":1}`+"\n", string(body))
+}
+
+func TestExportDevice_CSV(t *testing.T) {
+	exportService := new(service.MockExportService)
+	scannedFrom := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	exportService.EXPECT().Export(mock.Anything, domain.ExportRequest{Devices: []string{"ac"}, Format: domain.ExportCSV}).
+		RunAndReturn(func(w io.Writer, request domain.ExportRequest) (domain.ExportSummary, error) {
+			_, err := fmt.Fprint(w, "device,id\nac,1\n")
+			return domain.ExportSummary{Truncated: true, ScannedFrom: &scannedFrom}, err
+		})
+
+	exportHandler := NewExportHandler(exportService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/ac/export", nil)
+	exportHandler.ExportDevice("ac")(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="ac-logs.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "device,id\nac,1\n", w.Body.String())
+	trailer := w.Result().Trailer
+	assert.Equal(t, "true", trailer.Get(httpUtils.TruncatedHeader))
+	assert.Equal(t, "2023-01-01T00:00:00Z", trailer.Get(httpUtils.ScannedFromHeader))
+}
+
+func TestExport_Failure(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "UnknownDevice", err: fmt.Errorf("heater: %w", registry.ErrDeviceNotRegistered),
+			wantStatus: http.StatusNotFound},
+		{name: "Other", err: fmt.Errorf("failure"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exportService := new(service.MockExportService)
+			exportService.EXPECT().Export(mock.Anything, domain.ExportRequest{Format: domain.ExportCSV}).Return(domain.ExportSummary{}, test.err)
+
+			exportHandler := NewExportHandler(exportService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/export", nil)
+			c.Request.Header.Set("Accept-Encoding", "gzip")
+			exportHandler.ExportAll(c)
+
+			assert.Equal(t, test.wantStatus, w.Code)
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+			assert.Empty(t, w.Header().Get("Content-Encoding"))
+			assert.Empty(t, w.Header().Get("Content-Disposition"))
+		})
+	}
+}
+
+func TestExport_InvalidFrom(t *testing.T) {
+	exportService := new(service.MockExportService)
+	exportHandler := NewExportHandler(exportService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/export?from=yesterday", nil)
+	exportHandler.ExportAll(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	exportService.AssertNotCalled(t, "Export")
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+)
+
+type GroupHandler struct {
+	service groupService.GroupService
+}
+
+func NewGroupHandler(service groupService.GroupService) *GroupHandler {
+	return &GroupHandler{service: service}
+}
+
+func (h *GroupHandler) ListGroups(c *gin.Context) {
+	groups := h.service.ListGroups()
+	c.IndentedJSON(http.StatusOK, &groups)
+}
+
+func (h *GroupHandler) GetGroupState(c *gin.Context) {
+	groupState, err := h.service.GetGroupState(c.Param("name"))
+	if err != nil {
+		writeGroupError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &groupState)
+}
+
+// SetGroupEnabled responds with 207 Multi-Status when only some members could
+// be switched and with 502 Bad Gateway when none of them could.
+func (h *GroupHandler) SetGroupEnabled(c *gin.Context) {
+	var request domain.GroupEnabledRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	groupState, err := h.service.SetGroupEnabled(c.Param("name"), *request.Enabled)
+	if err != nil {
+		writeGroupError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if len(groupState.Failed) > 0 {
+		status = http.StatusMultiStatus
+		if len(groupState.Succeeded) == 0 {
+			status = http.StatusBadGateway
+		}
+	}
+	c.IndentedJSON(status, &groupState)
+}
+
+func writeGroupError(c *gin.Context, err error) {
+	if errors.Is(err, registry.ErrGroupNotRegistered) {
+		httpUtils.WriteProblem(c, http.StatusNotFound, err.Error())
+		return
+	}
+	httpUtils.WriteServiceError(c, err)
+}
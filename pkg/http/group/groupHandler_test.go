@@ -0,0 +1,109 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	"github.com/stretchr/testify/assert"
+)
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func TestListGroups(t *testing.T) {
+	groupService := new(service.MockGroupService)
+	groupService.EXPECT().ListGroups().Return([]domain.Group{{Name: "allPlugs", Members: []string{"smartPlug"}}})
+
+	groupHandler := NewGroupHandler(groupService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	groupHandler.ListGroups(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"name": "allPlugs", "members": ["smartPlug"]}]`, w.Body.String())
+}
+
+func TestGetGroupState_Success(t *testing.T) {
+	groupService := new(service.MockGroupService)
+	groupService.EXPECT().GetGroupState("allPlugs").Return(domain.GroupState{Name: "allPlugs", State: domain.GroupMixed,
+		Succeeded: []string{"plugA", "plugB"}, Failed: []string{},
+		Members: []domain.GroupMemberResult{{Name: "plugA", Enabled: boolPtr(true)}, {Name: "plugB", Enabled: boolPtr(false)}},
+	}, nil)
+
+	groupHandler := NewGroupHandler(groupService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "name", Value: "allPlugs"}}
+	groupHandler.GetGroupState(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name": "allPlugs", "state": "mixed", "succeeded": ["plugA", "plugB"], "failed": [],
+		"members": [{"name": "plugA", "enabled": true}, {"name": "plugB", "enabled": false}]}`, w.Body.String())
+}
+
+func TestGetGroupState_NotFound(t *testing.T) {
+	groupService := new(service.MockGroupService)
+	groupService.EXPECT().GetGroupState("unknown").Return(domain.GroupState{}, registry.ErrGroupNotRegistered)
+
+	groupHandler := NewGroupHandler(groupService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "name", Value: "unknown"}}
+	groupHandler.GetGroupState(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSetGroupEnabled(t *testing.T) {
+	tests := []struct {
+		name      string
+		succeeded []string
+		failed    []string
+		wantCode  int
+	}{
+		{name: "Success", succeeded: []string{"plugA", "plugB"}, failed: []string{}, wantCode: http.StatusOK},
+		{name: "PartialFailure", succeeded: []string{"plugA"}, failed: []string{"plugB"}, wantCode: http.StatusMultiStatus},
+		{name: "AllFailed", succeeded: []string{}, failed: []string{"plugA", "plugB"}, wantCode: http.StatusBadGateway},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			groupService := new(service.MockGroupService)
+			groupService.EXPECT().SetGroupEnabled("allPlugs", true).Return(domain.GroupState{Name: "allPlugs",
+				Succeeded: test.succeeded, Failed: test.failed}, nil)
+
+			groupHandler := NewGroupHandler(groupService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "allPlugs"}}
+			c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"enabled": true}`))
+			groupHandler.SetGroupEnabled(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestSetGroupEnabled_InvalidBody(t *testing.T) {
+	groupService := new(service.MockGroupService)
+
+	groupHandler := NewGroupHandler(groupService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "name", Value: "allPlugs"}}
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{}`))
+	groupHandler.SetGroupEnabled(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
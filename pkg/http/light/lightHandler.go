@@ -0,0 +1,119 @@
+package http
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	lightService "github.com/pklimuk-eng-thesis/control-station/pkg/service/light"
+)
+
+type LightHandler struct {
+	service lightService.LightService
+	mu      sync.Mutex
+}
+
+func NewLightHandler(service lightService.LightService) *LightHandler {
+	return &LightHandler{service: service}
+}
+
+func (h *LightHandler) GetInfo(c *gin.Context) {
+	lightInfo, err := h.service.GetInfo()
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	httpUtils.SetETag(c, lightInfo)
+	c.IndentedJSON(http.StatusOK, &lightInfo)
+}
+
+func (h *LightHandler) ToggleEnabled(c *gin.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !httpUtils.CheckIfMatch(c, h.service.ReadInfo) {
+		return
+	}
+
+	lightInfo, err := h.service.ToggleEnabled(c.Request.Context())
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	httpUtils.SetETag(c, lightInfo)
+	c.IndentedJSON(http.StatusOK, &lightInfo)
+}
+
+func (h *LightHandler) SetBrightness(c *gin.Context) {
+	var request domain.LightBrightnessRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !httpUtils.CheckIfMatch(c, h.service.ReadInfo) {
+		return
+	}
+
+	lightInfo, err := h.service.SetBrightness(request)
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	httpUtils.SetETag(c, lightInfo)
+	c.IndentedJSON(http.StatusOK, &lightInfo)
+}
+
+func (h *LightHandler) SetColor(c *gin.Context) {
+	var request domain.LightColorRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !httpUtils.CheckIfMatch(c, h.service.ReadInfo) {
+		return
+	}
+
+	lightInfo, err := h.service.SetColor(request)
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	httpUtils.SetETag(c, lightInfo)
+	c.IndentedJSON(http.StatusOK, &lightInfo)
+}
+
+func (h *LightHandler) GetCapabilities(c *gin.Context) {
+	capabilities := h.service.GetCapabilities()
+	c.IndentedJSON(http.StatusOK, &capabilities)
+}
+
+func (h *LightHandler) GetLightLogsLimitN(c *gin.Context) {
+	query, ok := httpUtils.ParseLogQuery(c)
+	if !ok {
+		return
+	}
+
+	lightLogs, err := h.service.QueryLightLogsFromDataService(query)
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	httpUtils.WriteLogPage(c, lightLogs)
+}
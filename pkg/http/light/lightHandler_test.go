@@ -0,0 +1,190 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/light"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/pklimuk-eng-thesis/control-station/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestGetInfo_Success(t *testing.T) {
+	lightService := new(service.MockLightService)
+	lightService.EXPECT().GetInfo().Return(domain.LightInfo{Enabled: true, Brightness: intPtr(70)}, nil)
+
+	lightHandler := NewLightHandler(lightService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	lightHandler.GetInfo(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": true, "brightness": 70}`, w.Body.String())
+	assert.NotEmpty(t, w.Header().Get(httpUtils.ETagHeader))
+}
+
+func TestGetInfo_ParsingFailure(t *testing.T) {
+	lightService := new(service.MockLightService)
+	lightService.EXPECT().GetInfo().Return(domain.LightInfo{Enabled: false}, utils.ErrParsingFailed)
+
+	lightHandler := NewLightHandler(lightService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	lightHandler.GetInfo(c)
+
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Gateway", "status": 502, "code": "INVALID_RESPONSE", "detail": "Parsing failed"}`,
+		w.Body.String())
+}
+
+func TestToggleEnabled_Success(t *testing.T) {
+	lightService := new(service.MockLightService)
+	lightService.EXPECT().ToggleEnabled(mock.Anything).Return(domain.LightInfo{Enabled: true}, nil)
+
+	lightHandler := NewLightHandler(lightService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	lightHandler.ToggleEnabled(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": true}`, w.Body.String())
+}
+
+func TestSetBrightness_Success(t *testing.T) {
+	lightService := new(service.MockLightService)
+	lightService.EXPECT().SetBrightness(domain.LightBrightnessRequest{Brightness: 30, TransitionMs: 500}).
+		Return(domain.LightInfo{Enabled: true, Brightness: intPtr(30)}, nil)
+
+	lightHandler := NewLightHandler(lightService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"brightness": 30, "transition_ms": 500}`))
+	lightHandler.SetBrightness(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": true, "brightness": 30}`, w.Body.String())
+}
+
+func TestSetBrightness_InvalidBody(t *testing.T) {
+	lightService := new(service.MockLightService)
+	lightHandler := NewLightHandler(lightService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"brightness": 30`))
+	lightHandler.SetBrightness(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSetBrightness_ValidationFailure(t *testing.T) {
+	lightService := new(service.MockLightService)
+	validationErr := &controlStationUtils.ValidationError{}
+	validationErr.Add("brightness", "is not supported")
+	lightService.EXPECT().SetBrightness(domain.LightBrightnessRequest{Brightness: 30}).Return(domain.LightInfo{}, validationErr)
+
+	lightHandler := NewLightHandler(lightService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"brightness": 30}`))
+	lightHandler.SetBrightness(c)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Unprocessable Entity", "status": 422, "code": "VALIDATION_FAILED",
+		"detail": "Validation failed: brightness: is not supported", "errors": [{"field": "brightness", "message": "is not supported"}]}`, w.Body.String())
+}
+
+func TestSetColor_Success(t *testing.T) {
+	lightService := new(service.MockLightService)
+	request := domain.LightColorRequest{RGB: &domain.RGBColor{Red: 255, Green: 0, Blue: 0}}
+	lightService.EXPECT().SetColor(request).Return(domain.LightInfo{Enabled: true, RGB: request.RGB}, nil)
+
+	lightHandler := NewLightHandler(lightService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"rgb": {"red": 255, "green": 0, "blue": 0}}`))
+	lightHandler.SetColor(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": true, "rgb": {"red": 255, "green": 0, "blue": 0}}`, w.Body.String())
+}
+
+func TestSetColor_PreconditionFailed(t *testing.T) {
+	lightService := new(service.MockLightService)
+	lightService.EXPECT().ReadInfo(mock.Anything).Return(domain.LightInfo{Enabled: true}, nil)
+
+	lightHandler := NewLightHandler(lightService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"color_temperature": 3000}`))
+	c.Request.Header.Set(httpUtils.IfMatchHeader, `"stale"`)
+	lightHandler.SetColor(c)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	lightService.AssertNotCalled(t, "SetColor", mock.Anything)
+}
+
+func TestGetCapabilities(t *testing.T) {
+	lightService := new(service.MockLightService)
+	lightService.EXPECT().GetCapabilities().Return(domain.LightCapabilities{Dimmable: true, MaxTransitionMs: 5000})
+
+	lightHandler := NewLightHandler(lightService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	lightHandler.GetCapabilities(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"dimmable": true, "supports_rgb": false, "supports_hsv": false, "max_transition_ms": 5000}`, w.Body.String())
+}
+
+func TestGetLightLogsLimitN_Success(t *testing.T) {
+	lightService := new(service.MockLightService)
+	lightService.EXPECT().QueryLightLogsFromDataService(domain.LogQuery{Limit: 1}).Return(domain.LogPage[domain.LightData]{Logs: []domain.LightData{
+		{ID: 1, CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), IsEnabled: true, Brightness: intPtr(80)},
+	}}, nil)
+
+	lightHandler := NewLightHandler(lightService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?limit=1", nil)
+	lightHandler.GetLightLogsLimitN(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id": 1, "created_at": "2023-01-01T00:00:00Z", "is_enabled": true, "brightness": 80}]`, w.Body.String())
+}
+
+func TestGetLightLogsLimitN_InvalidLimit(t *testing.T) {
+	lightService := new(service.MockLightService)
+	lightHandler := NewLightHandler(lightService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?limit=invalid", nil)
+	lightHandler.GetLightLogsLimitN(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Request", "status": 400, "code": "BAD_REQUEST", "detail": "Invalid limit parameter"}`,
+		w.Body.String())
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	maintenanceService "github.com/pklimuk-eng-thesis/control-station/pkg/service/maintenance"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+// OverrideHeader lets a caller reach the endpoints of a device in maintenance,
+// e.g. the technician servicing it.
+const OverrideHeader = "X-Maintenance-Override"
+
+type MaintenanceHandler struct {
+	service maintenanceService.MaintenanceService
+}
+
+func NewMaintenanceHandler(service maintenanceService.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{service: service}
+}
+
+func (h *MaintenanceHandler) ListWindows(c *gin.Context) {
+	windows := h.service.ListWindows()
+	c.IndentedJSON(http.StatusOK, &windows)
+}
+
+func (h *MaintenanceHandler) Schedule(c *gin.Context) {
+	var request domain.MaintenanceRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	window, err := h.service.Schedule(request, c.ClientIP())
+	if err != nil {
+		writeMaintenanceError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusCreated, &window)
+}
+
+func (h *MaintenanceHandler) End(c *gin.Context) {
+	window, err := h.service.End(c.Param("id"), c.ClientIP())
+	if err != nil {
+		writeMaintenanceError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &window)
+}
+
+// Guard answers the requests to the endpoints of a device in maintenance with
+// 423 Locked, unless the override header is set. It has to be used before the
+// device routes are set up.
+func Guard(service maintenanceService.MaintenanceService, deviceRegistry registry.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader(OverrideHeader) == "true" {
+			c.Next()
+			return
+		}
+
+		path := c.Request.URL.Path
+		for _, device := range deviceRegistry.List() {
+			if device.Route == "" || !strings.HasPrefix(path, device.Route+"/") {
+				continue
+			}
+			if service.InMaintenance(device.Name) {
+				httpUtils.WriteServiceError(c, fmt.Errorf("%w: %s, set the %s header to override",
+					controlStationUtils.ErrInMaintenance, device.Name, OverrideHeader))
+				c.Abort()
+				return
+			}
+			break
+		}
+		c.Next()
+	}
+}
+
+func writeMaintenanceError(c *gin.Context, err error) {
+	if errors.Is(err, registry.ErrDeviceNotRegistered) || errors.Is(err, maintenanceService.ErrWindowNotFound) {
+		httpUtils.WriteProblem(c, http.StatusNotFound, err.Error())
+		return
+	}
+	httpUtils.WriteServiceError(c, err)
+}
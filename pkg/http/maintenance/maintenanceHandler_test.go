@@ -0,0 +1,128 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/maintenance"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListWindows(t *testing.T) {
+	maintenanceService := new(service.MockMaintenanceService)
+	maintenanceService.EXPECT().ListWindows().Return([]domain.MaintenanceWindow{{ID: "a", Device: "smartPlug"}})
+
+	maintenanceHandler := NewMaintenanceHandler(maintenanceService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	maintenanceHandler.ListWindows(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id": "a", "device": "smartPlug", "start": "0001-01-01T00:00:00Z", "source": ""}]`,
+		w.Body.String())
+}
+
+func TestSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", body: `{"device": "smartPlug"}`, wantCode: http.StatusCreated},
+		{name: "MissingDevice", body: `{}`, wantCode: http.StatusBadRequest},
+		{name: "UnknownDevice", body: `{"device": "unknown"}`, err: registry.ErrDeviceNotRegistered,
+			wantCode: http.StatusNotFound},
+		{name: "Invalid", body: `{"device": "smartPlug"}`, err: &controlStationUtils.ValidationError{},
+			wantCode: http.StatusUnprocessableEntity},
+		{name: "Failure", body: `{"device": "smartPlug"}`, err: errors.New("failure"),
+			wantCode: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maintenanceService := new(service.MockMaintenanceService)
+			maintenanceService.EXPECT().Schedule(mock.Anything, mock.Anything).Return(domain.MaintenanceWindow{}, test.err)
+
+			maintenanceHandler := NewMaintenanceHandler(maintenanceService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(test.body))
+			maintenanceHandler.Schedule(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestEnd(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", wantCode: http.StatusOK},
+		{name: "NotFound", err: service.ErrWindowNotFound, wantCode: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maintenanceService := new(service.MockMaintenanceService)
+			maintenanceService.EXPECT().End("a", mock.Anything).Return(domain.MaintenanceWindow{}, test.err)
+
+			maintenanceHandler := NewMaintenanceHandler(maintenanceService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+			c.Params = gin.Params{{Key: "id", Value: "a"}}
+			maintenanceHandler.End(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestGuard(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		override string
+		wantCode int
+	}{
+		{name: "InMaintenance", path: "/smartPlug/info", wantCode: http.StatusLocked},
+		{name: "Override", path: "/smartPlug/info", override: "true", wantCode: http.StatusOK},
+		{name: "NotInMaintenance", path: "/smartBulb/info", wantCode: http.StatusOK},
+		{name: "OtherRoute", path: "/status", wantCode: http.StatusOK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maintenanceService := new(service.MockMaintenanceService)
+			maintenanceService.EXPECT().InMaintenance("smartPlug").Return(true).Maybe()
+			maintenanceService.EXPECT().InMaintenance("smartBulb").Return(false).Maybe()
+			deviceRegistry := registry.NewRegistry()
+			deviceRegistry.Register(domain.RegisteredDevice{Name: "smartPlug", Kind: domain.KindPlug, Route: "/smartPlug"})
+			deviceRegistry.Register(domain.RegisteredDevice{Name: "smartBulb", Kind: domain.KindLight, Route: "/smartBulb"})
+
+			r := gin.New()
+			r.Use(Guard(maintenanceService, deviceRegistry))
+			r.GET("/*path", func(c *gin.Context) { c.Status(http.StatusOK) })
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, test.path, nil)
+			if test.override != "" {
+				req.Header.Set(OverrideHeader, test.override)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	occupancyService "github.com/pklimuk-eng-thesis/control-station/pkg/service/occupancy"
+)
+
+type OccupancyHandler struct {
+	service        occupancyService.OccupancyService
+	overrideWindow time.Duration
+}
+
+// NewOccupancyHandler takes the override window used when a request does not
+// set one.
+func NewOccupancyHandler(service occupancyService.OccupancyService, overrideWindow time.Duration) *OccupancyHandler {
+	return &OccupancyHandler{service: service, overrideWindow: overrideWindow}
+}
+
+func (h *OccupancyHandler) ListOccupancy(c *gin.Context) {
+	occupancy := h.service.ListOccupancy()
+	c.IndentedJSON(http.StatusOK, &occupancy)
+}
+
+func (h *OccupancyHandler) GetOccupancy(c *gin.Context) {
+	occupancy, err := h.service.GetOccupancy(c.Param("id"))
+	if err != nil {
+		writeOccupancyError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &occupancy)
+}
+
+func (h *OccupancyHandler) SetOverride(c *gin.Context) {
+	var request domain.OccupancyOverrideRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	window := h.overrideWindow
+	if request.Minutes != nil {
+		window = time.Duration(*request.Minutes) * time.Minute
+	}
+	occupancy, err := h.service.SetOverride(c.Param("id"), window)
+	if err != nil {
+		writeOccupancyError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &occupancy)
+}
+
+func writeOccupancyError(c *gin.Context, err error) {
+	if errors.Is(err, registry.ErrRoomNotRegistered) {
+		httpUtils.WriteProblem(c, http.StatusNotFound, err.Error())
+		return
+	}
+	httpUtils.WriteServiceError(c, err)
+}
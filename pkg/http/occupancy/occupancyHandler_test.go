@@ -0,0 +1,108 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/occupancy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListOccupancy(t *testing.T) {
+	occupancyService := new(service.MockOccupancyService)
+	occupancyService.EXPECT().ListOccupancy().Return([]domain.RoomOccupancy{
+		{Room: "livingRoom", State: domain.RoomOccupied, Since: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+	})
+
+	occupancyHandler := NewOccupancyHandler(occupancyService, time.Hour)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	occupancyHandler.ListOccupancy(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"room": "livingRoom", "state": "occupied", "since": "2023-01-01T00:00:00Z"}]`,
+		w.Body.String())
+}
+
+func TestGetOccupancy(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", wantCode: http.StatusOK},
+		{name: "NotFound", err: registry.ErrRoomNotRegistered, wantCode: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			occupancyService := new(service.MockOccupancyService)
+			occupancyService.EXPECT().GetOccupancy("livingRoom").Return(domain.RoomOccupancy{}, test.err)
+
+			occupancyHandler := NewOccupancyHandler(occupancyService, time.Hour)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "livingRoom"}}
+			occupancyHandler.GetOccupancy(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestSetOverride(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantWindow time.Duration
+		wantCode   int
+	}{
+		{name: "DefaultWindow", body: `{}`, wantWindow: time.Hour, wantCode: http.StatusOK},
+		{name: "Minutes", body: `{"minutes": 15}`, wantWindow: 15 * time.Minute, wantCode: http.StatusOK},
+		{name: "End", body: `{"minutes": 0}`, wantWindow: 0, wantCode: http.StatusOK},
+		{name: "NotFound", body: `{}`, err: registry.ErrRoomNotRegistered, wantWindow: time.Hour,
+			wantCode: http.StatusNotFound},
+		{name: "Failure", body: `{}`, err: errors.New("failure"), wantWindow: time.Hour,
+			wantCode: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			occupancyService := new(service.MockOccupancyService)
+			occupancyService.EXPECT().SetOverride("livingRoom", test.wantWindow).Return(domain.RoomOccupancy{}, test.err)
+
+			occupancyHandler := NewOccupancyHandler(occupancyService, time.Hour)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "livingRoom"}}
+			c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(test.body))
+			occupancyHandler.SetOverride(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestSetOverride_InvalidBody(t *testing.T) {
+	occupancyService := new(service.MockOccupancyService)
+
+	occupancyHandler := NewOccupancyHandler(occupancyService, time.Hour)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"minutes": "soon"}`))
+	occupancyHandler.SetOverride(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	occupancyService.AssertNotCalled(t, "SetOverride", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	plugService "github.com/pklimuk-eng-thesis/control-station/pkg/service/plug"
+)
+
+// defaultEnergyDays and defaultEnergyMonths are how far back the energy totals
+// reach when no start of the range is given.
+const defaultEnergyDays = 30
+const defaultEnergyMonths = 12
+
+type PlugHandler struct {
+	service plugService.PlugService
+	mu      sync.Mutex
+}
+
+func NewPlugHandler(service plugService.PlugService) *PlugHandler {
+	return &PlugHandler{service: service}
+}
+
+func (h *PlugHandler) GetInfo(c *gin.Context) {
+	plugInfo, err := h.service.GetInfo()
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	httpUtils.SetETag(c, plugInfo)
+	c.IndentedJSON(http.StatusOK, &plugInfo)
+}
+
+func (h *PlugHandler) ToggleEnabled(c *gin.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !httpUtils.CheckIfMatch(c, h.service.ReadInfo) {
+		return
+	}
+
+	plugInfo, err := h.service.ToggleEnabled(c.Request.Context())
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	httpUtils.SetETag(c, plugInfo)
+	c.IndentedJSON(http.StatusOK, &plugInfo)
+}
+
+func (h *PlugHandler) GetPlugLogsLimitN(c *gin.Context) {
+	query, ok := httpUtils.ParseLogQuery(c)
+	if !ok {
+		return
+	}
+
+	plugLogs, err := h.service.QueryPlugLogsFromDataService(query)
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	httpUtils.WriteLogPage(c, plugLogs)
+}
+
+func (h *PlugHandler) GetEnergyTotals(c *gin.Context) {
+	period := domain.EnergyPeriod(c.DefaultQuery("period", string(domain.EnergyPeriodDaily)))
+	if period != domain.EnergyPeriodDaily && period != domain.EnergyPeriodMonthly {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, "Invalid period parameter")
+		return
+	}
+
+	from, to, ok := httpUtils.ParseTimeRange(c)
+	if !ok {
+		return
+	}
+	if to == nil {
+		now := time.Now()
+		to = &now
+	}
+	if from == nil {
+		start := to.AddDate(0, 0, -defaultEnergyDays)
+		if period == domain.EnergyPeriodMonthly {
+			start = to.AddDate(0, -defaultEnergyMonths, 0)
+		}
+		from = &start
+	}
+	if !from.Before(*to) {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, "Invalid to parameter")
+		return
+	}
+
+	totals, err := h.service.GetEnergyTotals(period, *from, *to)
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	httpUtils.SetTruncationHeaders(c, totals.Truncated, totals.ScannedFrom)
+	c.IndentedJSON(http.StatusOK, &totals.Totals)
+}
+
+func (h *PlugHandler) GetTariff(c *gin.Context) {
+	tariff := h.service.GetTariff()
+	c.IndentedJSON(http.StatusOK, &tariff)
+}
@@ -0,0 +1,240 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/plug"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/pklimuk-eng-thesis/control-station/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func floatPtr(v float32) *float32 {
+	return &v
+}
+
+func TestGetInfo_Success(t *testing.T) {
+	plugService := new(service.MockPlugService)
+	plugService.EXPECT().GetInfo().Return(domain.PlugInfo{Enabled: true, Power: floatPtr(40), Energy: floatPtr(3.5)}, nil)
+
+	plugHandler := NewPlugHandler(plugService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	plugHandler.GetInfo(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": true, "power": 40, "energy": 3.5}`, w.Body.String())
+}
+
+func TestGetInfo_ParsingFailure(t *testing.T) {
+	plugService := new(service.MockPlugService)
+	plugService.EXPECT().GetInfo().Return(domain.PlugInfo{Enabled: false}, utils.ErrParsingFailed)
+
+	plugHandler := NewPlugHandler(plugService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	plugHandler.GetInfo(c)
+
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Gateway", "status": 502, "code": "INVALID_RESPONSE", "detail": "Parsing failed"}`,
+		w.Body.String())
+}
+
+func TestToggleEnabled_Success(t *testing.T) {
+	plugService := new(service.MockPlugService)
+	plugService.EXPECT().ToggleEnabled(mock.Anything).Return(domain.PlugInfo{Enabled: true}, nil)
+
+	plugHandler := NewPlugHandler(plugService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	plugHandler.ToggleEnabled(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": true}`, w.Body.String())
+}
+
+func TestToggleEnabled_Interlocked(t *testing.T) {
+	plugService := new(service.MockPlugService)
+	plugService.EXPECT().ToggleEnabled(mock.Anything).
+		Return(domain.PlugInfo{}, fmt.Errorf("%w: smartPlug", controlStationUtils.ErrInterlocked))
+
+	plugHandler := NewPlugHandler(plugService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	plugHandler.ToggleEnabled(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestToggleEnabled_PreconditionFailed(t *testing.T) {
+	plugService := new(service.MockPlugService)
+	plugService.EXPECT().ReadInfo(mock.Anything).Return(domain.PlugInfo{Enabled: true}, nil)
+
+	plugHandler := NewPlugHandler(plugService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+	c.Request.Header.Set(httpUtils.IfMatchHeader, `"stale"`)
+	plugHandler.ToggleEnabled(c)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	plugService.AssertNotCalled(t, "ToggleEnabled", mock.Anything)
+}
+
+func TestGetPlugLogsLimitN_Success(t *testing.T) {
+	plugService := new(service.MockPlugService)
+	plugService.EXPECT().QueryPlugLogsFromDataService(domain.LogQuery{Limit: 1}).Return(domain.LogPage[domain.PlugData]{Logs: []domain.PlugData{
+		{ID: 1, CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), IsEnabled: true, Power: floatPtr(20)},
+	}}, nil)
+
+	plugHandler := NewPlugHandler(plugService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?limit=1", nil)
+	plugHandler.GetPlugLogsLimitN(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id": 1, "created_at": "2023-01-01T00:00:00Z", "is_enabled": true, "power": 20}]`, w.Body.String())
+}
+
+func TestGetEnergyTotals_Success(t *testing.T) {
+	plugService := new(service.MockPlugService)
+	cost := 5.0
+	plugService.EXPECT().GetEnergyTotals(domain.EnergyPeriodMonthly, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)).Return(domain.EnergyTotals{Totals: []domain.EnergyTotal{
+		{PeriodStart: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Energy: 10, Cost: &cost, Currency: "PLN"},
+		{PeriodStart: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), Energy: 2, Partial: true},
+	}}, nil)
+
+	plugHandler := NewPlugHandler(plugService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet,
+		"/?period=monthly&from=2023-01-01T00:00:00Z&to=2023-03-01T00:00:00Z", nil)
+	plugHandler.GetEnergyTotals(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(httpUtils.TruncatedHeader))
+	assert.JSONEq(t, `[
+		{"period_start": "2023-01-01T00:00:00Z", "energy": 10, "partial": false, "cost": 5, "currency": "PLN"},
+		{"period_start": "2023-02-01T00:00:00Z", "energy": 2, "partial": true}
+	]`, w.Body.String())
+}
+
+func TestGetEnergyTotals_DefaultParameters(t *testing.T) {
+	plugService := new(service.MockPlugService)
+	plugService.EXPECT().GetEnergyTotals(domain.EnergyPeriodDaily, mock.Anything, mock.Anything).
+		RunAndReturn(func(period domain.EnergyPeriod, from time.Time, to time.Time) (domain.EnergyTotals, error) {
+			assert.Equal(t, to.AddDate(0, 0, -30), from)
+			return domain.EnergyTotals{Totals: []domain.EnergyTotal{}}, nil
+		})
+
+	plugHandler := NewPlugHandler(plugService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+	plugHandler.GetEnergyTotals(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+}
+
+func TestGetEnergyTotals_Truncated(t *testing.T) {
+	plugService := new(service.MockPlugService)
+	scannedFrom := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	plugService.EXPECT().GetEnergyTotals(domain.EnergyPeriodDaily, mock.Anything, mock.Anything).
+		Return(domain.EnergyTotals{
+			Totals:      []domain.EnergyTotal{{PeriodStart: scannedFrom, Energy: 1, Partial: true}},
+			Truncated:   true,
+			ScannedFrom: &scannedFrom,
+		}, nil)
+
+	plugHandler := NewPlugHandler(plugService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+	plugHandler.GetEnergyTotals(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get(httpUtils.TruncatedHeader))
+	assert.Equal(t, "2023-01-02T00:00:00Z", w.Header().Get(httpUtils.ScannedFromHeader))
+	assert.JSONEq(t, `[{"period_start": "2023-01-02T00:00:00Z", "energy": 1, "partial": true}]`, w.Body.String())
+}
+
+func TestGetEnergyTotals_InvalidParameters(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		wantBody string
+	}{
+		{name: "Period", url: "/?period=weekly", wantBody: "Invalid period parameter"},
+		{name: "From", url: "/?from=invalid", wantBody: "Invalid from parameter"},
+		{name: "Range", url: "/?from=2023-01-02T00:00:00Z&to=2023-01-01T00:00:00Z", wantBody: "Invalid to parameter"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plugService := new(service.MockPlugService)
+			plugHandler := NewPlugHandler(plugService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, test.url, nil)
+			plugHandler.GetEnergyTotals(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Request", "status": 400, "code": "BAD_REQUEST", "detail": "`+
+				test.wantBody+`"}`, w.Body.String())
+		})
+	}
+}
+
+func TestGetEnergyTotals_Error(t *testing.T) {
+	plugService := new(service.MockPlugService)
+	plugService.EXPECT().GetEnergyTotals(domain.EnergyPeriodDaily, mock.Anything, mock.Anything).Return(domain.EnergyTotals{},
+		errors.New("error"))
+
+	plugHandler := NewPlugHandler(plugService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+	plugHandler.GetEnergyTotals(c)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Internal Server Error", "status": 500, "code": "INTERNAL_ERROR", "detail": "error"}`,
+		w.Body.String())
+}
+
+func TestGetTariff(t *testing.T) {
+	plugService := new(service.MockPlugService)
+	plugService.EXPECT().GetTariff().Return(domain.Tariff{PricePerKWh: 0.75, Currency: "PLN"})
+
+	plugHandler := NewPlugHandler(plugService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	plugHandler.GetTariff(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"price_per_kwh": 0.75, "currency": "PLN"}`, w.Body.String())
+}
@@ -0,0 +1,87 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	roomService "github.com/pklimuk-eng-thesis/control-station/pkg/service/room"
+)
+
+type RoomHandler struct {
+	service roomService.RoomService
+}
+
+func NewRoomHandler(service roomService.RoomService) *RoomHandler {
+	return &RoomHandler{service: service}
+}
+
+func (h *RoomHandler) ListRooms(c *gin.Context) {
+	rooms := h.service.ListRooms()
+	c.IndentedJSON(http.StatusOK, &rooms)
+}
+
+func (h *RoomHandler) GetRoomStatus(c *gin.Context) {
+	roomStatus, err := h.service.GetRoomStatus(c.Param("id"))
+	if err != nil {
+		writeRoomError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &roomStatus)
+}
+
+func (h *RoomHandler) SetRoomLights(c *gin.Context) {
+	var request domain.RoomLightsRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, err := h.service.SetRoomLights(c.Param("id"), request)
+	if err != nil {
+		writeRoomError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &results)
+}
+
+func (h *RoomHandler) GetZoneStatus(c *gin.Context) {
+	zoneStatus, err := h.service.GetZoneStatus(c.Param("id"))
+	if err != nil {
+		writeRoomError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &zoneStatus)
+}
+
+func (h *RoomHandler) SetZoneLights(c *gin.Context) {
+	var request domain.RoomLightsRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, err := h.service.SetZoneLights(c.Param("id"), request)
+	if err != nil {
+		writeRoomError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &results)
+}
+
+func writeRoomError(c *gin.Context, err error) {
+	if errors.Is(err, registry.ErrRoomNotRegistered) || errors.Is(err, roomService.ErrZoneNotFound) {
+		httpUtils.WriteProblem(c, http.StatusNotFound, err.Error())
+		return
+	}
+	httpUtils.WriteServiceError(c, err)
+}
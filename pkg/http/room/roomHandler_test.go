@@ -0,0 +1,133 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/room"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func TestListRooms(t *testing.T) {
+	roomService := new(service.MockRoomService)
+	roomService.EXPECT().ListRooms().Return([]domain.Room{{ID: "livingRoom", Name: "Living room"}})
+
+	roomHandler := NewRoomHandler(roomService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	roomHandler.ListRooms(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id": "livingRoom", "name": "Living room"}]`, w.Body.String())
+}
+
+func TestGetRoomStatus_Success(t *testing.T) {
+	roomService := new(service.MockRoomService)
+	roomService.EXPECT().GetRoomStatus("livingRoom").Return(domain.RoomStatus{
+		ID:    "livingRoom",
+		Rooms: []domain.Room{{ID: "livingRoom", Name: "Living room"}},
+		Devices: []domain.DeviceStatus{
+			{Name: "smartBulb", Kind: domain.KindLight, Room: "livingRoom", State: domain.LightInfo{Enabled: true}},
+			{Name: "presenceSensor", Kind: domain.KindSensor, Room: "livingRoom", Error: "unreachable"},
+		},
+	}, nil)
+
+	roomHandler := NewRoomHandler(roomService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "livingRoom"}}
+	roomHandler.GetRoomStatus(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id": "livingRoom", "rooms": [{"id": "livingRoom", "name": "Living room"}], "devices": [
+		{"name": "smartBulb", "kind": "light", "room": "livingRoom", "state": {"enabled": true}},
+		{"name": "presenceSensor", "kind": "sensor", "room": "livingRoom", "error": "unreachable"}]}`, w.Body.String())
+}
+
+func TestGetRoomStatus_NotFound(t *testing.T) {
+	roomService := new(service.MockRoomService)
+	roomService.EXPECT().GetRoomStatus("garage").Return(domain.RoomStatus{}, registry.ErrRoomNotRegistered)
+
+	roomHandler := NewRoomHandler(roomService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "garage"}}
+	roomHandler.GetRoomStatus(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetZoneStatus_NotFound(t *testing.T) {
+	roomService := new(service.MockRoomService)
+	roomService.EXPECT().GetZoneStatus("attic").Return(domain.RoomStatus{}, service.ErrZoneNotFound)
+
+	roomHandler := NewRoomHandler(roomService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "attic"}}
+	roomHandler.GetZoneStatus(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSetRoomLights_Success(t *testing.T) {
+	roomService := new(service.MockRoomService)
+	roomService.EXPECT().SetRoomLights("livingRoom", domain.RoomLightsRequest{Enabled: boolPtr(true)}).
+		Return([]domain.LightResult{{Name: "smartBulb", State: &domain.LightInfo{Enabled: true}}}, nil)
+
+	roomHandler := NewRoomHandler(roomService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "livingRoom"}}
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"enabled": true}`))
+	roomHandler.SetRoomLights(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"name": "smartBulb", "state": {"enabled": true}}]`, w.Body.String())
+}
+
+func TestSetRoomLights_InvalidBody(t *testing.T) {
+	roomService := new(service.MockRoomService)
+
+	roomHandler := NewRoomHandler(roomService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "livingRoom"}}
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`invalid`))
+	roomHandler.SetRoomLights(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSetZoneLights_ValidationFailure(t *testing.T) {
+	validationErr := &controlStationUtils.ValidationError{}
+	validationErr.Add("enabled", "either enabled or brightness must be set")
+	roomService := new(service.MockRoomService)
+	roomService.EXPECT().SetZoneLights("upstairs", domain.RoomLightsRequest{}).Return(nil, validationErr)
+
+	roomHandler := NewRoomHandler(roomService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "upstairs"}}
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{}`))
+	roomHandler.SetZoneLights(c)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
@@ -2,30 +2,79 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
 	ac "github.com/pklimuk-eng-thesis/control-station/pkg/http/ac"
+	analog "github.com/pklimuk-eng-thesis/control-station/pkg/http/analog"
+	analytics "github.com/pklimuk-eng-thesis/control-station/pkg/http/analytics"
+	arbitration "github.com/pklimuk-eng-thesis/control-station/pkg/http/arbitration"
+	away "github.com/pklimuk-eng-thesis/control-station/pkg/http/away"
 	device "github.com/pklimuk-eng-thesis/control-station/pkg/http/device"
-	sensor "github.com/pklimuk-eng-thesis/control-station/pkg/http/sensor"
+	export "github.com/pklimuk-eng-thesis/control-station/pkg/http/export"
+	group "github.com/pklimuk-eng-thesis/control-station/pkg/http/group"
+	light "github.com/pklimuk-eng-thesis/control-station/pkg/http/light"
+	maintenance "github.com/pklimuk-eng-thesis/control-station/pkg/http/maintenance"
+	occupancy "github.com/pklimuk-eng-thesis/control-station/pkg/http/occupancy"
+	plug "github.com/pklimuk-eng-thesis/control-station/pkg/http/plug"
+	room "github.com/pklimuk-eng-thesis/control-station/pkg/http/room"
+	safety "github.com/pklimuk-eng-thesis/control-station/pkg/http/safety"
+	security "github.com/pklimuk-eng-thesis/control-station/pkg/http/security"
+	status "github.com/pklimuk-eng-thesis/control-station/pkg/http/status"
+	thermostat "github.com/pklimuk-eng-thesis/control-station/pkg/http/thermostat"
+	timeline "github.com/pklimuk-eng-thesis/control-station/pkg/http/timeline"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
 )
 
 var enabledEndpoint = "/enabled"
-var detectedEndpoint = "/detected"
 var infoEndpoint = "/info"
 var logsEndpoint = "/logs"
 var updateEndpoint = "/update"
+var capabilitiesEndpoint = "/capabilities"
+var brightnessEndpoint = "/brightness"
+var colorEndpoint = "/color"
+var confirmEndpoint = "/confirm"
+var auditEndpoint = "/audit"
+var stateEndpoint = "/state"
+var roomStatusEndpoint = "/:id/status"
+var roomLightsEndpoint = "/:id/lights"
+var groupEndpoint = "/:name"
+var groupEnabledEndpoint = "/:name/enabled"
+var armEndpoint = "/arm"
+var disarmEndpoint = "/disarm"
+var acknowledgeEndpoint = "/acknowledge"
+var statusEndpoint = "/status"
+var occupancyEndpoint = "/:id"
+var overrideEndpoint = "/:id/override"
+var enterEndpoint = "/enter"
+var leaveEndpoint = "/leave"
+var maintenanceEndEndpoint = "/:id/end"
+var controlEndpoint = "/:name"
+var releaseEndpoint = "/:name/release"
+var energyEndpoint = "/energy"
+var tariffEndpoint = "/tariff"
+var thresholdEndpoint = "/threshold"
+var exportEndpoint = "/export"
 
-func SetupSensorRouter(r *gin.Engine, sH *sensor.SensorHandler, groupName string) {
-	route := r.Group(groupName)
-	route.GET(infoEndpoint, sH.GetInfo)
-	route.PATCH(enabledEndpoint, sH.ToggleEnabled)
-	route.PATCH(detectedEndpoint, sH.ToggleDetected)
-	route.GET(logsEndpoint, sH.GetSensorLogsLimitN)
-}
-
-func SetupDeviceRouter(r *gin.Engine, dH *device.DeviceHandler, groupName string) {
+func SetupDeviceRouter(r *gin.Engine, dH *device.DeviceHandler, groupName string, capabilities []domain.Capability) {
 	route := r.Group(groupName)
 	route.GET(infoEndpoint, dH.GetInfo)
-	route.PATCH(enabledEndpoint, dH.ToggleEnabled)
+	route.GET(capabilitiesEndpoint, dH.GetCapabilities)
 	route.GET(logsEndpoint, dH.GetDeviceLogsLimitN)
+	route.GET(stateEndpoint, dH.GetState)
+	audited := false
+	for _, capability := range capabilities {
+		audited = audited || controlStationUtils.IsAudited(capability)
+		for _, endpoint := range controlStationUtils.CapabilityEndpoints(capability) {
+			if controlStationUtils.RequiresConfirmation(endpoint) {
+				route.PATCH(endpoint, dH.RequestConfirmation(capability, endpoint))
+				route.PATCH(endpoint+confirmEndpoint, dH.Confirm(capability, endpoint))
+				continue
+			}
+			route.PATCH(endpoint, dH.Invoke(capability, endpoint))
+		}
+	}
+	if audited {
+		route.GET(auditEndpoint, dH.GetAuditLogsLimitN)
+	}
 }
 
 func SetupACRouter(r *gin.Engine, aH *ac.ACHandler, groupName string) {
@@ -33,5 +82,126 @@ func SetupACRouter(r *gin.Engine, aH *ac.ACHandler, groupName string) {
 	route.GET(infoEndpoint, aH.GetInfo)
 	route.PATCH(enabledEndpoint, aH.ToggleEnabled)
 	route.PATCH(updateEndpoint, aH.UpdateACSettings)
+	route.GET(capabilitiesEndpoint, aH.GetCapabilities)
 	route.GET(logsEndpoint, aH.GetACLogsLimitN)
 }
+
+func SetupLightRouter(r *gin.Engine, lH *light.LightHandler, groupName string) {
+	route := r.Group(groupName)
+	route.GET(infoEndpoint, lH.GetInfo)
+	route.PATCH(enabledEndpoint, lH.ToggleEnabled)
+	route.PATCH(brightnessEndpoint, lH.SetBrightness)
+	route.PATCH(colorEndpoint, lH.SetColor)
+	route.GET(capabilitiesEndpoint, lH.GetCapabilities)
+	route.GET(logsEndpoint, lH.GetLightLogsLimitN)
+}
+
+func SetupPlugRouter(r *gin.Engine, pH *plug.PlugHandler, groupName string) {
+	route := r.Group(groupName)
+	route.GET(infoEndpoint, pH.GetInfo)
+	route.PATCH(enabledEndpoint, pH.ToggleEnabled)
+	route.GET(logsEndpoint, pH.GetPlugLogsLimitN)
+	route.GET(energyEndpoint, pH.GetEnergyTotals)
+	route.GET(tariffEndpoint, pH.GetTariff)
+}
+
+func SetupAnalogSensorRouter(r *gin.Engine, aSH *analog.AnalogSensorHandler, groupName string) {
+	route := r.Group(groupName)
+	route.GET(infoEndpoint, aSH.GetInfo)
+	route.PATCH(enabledEndpoint, aSH.ToggleEnabled)
+	route.GET(thresholdEndpoint, aSH.GetThreshold)
+	route.GET(logsEndpoint, aSH.GetAnalogSensorLogsLimitN)
+}
+
+func SetupRoomRouter(r *gin.Engine, rH *room.RoomHandler) {
+	rooms := r.Group("/rooms")
+	rooms.GET("", rH.ListRooms)
+	rooms.GET(roomStatusEndpoint, rH.GetRoomStatus)
+	rooms.PATCH(roomLightsEndpoint, rH.SetRoomLights)
+
+	zones := r.Group("/zones")
+	zones.GET(roomStatusEndpoint, rH.GetZoneStatus)
+	zones.PATCH(roomLightsEndpoint, rH.SetZoneLights)
+}
+
+func SetupGroupRouter(r *gin.Engine, gH *group.GroupHandler) {
+	groups := r.Group("/groups")
+	groups.GET("", gH.ListGroups)
+	groups.GET(groupEndpoint, gH.GetGroupState)
+	groups.PATCH(groupEnabledEndpoint, gH.SetGroupEnabled)
+}
+
+func SetupStatusRouter(r *gin.Engine, sH *status.StatusHandler) {
+	r.GET("/status", sH.GetHomeStatus)
+}
+
+func SetupSecurityRouter(r *gin.Engine, sH *security.SecurityHandler) {
+	route := r.Group("/security")
+	route.GET("", sH.GetState)
+	route.PATCH(armEndpoint, sH.Arm)
+	route.PATCH(disarmEndpoint, sH.Disarm)
+	route.GET(auditEndpoint, sH.GetSecurityAuditLogsLimitN)
+}
+
+func SetupSafetyRouter(r *gin.Engine, sH *safety.SafetyHandler) {
+	route := r.Group("/safety")
+	route.GET("", sH.GetState)
+	route.PATCH(acknowledgeEndpoint, sH.Acknowledge)
+	route.GET(logsEndpoint, sH.GetSafetyLogsLimitN)
+}
+
+func SetupThermostatRouter(r *gin.Engine, tH *thermostat.ThermostatHandler) {
+	route := r.Group("/thermostat")
+	route.GET(statusEndpoint, tH.GetStatus)
+}
+
+func SetupOccupancyRouter(r *gin.Engine, oH *occupancy.OccupancyHandler) {
+	route := r.Group("/occupancy")
+	route.GET("", oH.ListOccupancy)
+	route.GET(occupancyEndpoint, oH.GetOccupancy)
+	route.PATCH(overrideEndpoint, oH.SetOverride)
+}
+
+func SetupMaintenanceRouter(r *gin.Engine, mH *maintenance.MaintenanceHandler) {
+	route := r.Group("/maintenance")
+	route.GET("", mH.ListWindows)
+	route.POST("", mH.Schedule)
+	route.PATCH(maintenanceEndEndpoint, mH.End)
+}
+
+func SetupControlRouter(r *gin.Engine, aH *arbitration.ArbitrationHandler) {
+	route := r.Group("/control")
+	route.GET("", aH.ListControls)
+	route.GET(controlEndpoint, aH.GetControl)
+	route.PATCH(releaseEndpoint, aH.Release)
+}
+
+func SetupAwayRouter(r *gin.Engine, aH *away.AwayHandler) {
+	route := r.Group("/away")
+	route.GET("", aH.GetState)
+	route.PATCH(enterEndpoint, aH.Enter)
+	route.PATCH(leaveEndpoint, aH.Leave)
+}
+
+// SetupExportRouter sets up the export of all devices and of each of the
+// devices under its route.
+func SetupExportRouter(r *gin.Engine, eH *export.ExportHandler, devices []domain.RegisteredDevice) {
+	r.GET(exportEndpoint, eH.ExportAll)
+	for _, device := range devices {
+		if device.Route != "" {
+			r.GET(device.Route+exportEndpoint, eH.ExportDevice(device.Name))
+		}
+	}
+}
+
+func SetupTimelineRouter(r *gin.Engine, tH *timeline.TimelineHandler) {
+	r.GET("/timeline", tH.GetTimeline)
+}
+
+func SetupAnalyticsRouter(r *gin.Engine, aH *analytics.AnalyticsHandler) {
+	route := r.Group("/analytics")
+	route.GET("/presence", aH.GetAnalytics(domain.MetricPresence))
+	route.GET("/doors", aH.GetAnalytics(domain.MetricDoorOpenings))
+	route.GET("/on-time", aH.GetAnalytics(domain.MetricOnTime))
+	route.GET("/ac", aH.GetAnalytics(domain.MetricACRuntime))
+}
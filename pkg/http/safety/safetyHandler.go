@@ -0,0 +1,55 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	safetyService "github.com/pklimuk-eng-thesis/control-station/pkg/service/safety"
+)
+
+type SafetyHandler struct {
+	service safetyService.SafetyService
+}
+
+func NewSafetyHandler(service safetyService.SafetyService) *SafetyHandler {
+	return &SafetyHandler{service: service}
+}
+
+func (h *SafetyHandler) GetState(c *gin.Context) {
+	safetyState := h.service.GetState()
+	c.IndentedJSON(http.StatusOK, &safetyState)
+}
+
+func (h *SafetyHandler) Acknowledge(c *gin.Context) {
+	safetyState, err := h.service.Acknowledge(c.ClientIP())
+	if errors.Is(err, safetyService.ErrNoActiveAlarm) || errors.Is(err, safetyService.ErrGasStillDetected) {
+		httpUtils.WriteProblem(c, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &safetyState)
+}
+
+func (h *SafetyHandler) GetSafetyLogsLimitN(c *gin.Context) {
+	limitStr := c.Query("limit")
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, "Invalid limit parameter")
+		return
+	}
+
+	safetyLogs, err := h.service.GetSafetyLogsFromDataServiceLimitN(limit)
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &safetyLogs)
+}
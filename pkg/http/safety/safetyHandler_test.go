@@ -0,0 +1,85 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/safety"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetState(t *testing.T) {
+	safetyService := new(service.MockSafetyService)
+	safetyService.EXPECT().GetState().Return(domain.SafetyState{Status: domain.SafetyAlarm, TriggeredBy: "gasSensor"})
+
+	safetyHandler := NewSafetyHandler(safetyService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	safetyHandler.GetState(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "alarm", "gas_detected": false, "triggered_by": "gasSensor"}`, w.Body.String())
+}
+
+func TestAcknowledge(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", wantCode: http.StatusOK},
+		{name: "NoActiveAlarm", err: service.ErrNoActiveAlarm, wantCode: http.StatusConflict},
+		{name: "GasStillDetected", err: service.ErrGasStillDetected, wantCode: http.StatusConflict},
+		{name: "Failure", err: errors.New("failure"), wantCode: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			safetyService := new(service.MockSafetyService)
+			safetyService.EXPECT().Acknowledge(mock.Anything).Return(domain.SafetyState{}, test.err)
+
+			safetyHandler := NewSafetyHandler(safetyService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+			safetyHandler.Acknowledge(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestGetSafetyLogsLimitN(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", limit: "10", wantCode: http.StatusOK},
+		{name: "InvalidLimit", limit: "abc", wantCode: http.StatusBadRequest},
+		{name: "Failure", limit: "10", err: errors.New("failure"), wantCode: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			safetyService := new(service.MockSafetyService)
+			safetyService.EXPECT().GetSafetyLogsFromDataServiceLimitN(10).Return([]domain.SafetyEvent{}, test.err)
+
+			safetyHandler := NewSafetyHandler(safetyService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = &http.Request{URL: &url.URL{RawQuery: "limit=" + test.limit}}
+			safetyHandler.GetSafetyLogsLimitN(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	securityService "github.com/pklimuk-eng-thesis/control-station/pkg/service/security"
+)
+
+type SecurityHandler struct {
+	service securityService.SecurityService
+}
+
+func NewSecurityHandler(service securityService.SecurityService) *SecurityHandler {
+	return &SecurityHandler{service: service}
+}
+
+func (h *SecurityHandler) GetState(c *gin.Context) {
+	securityState := h.service.GetState()
+	c.IndentedJSON(http.StatusOK, &securityState)
+}
+
+func (h *SecurityHandler) Arm(c *gin.Context) {
+	var request domain.SecurityArmRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	securityState, err := h.service.Arm(request.Mode, c.ClientIP())
+	if errors.Is(err, securityService.ErrDisarmRequired) {
+		httpUtils.WriteProblem(c, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &securityState)
+}
+
+func (h *SecurityHandler) Disarm(c *gin.Context) {
+	var request domain.SecurityDisarmRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	securityState, err := h.service.Disarm(request.PIN, c.ClientIP())
+	if errors.Is(err, securityService.ErrInvalidPIN) {
+		httpUtils.WriteProblem(c, http.StatusForbidden, err.Error())
+		return
+	}
+	if errors.Is(err, securityService.ErrPINLockedOut) {
+		if securityState.PINLockedUntil != nil {
+			retryAfter := int(math.Ceil(time.Until(*securityState.PINLockedUntil).Seconds()))
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+		}
+		httpUtils.WriteProblem(c, http.StatusTooManyRequests, err.Error())
+		return
+	}
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &securityState)
+}
+
+func (h *SecurityHandler) GetSecurityAuditLogsLimitN(c *gin.Context) {
+	limitStr := c.Query("limit")
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, "Invalid limit parameter")
+		return
+	}
+
+	auditLogs, err := h.service.GetSecurityAuditLogsFromDataServiceLimitN(limit)
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &auditLogs)
+}
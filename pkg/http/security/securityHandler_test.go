@@ -0,0 +1,126 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/security"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetState(t *testing.T) {
+	securityService := new(service.MockSecurityService)
+	securityService.EXPECT().GetState().
+		Return(domain.SecurityState{Mode: domain.SecurityArmedHome, Status: domain.AlarmReady})
+
+	securityHandler := NewSecurityHandler(securityService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	securityHandler.GetState(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"mode": "armed_home"`)
+}
+
+func TestArm(t *testing.T) {
+	validationErr := &controlStationUtils.ValidationError{}
+	validationErr.Add("mode", "must be one of armed_away, armed_home")
+
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", body: `{"mode": "armed_away"}`, wantCode: http.StatusOK},
+		{name: "InvalidBody", body: `{}`, wantCode: http.StatusBadRequest},
+		{name: "InvalidMode", body: `{"mode": "disarmed"}`, err: validationErr,
+			wantCode: http.StatusUnprocessableEntity},
+		{name: "Triggered", body: `{"mode": "armed_away"}`, err: service.ErrDisarmRequired,
+			wantCode: http.StatusConflict},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			securityService := new(service.MockSecurityService)
+			securityService.EXPECT().Arm(mock.Anything, mock.Anything).Return(domain.SecurityState{}, test.err)
+
+			securityHandler := NewSecurityHandler(securityService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(test.body))
+			securityHandler.Arm(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestDisarm(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", body: `{"pin": "1234"}`, wantCode: http.StatusOK},
+		{name: "InvalidBody", body: `{}`, wantCode: http.StatusBadRequest},
+		{name: "InvalidPIN", body: `{"pin": "0000"}`, err: service.ErrInvalidPIN, wantCode: http.StatusForbidden},
+		{name: "LockedOut", body: `{"pin": "1234"}`, err: service.ErrPINLockedOut, wantCode: http.StatusTooManyRequests},
+		{name: "Failure", body: `{"pin": "1234"}`, err: errors.New("failure"),
+			wantCode: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			securityService := new(service.MockSecurityService)
+			securityService.EXPECT().Disarm(mock.Anything, mock.Anything).Return(domain.SecurityState{}, test.err)
+
+			securityHandler := NewSecurityHandler(securityService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(test.body))
+			securityHandler.Disarm(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
+
+func TestGetSecurityAuditLogsLimitN(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", limit: "10", wantCode: http.StatusOK},
+		{name: "InvalidLimit", limit: "abc", wantCode: http.StatusBadRequest},
+		{name: "Failure", limit: "10", err: errors.New("failure"), wantCode: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			securityService := new(service.MockSecurityService)
+			securityService.EXPECT().GetSecurityAuditLogsFromDataServiceLimitN(10).
+				Return([]domain.SecurityTransition{}, test.err)
+
+			securityHandler := NewSecurityHandler(securityService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = &http.Request{URL: &url.URL{RawQuery: "limit=" + test.limit}}
+			securityHandler.GetSecurityAuditLogsLimitN(c)
+
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
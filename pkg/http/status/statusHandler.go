@@ -0,0 +1,29 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	statusService "github.com/pklimuk-eng-thesis/control-station/pkg/service/status"
+)
+
+type StatusHandler struct {
+	service statusService.StatusService
+}
+
+func NewStatusHandler(service statusService.StatusService) *StatusHandler {
+	return &StatusHandler{service: service}
+}
+
+func (h *StatusHandler) GetHomeStatus(c *gin.Context) {
+	cachedOnly, err := strconv.ParseBool(c.DefaultQuery("cached", "false"))
+	if err != nil {
+		httpUtils.WriteProblem(c, http.StatusBadRequest, "Invalid cached parameter")
+		return
+	}
+
+	homeStatus := h.service.GetHomeStatus(cachedOnly)
+	c.IndentedJSON(http.StatusOK, &homeStatus)
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/status"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHomeStatus(t *testing.T) {
+	latency := int64(12)
+	updatedAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	statusService := new(service.MockStatusService)
+	statusService.EXPECT().GetHomeStatus(false).Return(domain.HomeStatus{
+		GeneratedAt: updatedAt,
+		Devices: []domain.DeviceReport{
+			{Name: "ac", Kind: domain.KindAC, Reachable: true, LatencyMs: &latency,
+				State: domain.ACInfo{Enabled: true}, UpdatedAt: &updatedAt},
+			{Name: "gasSensor", Kind: domain.KindSensor, Error: "unreachable"},
+		},
+	})
+
+	statusHandler := NewStatusHandler(statusService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/status", nil)
+	statusHandler.GetHomeStatus(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"generated_at": "2023-01-01T12:00:00Z", "devices": [
+		{"name": "ac", "kind": "ac", "reachable": true, "cached": false, "latency_ms": 12,
+			"state": {"enabled": true, "temperature": 0, "humidity": 0}, "updated_at": "2023-01-01T12:00:00Z"},
+		{"name": "gasSensor", "kind": "sensor", "reachable": false, "cached": false, "error": "unreachable"}]}`,
+		w.Body.String())
+}
+
+func TestGetHomeStatus_Cached(t *testing.T) {
+	statusService := new(service.MockStatusService)
+	statusService.EXPECT().GetHomeStatus(true).Return(domain.HomeStatus{Devices: []domain.DeviceReport{}})
+
+	statusHandler := NewStatusHandler(statusService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/status?cached=true", nil)
+	statusHandler.GetHomeStatus(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetHomeStatus_InvalidCached(t *testing.T) {
+	statusService := new(service.MockStatusService)
+
+	statusHandler := NewStatusHandler(statusService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/status?cached=maybe", nil)
+	statusHandler.GetHomeStatus(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Request", "status": 400, "code": "BAD_REQUEST", "detail": "Invalid cached parameter"}`,
+		w.Body.String())
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	thermostatService "github.com/pklimuk-eng-thesis/control-station/pkg/service/thermostat"
+)
+
+type ThermostatHandler struct {
+	service thermostatService.ThermostatService
+}
+
+func NewThermostatHandler(service thermostatService.ThermostatService) *ThermostatHandler {
+	return &ThermostatHandler{service: service}
+}
+
+func (h *ThermostatHandler) GetStatus(c *gin.Context) {
+	thermostatStatus := h.service.GetStatus()
+	c.IndentedJSON(http.StatusOK, &thermostatStatus)
+}
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/thermostat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStatus(t *testing.T) {
+	temperature := float32(25.5)
+	thermostatService := new(service.MockThermostatService)
+	thermostatService.EXPECT().GetStatus().Return(domain.ThermostatStatus{Mode: domain.ACModeCool, Target: 24,
+		Temperature: &temperature, ACEnabled: true, Decision: domain.ThermostatTurnOn,
+		Reason: "25.5 is above the target 24.0 by more than 0.5"})
+
+	thermostatHandler := NewThermostatHandler(thermostatService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	thermostatHandler.GetStatus(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"mode": "cool", "target": 24, "temperature": 25.5, "ac_enabled": true,
+		"decision": "turn_on", "reason": "25.5 is above the target 24.0 by more than 0.5"}`, w.Body.String())
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	httpUtils "github.com/pklimuk-eng-thesis/control-station/pkg/http/utils"
+	timelineService "github.com/pklimuk-eng-thesis/control-station/pkg/service/timeline"
+)
+
+const defaultTimelineLimit = 100
+
+type TimelineHandler struct {
+	service timelineService.TimelineService
+}
+
+func NewTimelineHandler(service timelineService.TimelineService) *TimelineHandler {
+	return &TimelineHandler{service: service}
+}
+
+// GetTimeline merges the logs of the devices of the comma separated kinds, or
+// of all devices without them.
+func (h *TimelineHandler) GetTimeline(c *gin.Context) {
+	from, to, ok := httpUtils.ParseTimeRange(c)
+	if !ok {
+		return
+	}
+	query := domain.TimelineQuery{From: from, To: to, Order: domain.SortOrder(c.Query("order")),
+		Limit: defaultTimelineLimit}
+
+	if value, ok := c.GetQuery("limit"); ok {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			httpUtils.WriteProblem(c, http.StatusBadRequest, "Invalid limit parameter")
+			return
+		}
+		query.Limit = limit
+	}
+
+	if value := c.Query("kinds"); value != "" {
+		for _, name := range strings.Split(value, ",") {
+			kind, err := domain.ParseDeviceKind(name)
+			if err != nil {
+				httpUtils.WriteProblem(c, http.StatusBadRequest, err.Error())
+				return
+			}
+			query.Kinds = append(query.Kinds, kind)
+		}
+	}
+
+	timeline, err := h.service.GetTimeline(query)
+	if err != nil {
+		httpUtils.WriteServiceError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, &timeline)
+}
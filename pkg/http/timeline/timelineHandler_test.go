@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	service "github.com/pklimuk-eng-thesis/control-station/pkg/service/timeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTimeline_Success(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	timelineService := new(service.MockTimelineService)
+	timelineService.EXPECT().GetTimeline(domain.TimelineQuery{From: &from,
+		Kinds: []domain.DeviceKind{domain.KindLight, domain.KindSensor}, Order: domain.SortAsc, Limit: 100},
+	).Return(domain.Timeline{Entries: []domain.TimelineEntry{
+		{Time: from, Device: "smartBulb", Kind: domain.KindLight, Log: json.RawMessage(`{"id": 1}`)},
+	}}, nil)
+
+	timelineHandler := NewTimelineHandler(timelineService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/timeline?from=2023-01-01T00:00:00Z&kinds=light,sensor&order=asc", nil)
+	timelineHandler.GetTimeline(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"entries": [
+		{"time": "2023-01-01T00:00:00Z", "device": "smartBulb", "kind": "light", "log": {"id": 1}}
+	]}`, w.Body.String())
+}
+
+func TestGetTimeline_InvalidParameter(t *testing.T) {
+	tests := []struct {
+		name       string
+		rawQuery   string
+		wantDetail string
+	}{
+		{name: "InvalidLimit", rawQuery: "limit=all", wantDetail: "Invalid limit parameter"},
+		{name: "UnknownKind", rawQuery: "kinds=light,toaster", wantDetail: "Unknown device kind: toaster"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			timelineService := new(service.MockTimelineService)
+			timelineHandler := NewTimelineHandler(timelineService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/timeline?"+test.rawQuery, nil)
+			timelineHandler.GetTimeline(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), test.wantDetail)
+			timelineService.AssertNotCalled(t, "GetTimeline")
+		})
+	}
+}
+
+func TestGetTimeline_Failure(t *testing.T) {
+	timelineService := new(service.MockTimelineService)
+	timelineService.EXPECT().GetTimeline(domain.TimelineQuery{Limit: 100}).Return(domain.Timeline{}, errors.New("failure"))
+
+	timelineHandler := NewTimelineHandler(timelineService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/timeline", nil)
+	timelineHandler.GetTimeline(c)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
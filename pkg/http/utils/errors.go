@@ -0,0 +1,104 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem is the RFC 7807 problem details body of every error response.
+type Problem struct {
+	Type   string                           `json:"type"`
+	Title  string                           `json:"title"`
+	Status int                              `json:"status"`
+	Code   controlStationUtils.ErrorCode    `json:"code"`
+	Detail string                           `json:"detail,omitempty"`
+	Errors []controlStationUtils.FieldError `json:"errors,omitempty"`
+	// Device and UpstreamStatus tell which upstream request failed and how the
+	// device or the data service answered it.
+	Device         string `json:"device,omitempty"`
+	UpstreamStatus int    `json:"upstream_status,omitempty"`
+}
+
+var codeStatuses = map[controlStationUtils.ErrorCode]int{
+	controlStationUtils.CodeDeviceUnreachable:      http.StatusBadGateway,
+	controlStationUtils.CodeDeviceTimeout:          http.StatusGatewayTimeout,
+	controlStationUtils.CodeDeviceError:            http.StatusBadGateway,
+	controlStationUtils.CodeInvalidResponse:        http.StatusBadGateway,
+	controlStationUtils.CodeDataServiceUnavailable: http.StatusServiceUnavailable,
+	controlStationUtils.CodeValidationFailed:       http.StatusUnprocessableEntity,
+	controlStationUtils.CodeInterlocked:            http.StatusConflict,
+	controlStationUtils.CodeInMaintenance:          http.StatusLocked,
+	controlStationUtils.CodeOverridden:             http.StatusConflict,
+}
+
+var statusCodes = map[int]controlStationUtils.ErrorCode{
+	http.StatusBadRequest:         controlStationUtils.CodeBadRequest,
+	http.StatusForbidden:          controlStationUtils.CodeForbidden,
+	http.StatusNotFound:           controlStationUtils.CodeNotFound,
+	http.StatusConflict:           controlStationUtils.CodeConflict,
+	http.StatusPreconditionFailed: controlStationUtils.CodePreconditionFailed,
+	http.StatusLocked:             controlStationUtils.CodeLocked,
+	http.StatusTooManyRequests:    controlStationUtils.CodeTooManyRequests,
+}
+
+// WriteServiceError answers with the status that matches the code of the
+// error, 500 for errors without one. Failed upstream requests are logged with
+// the upstream status and body, which the response only carries in part.
+func WriteServiceError(c *gin.Context, err error) {
+	code := controlStationUtils.ErrorCodeOf(err)
+	status, ok := codeStatuses[code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	problem := newProblem(status, code, err.Error())
+	var validationErr *controlStationUtils.ValidationError
+	if errors.As(err, &validationErr) {
+		problem.Errors = validationErr.Errors
+	}
+	var upstreamErr *controlStationUtils.UpstreamError
+	if errors.As(err, &upstreamErr) {
+		problem.Device = upstreamErr.Device
+		problem.UpstreamStatus = upstreamErr.Status
+		logUpstreamError(c, code, upstreamErr)
+	}
+	writeProblem(c, problem)
+}
+
+// WriteProblem answers with a problem of the given status, e.g. for a request
+// the handler rejects itself.
+func WriteProblem(c *gin.Context, status int, detail string) {
+	code, ok := statusCodes[status]
+	if !ok {
+		code = controlStationUtils.CodeInternal
+	}
+	writeProblem(c, newProblem(status, code, detail))
+}
+
+func logUpstreamError(c *gin.Context, code controlStationUtils.ErrorCode, err *controlStationUtils.UpstreamError) {
+	request := ""
+	if c.Request != nil && c.Request.URL != nil {
+		request = fmt.Sprintf(" on %s %s", c.Request.Method, c.Request.URL.Path)
+	}
+	if err.Status == 0 {
+		log.Printf("%s from '%s'%s: %s\n", code, err.Device, request, err.Err)
+		return
+	}
+	log.Printf("%s from '%s'%s: status=%d body=%q cause=%v\n", code, err.Device, request, err.Status, err.Body, err.Err)
+}
+
+func newProblem(status int, code controlStationUtils.ErrorCode, detail string) Problem {
+	return Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Code: code, Detail: detail}
+}
+
+func writeProblem(c *gin.Context, problem Problem) {
+	c.Header("Content-Type", problemContentType)
+	c.IndentedJSON(problem.Status, &problem)
+}
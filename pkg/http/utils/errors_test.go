@@ -0,0 +1,83 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteServiceError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "DeviceUnreachable", err: controlStationUtils.NewServiceError(controlStationUtils.CodeDeviceUnreachable,
+			errors.New("failure")), wantStatus: http.StatusBadGateway, wantCode: "DEVICE_UNREACHABLE"},
+		{name: "DeviceTimeout", err: controlStationUtils.NewServiceError(controlStationUtils.CodeDeviceTimeout,
+			errors.New("failure")), wantStatus: http.StatusGatewayTimeout, wantCode: "DEVICE_TIMEOUT"},
+		{name: "DataServiceUnavailable", err: controlStationUtils.NewServiceError(
+			controlStationUtils.CodeDataServiceUnavailable, errors.New("failure")),
+			wantStatus: http.StatusServiceUnavailable, wantCode: "DATA_SERVICE_UNAVAILABLE"},
+		{name: "InMaintenance", err: fmt.Errorf("%w: ac", controlStationUtils.ErrInMaintenance),
+			wantStatus: http.StatusLocked, wantCode: "IN_MAINTENANCE"},
+		{name: "Other", err: errors.New("failure"), wantStatus: http.StatusInternalServerError,
+			wantCode: "INTERNAL_ERROR"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			WriteServiceError(c, test.err)
+
+			assert.Equal(t, test.wantStatus, w.Code)
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+			assert.JSONEq(t, fmt.Sprintf(`{"type": "about:blank", "title": %q, "status": %d, "code": %q, "detail": %q}`,
+				http.StatusText(test.wantStatus), test.wantStatus, test.wantCode, test.err.Error()), w.Body.String())
+		})
+	}
+}
+
+func TestWriteServiceError_Validation(t *testing.T) {
+	validationErr := &controlStationUtils.ValidationError{}
+	validationErr.Add("position", "must be between 0 and 100")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	WriteServiceError(c, validationErr)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Unprocessable Entity", "status": 422, "code": "VALIDATION_FAILED",
+		"detail": "Validation failed: position: must be between 0 and 100",
+		"errors": [{"field": "position", "message": "must be between 0 and 100"}]}`, w.Body.String())
+}
+
+func TestWriteProblem(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	WriteProblem(c, http.StatusNotFound, "Room is not registered")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Not Found", "status": 404, "code": "NOT_FOUND",
+		"detail": "Room is not registered"}`, w.Body.String())
+}
+
+func TestWriteServiceError_Upstream(t *testing.T) {
+	err := controlStationUtils.NewServiceError(controlStationUtils.CodeDeviceError,
+		&controlStationUtils.UpstreamError{Device: "smartPlug", Status: http.StatusInternalServerError, Body: "Plug is disabled"})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	WriteServiceError(c, err)
+
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Bad Gateway", "status": 502, "code": "DEVICE_ERROR",
+		"detail": "smartPlug: Plug is disabled", "device": "smartPlug", "upstream_status": 500}`, w.Body.String())
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ETagHeader = "ETag"
+const IfMatchHeader = "If-Match"
+
+func ComputeETag(v any) (string, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(encoded)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// IfMatchSatisfied uses the strong comparison required for If-Match by RFC
+// 7232, so a weak entity tag never matches.
+func IfMatchSatisfied(ifMatch string, etag string) bool {
+	if strings.HasPrefix(etag, "W/") {
+		return false
+	}
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func SetETag(c *gin.Context, v any) {
+	etag, err := ComputeETag(v)
+	if err != nil {
+		return
+	}
+	c.Header(ETagHeader, etag)
+}
+
+// CheckIfMatch compares the If-Match header of the request with the ETag of the
+// current resource state, read with the request context and without logging it
+// to the data service. It writes the error response itself and returns false
+// when the request must not proceed.
+func CheckIfMatch[V any](c *gin.Context, readCurrent func(ctx context.Context) (V, error)) bool {
+	ifMatch := c.GetHeader(IfMatchHeader)
+	if ifMatch == "" {
+		return true
+	}
+
+	current, err := readCurrent(c.Request.Context())
+	if err != nil {
+		WriteServiceError(c, err)
+		return false
+	}
+
+	etag, err := ComputeETag(current)
+	if err != nil {
+		WriteServiceError(c, err)
+		return false
+	}
+
+	if !IfMatchSatisfied(ifMatch, etag) {
+		c.Header(ETagHeader, etag)
+		WriteProblem(c, http.StatusPreconditionFailed, "Resource state has changed")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeETag(t *testing.T) {
+	first, err := ComputeETag(domain.DeviceInfo{Enabled: true})
+	assert.NoError(t, err)
+	second, err := ComputeETag(domain.DeviceInfo{Enabled: true})
+	assert.NoError(t, err)
+	third, err := ComputeETag(domain.DeviceInfo{Enabled: false})
+	assert.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, third)
+	assert.Equal(t, byte('"'), first[0])
+}
+
+func TestIfMatchSatisfied(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		etag    string
+		want    bool
+	}{
+		{name: "Exact", ifMatch: `"abc"`, etag: `"abc"`, want: true},
+		{name: "Wildcard", ifMatch: "*", etag: `"abc"`, want: true},
+		{name: "List", ifMatch: `"xyz", "abc"`, etag: `"abc"`, want: true},
+		{name: "WeakCandidate", ifMatch: `W/"abc"`, etag: `"abc"`, want: false},
+		{name: "WeakETag", ifMatch: `W/"abc"`, etag: `W/"abc"`, want: false},
+		{name: "Mismatch", ifMatch: `"xyz"`, etag: `"abc"`, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, IfMatchSatisfied(test.ifMatch, test.etag))
+		})
+	}
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	current := domain.DeviceInfo{Enabled: true}
+	currentETag, _ := ComputeETag(current)
+
+	tests := []struct {
+		name        string
+		ifMatch     string
+		readCurrent func(ctx context.Context) (domain.DeviceInfo, error)
+		want        bool
+		wantCode    int
+	}{
+		{
+			name:        "NoHeader",
+			ifMatch:     "",
+			readCurrent: func(ctx context.Context) (domain.DeviceInfo, error) { panic("must not be called") },
+			want:        true,
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "Match",
+			ifMatch:     currentETag,
+			readCurrent: func(ctx context.Context) (domain.DeviceInfo, error) { return current, nil },
+			want:        true,
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "Mismatch",
+			ifMatch:     `"stale"`,
+			readCurrent: func(ctx context.Context) (domain.DeviceInfo, error) { return current, nil },
+			want:        false,
+			wantCode:    http.StatusPreconditionFailed,
+		},
+		{
+			name:        "Error",
+			ifMatch:     currentETag,
+			readCurrent: func(ctx context.Context) (domain.DeviceInfo, error) { return domain.DeviceInfo{}, errors.New("error") },
+			want:        false,
+			wantCode:    http.StatusInternalServerError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPatch, "/", nil)
+			if test.ifMatch != "" {
+				c.Request.Header.Set(IfMatchHeader, test.ifMatch)
+			}
+
+			got := CheckIfMatch(c, test.readCurrent)
+
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantCode, w.Code)
+		})
+	}
+}
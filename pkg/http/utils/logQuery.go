@@ -0,0 +1,88 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+)
+
+// NextCursorHeader carries the cursor of the next page of logs, so that the
+// body stays the plain list of logs it always was.
+const NextCursorHeader = "X-Next-Cursor"
+const TruncatedHeader = "X-Logs-Truncated"
+const ScannedFromHeader = "X-Logs-Scanned-From"
+
+// ParseLogQuery reads the log query from the query parameters. It writes the
+// error response itself and returns false when a parameter can not be parsed.
+func ParseLogQuery(c *gin.Context) (query domain.LogQuery, ok bool) {
+	query = domain.LogQuery{Order: domain.SortOrder(c.Query("order")), Cursor: c.Query("cursor")}
+
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil {
+		WriteProblem(c, http.StatusBadRequest, "Invalid limit parameter")
+		return query, false
+	}
+	query.Limit = limit
+
+	if query.From, query.To, ok = ParseTimeRange(c); !ok {
+		return query, false
+	}
+
+	for _, param := range []struct {
+		name  string
+		value **bool
+	}{{"is_enabled", &query.IsEnabled}, {"detected", &query.Detected}} {
+		if value, ok := c.GetQuery(param.name); ok {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				WriteProblem(c, http.StatusBadRequest, "Invalid "+param.name+" parameter")
+				return query, false
+			}
+			*param.value = &parsed
+		}
+	}
+	return query, true
+}
+
+// ParseTimeRange reads the optional RFC 3339 from and to query parameters. It
+// writes the error response itself and returns false when one can not be
+// parsed.
+func ParseTimeRange(c *gin.Context) (from *time.Time, to *time.Time, ok bool) {
+	for _, param := range []struct {
+		name  string
+		value **time.Time
+	}{{"from", &from}, {"to", &to}} {
+		if value, ok := c.GetQuery(param.name); ok {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				WriteProblem(c, http.StatusBadRequest, "Invalid "+param.name+" parameter")
+				return nil, nil, false
+			}
+			*param.value = &parsed
+		}
+	}
+	return from, to, true
+}
+
+func WriteLogPage[K any](c *gin.Context, page domain.LogPage[K]) {
+	if page.NextCursor != "" {
+		c.Header(NextCursorHeader, page.NextCursor)
+	}
+	SetTruncationHeaders(c, page.Truncated, page.ScannedFrom)
+	c.IndentedJSON(http.StatusOK, &page.Logs)
+}
+
+// SetTruncationHeaders tells the client that only the logs since scannedFrom
+// could be searched, although the query reaches further back.
+func SetTruncationHeaders(c *gin.Context, truncated bool, scannedFrom *time.Time) {
+	if !truncated {
+		return
+	}
+	c.Header(TruncatedHeader, "true")
+	if scannedFrom != nil {
+		c.Header(ScannedFromHeader, scannedFrom.UTC().Format(time.RFC3339Nano))
+	}
+}
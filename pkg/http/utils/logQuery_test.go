@@ -0,0 +1,76 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogQuery(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet,
+		"/?limit=10&from=2023-01-01T00:00:00Z&detected=false&order=asc&cursor=abc", nil)
+
+	query, ok := ParseLogQuery(c)
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	detected := false
+	assert.True(t, ok)
+	assert.Equal(t, domain.LogQuery{From: &from, Detected: &detected, Order: domain.SortAsc, Limit: 10,
+		Cursor: "abc"}, query)
+}
+
+func TestParseLogQuery_InvalidParameter(t *testing.T) {
+	tests := []struct {
+		name       string
+		rawQuery   string
+		wantDetail string
+	}{
+		{name: "MissingLimit", rawQuery: "", wantDetail: "Invalid limit parameter"},
+		{name: "InvalidTo", rawQuery: "limit=1&to=yesterday", wantDetail: "Invalid to parameter"},
+		{name: "InvalidIsEnabled", rawQuery: "limit=1&is_enabled=maybe", wantDetail: "Invalid is_enabled parameter"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/?"+test.rawQuery, nil)
+
+			_, ok := ParseLogQuery(c)
+
+			assert.False(t, ok)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), test.wantDetail)
+		})
+	}
+}
+
+func TestWriteLogPage(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	WriteLogPage(c, domain.LogPage[domain.PlugData]{Logs: []domain.PlugData{{ID: 1}}, NextCursor: "next"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "next", w.Header().Get(NextCursorHeader))
+	assert.JSONEq(t, `[{"id": 1, "created_at": "0001-01-01T00:00:00Z", "is_enabled": false}]`, w.Body.String())
+}
+
+func TestWriteLogPage_Truncated(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	scannedFrom := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	WriteLogPage(c, domain.LogPage[domain.PlugData]{Logs: []domain.PlugData{}, Truncated: true, ScannedFrom: &scannedFrom})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get(TruncatedHeader))
+	assert.Equal(t, "2023-01-01T12:00:00Z", w.Header().Get(ScannedFromHeader))
+	assert.Empty(t, w.Header().Get(NextCursorHeader))
+}
@@ -0,0 +1,199 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+)
+
+var ErrDeviceNotRegistered = errors.New("Device is not registered")
+var ErrDeviceAlreadyRegistered = errors.New("Device is already registered")
+var ErrRoomNotRegistered = errors.New("Room is not registered")
+var ErrRoomAlreadyRegistered = errors.New("Room is already registered")
+var ErrGroupNotRegistered = errors.New("Group is not registered")
+var ErrGroupAlreadyRegistered = errors.New("Group is already registered")
+
+type Registry interface {
+	Register(device domain.RegisteredDevice) error
+	Get(name string) (domain.RegisteredDevice, error)
+	List() []domain.RegisteredDevice
+	RegisterRoom(room domain.Room) error
+	GetRoom(id string) (domain.Room, error)
+	ListRooms() []domain.Room
+	AssignRoom(deviceName string, roomID string) error
+	ListByRooms(roomIDs ...string) []domain.RegisteredDevice
+	RegisterGroup(group domain.Group) error
+	GetGroup(name string) (domain.Group, error)
+	ListGroups() []domain.Group
+}
+
+type registry struct {
+	mu      sync.RWMutex
+	devices map[string]domain.RegisteredDevice
+	rooms   map[string]domain.Room
+	groups  map[string]domain.Group
+}
+
+func NewRegistry() Registry {
+	return &registry{
+		devices: map[string]domain.RegisteredDevice{},
+		rooms:   map[string]domain.Room{},
+		groups:  map[string]domain.Group{},
+	}
+}
+
+func (r *registry) Register(device domain.RegisteredDevice) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.devices[device.Name]; ok {
+		return ErrDeviceAlreadyRegistered
+	}
+	if _, ok := r.rooms[device.Room]; device.Room != "" && !ok {
+		return ErrRoomNotRegistered
+	}
+	r.devices[device.Name] = device
+	return nil
+}
+
+func (r *registry) Get(name string) (domain.RegisteredDevice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	device, ok := r.devices[name]
+	if !ok {
+		return domain.RegisteredDevice{}, ErrDeviceNotRegistered
+	}
+	return device, nil
+}
+
+func (r *registry) List() []domain.RegisteredDevice {
+	return r.ListByRooms()
+}
+
+func (r *registry) RegisterRoom(room domain.Room) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rooms[room.ID]; ok {
+		return ErrRoomAlreadyRegistered
+	}
+	r.rooms[room.ID] = room
+	return nil
+}
+
+func (r *registry) GetRoom(id string) (domain.Room, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	room, ok := r.rooms[id]
+	if !ok {
+		return domain.Room{}, ErrRoomNotRegistered
+	}
+	return room, nil
+}
+
+func (r *registry) ListRooms() []domain.Room {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rooms := make([]domain.Room, 0, len(r.rooms))
+	for _, room := range r.rooms {
+		rooms = append(rooms, room)
+	}
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].ID < rooms[j].ID
+	})
+	return rooms
+}
+
+// AssignRoom moves the device to the room. An empty roomID removes the device
+// from its room.
+func (r *registry) AssignRoom(deviceName string, roomID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	device, ok := r.devices[deviceName]
+	if !ok {
+		return ErrDeviceNotRegistered
+	}
+	if _, ok := r.rooms[roomID]; roomID != "" && !ok {
+		return ErrRoomNotRegistered
+	}
+	device.Room = roomID
+	r.devices[deviceName] = device
+	return nil
+}
+
+// ListByRooms returns the devices assigned to any of the rooms, sorted by name.
+// Without roomIDs all devices are returned.
+func (r *registry) ListByRooms(roomIDs ...string) []domain.RegisteredDevice {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	devices := make([]domain.RegisteredDevice, 0, len(r.devices))
+	for _, device := range r.devices {
+		if len(roomIDs) == 0 || contains(roomIDs, device.Room) {
+			devices = append(devices, device)
+		}
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].Name < devices[j].Name
+	})
+	return devices
+}
+
+// RegisterGroup registers a named group of devices. All members have to be
+// registered before.
+func (r *registry) RegisterGroup(group domain.Group) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[group.Name]; ok {
+		return ErrGroupAlreadyRegistered
+	}
+	for _, member := range group.Members {
+		if _, ok := r.devices[member]; !ok {
+			return fmt.Errorf("%s: %w", member, ErrDeviceNotRegistered)
+		}
+	}
+	r.groups[group.Name] = group
+	return nil
+}
+
+func (r *registry) GetGroup(name string) (domain.Group, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	group, ok := r.groups[name]
+	if !ok {
+		return domain.Group{}, ErrGroupNotRegistered
+	}
+	return group, nil
+}
+
+func (r *registry) ListGroups() []domain.Group {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	groups := make([]domain.Group, 0, len(r.groups))
+	for _, group := range r.groups {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
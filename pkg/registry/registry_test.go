@@ -0,0 +1,138 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	r := NewRegistry()
+	device := domain.RegisteredDevice{Name: "smartBulb", Kind: domain.KindDevice, Address: "http://test", Route: "/smartBulb"}
+
+	assert.NoError(t, r.Register(device))
+	assert.ErrorIs(t, r.Register(device), ErrDeviceAlreadyRegistered)
+}
+
+func TestGet(t *testing.T) {
+	r := NewRegistry()
+	capabilities := domain.DefaultACCapabilities()
+	device := domain.RegisteredDevice{Name: "ac", Kind: domain.KindAC, Address: "http://test", Route: "/ac", ACCapabilities: &capabilities}
+	r.Register(device)
+
+	got, err := r.Get("ac")
+	assert.NoError(t, err)
+	assert.Equal(t, device, got)
+
+	_, err = r.Get("unknown")
+	assert.ErrorIs(t, err, ErrDeviceNotRegistered)
+}
+
+func TestList(t *testing.T) {
+	r := NewRegistry()
+	r.Register(domain.RegisteredDevice{Name: "smartPlug", Kind: domain.KindDevice})
+	r.Register(domain.RegisteredDevice{Name: "ac", Kind: domain.KindAC})
+	r.Register(domain.RegisteredDevice{Name: "gasSensor", Kind: domain.KindSensor})
+
+	got := r.List()
+
+	assert.Equal(t, []domain.RegisteredDevice{
+		{Name: "ac", Kind: domain.KindAC},
+		{Name: "gasSensor", Kind: domain.KindSensor},
+		{Name: "smartPlug", Kind: domain.KindDevice},
+	}, got)
+}
+
+func TestRegister_UnknownRoom(t *testing.T) {
+	r := NewRegistry()
+	device := domain.RegisteredDevice{Name: "smartBulb", Kind: domain.KindLight, Room: "livingRoom"}
+
+	assert.ErrorIs(t, r.Register(device), ErrRoomNotRegistered)
+
+	r.RegisterRoom(domain.Room{ID: "livingRoom", Name: "Living room"})
+	assert.NoError(t, r.Register(device))
+}
+
+func TestRegisterRoom(t *testing.T) {
+	r := NewRegistry()
+	room := domain.Room{ID: "bedroom", Name: "Bedroom", Zone: "upstairs"}
+
+	assert.NoError(t, r.RegisterRoom(room))
+	assert.ErrorIs(t, r.RegisterRoom(room), ErrRoomAlreadyRegistered)
+
+	got, err := r.GetRoom("bedroom")
+	assert.NoError(t, err)
+	assert.Equal(t, room, got)
+
+	_, err = r.GetRoom("unknown")
+	assert.ErrorIs(t, err, ErrRoomNotRegistered)
+}
+
+func TestListRooms(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterRoom(domain.Room{ID: "livingRoom"})
+	r.RegisterRoom(domain.Room{ID: "bedroom"})
+
+	assert.Equal(t, []domain.Room{{ID: "bedroom"}, {ID: "livingRoom"}}, r.ListRooms())
+}
+
+func TestAssignRoom(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterRoom(domain.Room{ID: "livingRoom"})
+	r.Register(domain.RegisteredDevice{Name: "smartBulb", Kind: domain.KindLight})
+
+	assert.NoError(t, r.AssignRoom("smartBulb", "livingRoom"))
+	got, _ := r.Get("smartBulb")
+	assert.Equal(t, "livingRoom", got.Room)
+
+	assert.ErrorIs(t, r.AssignRoom("smartBulb", "unknown"), ErrRoomNotRegistered)
+	assert.ErrorIs(t, r.AssignRoom("unknown", "livingRoom"), ErrDeviceNotRegistered)
+
+	assert.NoError(t, r.AssignRoom("smartBulb", ""))
+	got, _ = r.Get("smartBulb")
+	assert.Equal(t, "", got.Room)
+}
+
+func TestListByRooms(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterRoom(domain.Room{ID: "livingRoom"})
+	r.RegisterRoom(domain.Room{ID: "bedroom"})
+	r.RegisterRoom(domain.Room{ID: "kitchen"})
+	r.Register(domain.RegisteredDevice{Name: "smartBulb", Room: "livingRoom"})
+	r.Register(domain.RegisteredDevice{Name: "ac", Room: "bedroom"})
+	r.Register(domain.RegisteredDevice{Name: "gasSensor", Room: "kitchen"})
+	r.Register(domain.RegisteredDevice{Name: "doorsSensor"})
+
+	assert.Equal(t, []domain.RegisteredDevice{
+		{Name: "ac", Room: "bedroom"},
+		{Name: "smartBulb", Room: "livingRoom"},
+	}, r.ListByRooms("livingRoom", "bedroom"))
+	assert.Len(t, r.ListByRooms(), 4)
+}
+
+func TestRegisterGroup(t *testing.T) {
+	r := NewRegistry()
+	r.Register(domain.RegisteredDevice{Name: "smartPlug", Kind: domain.KindPlug})
+	group := domain.Group{Name: "allPlugs", Members: []string{"smartPlug"}}
+
+	assert.NoError(t, r.RegisterGroup(group))
+	assert.ErrorIs(t, r.RegisterGroup(group), ErrGroupAlreadyRegistered)
+	assert.ErrorIs(t, r.RegisterGroup(domain.Group{Name: "outdoorLights", Members: []string{"gardenBulb"}}),
+		ErrDeviceNotRegistered)
+
+	got, err := r.GetGroup("allPlugs")
+	assert.NoError(t, err)
+	assert.Equal(t, group, got)
+
+	_, err = r.GetGroup("unknown")
+	assert.ErrorIs(t, err, ErrGroupNotRegistered)
+}
+
+func TestListGroups(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterGroup(domain.Group{Name: "outdoorLights"})
+	r.RegisterGroup(domain.Group{Name: "allPlugs"})
+
+	assert.Equal(t, []domain.Group{{Name: "allPlugs"}, {Name: "outdoorLights"}}, r.ListGroups())
+}
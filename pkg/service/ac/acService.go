@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
 	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
 )
@@ -8,17 +10,22 @@ import (
 //go:generate --name ACService --output mock_acService.go
 type ACService interface {
 	GetInfo() (domain.ACInfo, error)
-	ToggleEnabled() (domain.ACInfo, error)
-	UpdateACSettings(desiredTemp float32, desiredHum float32) (domain.ACInfo, error)
+	ReadInfo(ctx context.Context) (domain.ACInfo, error)
+	ToggleEnabled(ctx context.Context) (domain.ACInfo, error)
+	UpdateACSettings(desiredSettings domain.ACInfo) (domain.ACInfo, error)
+	GetCapabilities() domain.ACCapabilities
 	GetACLogsFromDataServiceLimitN(limit int) ([]domain.ACData, error)
+	QueryACLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.ACData], error)
 }
 
 type acService struct {
-	ac *domain.AC
+	ac            *domain.AC
+	capabilities  domain.ACCapabilities
+	powerOnPolicy domain.ACPowerOnPolicy
 }
 
-func NewACService(ac *domain.AC) ACService {
-	return &acService{ac: ac}
+func NewACService(ac *domain.AC, capabilities domain.ACCapabilities, powerOnPolicy domain.ACPowerOnPolicy) ACService {
+	return &acService{ac: ac, capabilities: capabilities, powerOnPolicy: powerOnPolicy}
 }
 
 func (s *acService) GetInfo() (domain.ACInfo, error) {
@@ -27,19 +34,63 @@ func (s *acService) GetInfo() (domain.ACInfo, error) {
 		domain.ACInfo{Enabled: false, Temperature: 0.0, Humidity: 0.0})
 }
 
-func (s *acService) ToggleEnabled() (domain.ACInfo, error) {
+func (s *acService) ReadInfo(ctx context.Context) (domain.ACInfo, error) {
+	address := s.ac.Address + controlStationUtils.InfoEndpoint
+	return controlStationUtils.FetchJSONContext[domain.ACInfo](ctx, address, s.ac.Name)
+}
+
+func (s *acService) ToggleEnabled(ctx context.Context) (domain.ACInfo, error) {
 	address := s.ac.Address + controlStationUtils.EnabledEndpoint
-	return controlStationUtils.MakePatchRequest(address, s.ac.Name, nil,
+	return controlStationUtils.MakePatchRequestContext(ctx, address, s.ac.Name, nil,
 		domain.ACInfo{Enabled: false, Temperature: 0.0, Humidity: 0.0})
 }
 
-func (s *acService) UpdateACSettings(desiredTemp float32, desiredHum float32) (domain.ACInfo, error) {
+func (s *acService) UpdateACSettings(desiredSettings domain.ACInfo) (domain.ACInfo, error) {
+	defaultValueOnError := domain.ACInfo{Enabled: false, Temperature: 0.0, Humidity: 0.0}
+	if err := s.validateSettings(desiredSettings); err != nil {
+		return defaultValueOnError, err
+	}
+
+	enabled, err := s.resolveEnabled(desiredSettings)
+	if err != nil {
+		return defaultValueOnError, err
+	}
+
+	settings := desiredSettings
+	settings.Enabled = enabled
 	address := s.ac.Address + controlStationUtils.UpdateEndpoint
-	return controlStationUtils.MakePatchRequest(address, s.ac.Name,
-		&domain.ACInfo{Enabled: true, Temperature: desiredTemp, Humidity: desiredHum},
-		domain.ACInfo{Enabled: false, Temperature: 0.0, Humidity: 0.0})
+	return controlStationUtils.MakePatchRequest(address, s.ac.Name, &settings, defaultValueOnError)
+}
+
+func (s *acService) GetCapabilities() domain.ACCapabilities {
+	return s.capabilities
 }
 
 func (s *acService) GetACLogsFromDataServiceLimitN(limit int) ([]domain.ACData, error) {
 	return controlStationUtils.GetLogsFromDataServiceLimitN[domain.ACData](s.ac.Name, limit)
 }
+
+func (s *acService) QueryACLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.ACData], error) {
+	return controlStationUtils.QueryLogsFromDataService[domain.ACData](s.ac.Name, query)
+}
+
+func (s *acService) validateSettings(settings domain.ACInfo) error {
+	validationErr := &controlStationUtils.ValidationError{}
+	controlStationUtils.ValidateACSettings(validationErr, settings, s.capabilities)
+	return validationErr.ErrorOrNil()
+}
+
+func (s *acService) resolveEnabled(settings domain.ACInfo) (bool, error) {
+	switch s.powerOnPolicy {
+	case domain.ACPowerOnKeep:
+		currentInfo, err := s.GetInfo()
+		if err != nil {
+			return false, err
+		}
+		return currentInfo.Enabled, nil
+	case domain.ACPowerOnRequest:
+		return settings.Enabled, nil
+	default:
+		return true, nil
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -8,12 +9,13 @@ import (
 	"time"
 
 	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewACService(t *testing.T) {
 	ac := domain.AC{Name: "test", Address: "http://test"}
-	service := NewACService(&ac)
+	service := NewACService(&ac, domain.DefaultACCapabilities(), domain.ACPowerOnAlways)
 	assert.NotNil(t, service)
 }
 
@@ -87,7 +89,7 @@ func TestToggleEnabled(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			defer test.ts.Close()
 			service := &acService{ac: &domain.AC{Name: "test", Address: test.ts.URL}}
-			got, err := service.ToggleEnabled()
+			got, err := service.ToggleEnabled(context.Background())
 
 			assert.Equal(t, test.want, got)
 			assert.Equal(t, test.wantErr, err != nil)
@@ -158,44 +160,207 @@ func TestGetDeviceLogsFromDataServiceLimitN(t *testing.T) {
 
 func TestUpdateACSettings(t *testing.T) {
 	tests := []struct {
-		name        string
-		desiredTemp float32
-		desiredHum  float32
-		ts          *httptest.Server
-		want        domain.ACInfo
-		wantErr     bool
+		name          string
+		desired       domain.ACInfo
+		powerOnPolicy domain.ACPowerOnPolicy
+		currentInfo   domain.ACInfo
+		status        int
+		wantRequest   *domain.ACInfo
+		want          domain.ACInfo
+		wantErr       bool
 	}{
 		{
-			name:        "Success",
-			desiredTemp: float32(20),
-			desiredHum:  float32(50),
-			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusOK)
-				json.NewEncoder(w).Encode(domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50})
-			})),
-			want:    domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50},
-			wantErr: false,
+			name:          "Success",
+			desired:       domain.ACInfo{Enabled: false, Temperature: 20, Humidity: 50},
+			powerOnPolicy: domain.ACPowerOnAlways,
+			status:        http.StatusOK,
+			wantRequest:   &domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50},
+			want:          domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50},
+			wantErr:       false,
 		},
 		{
-			name:        "Failure",
-			desiredTemp: float32(20),
-			desiredHum:  float32(50),
-			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusInternalServerError)
-			},
-			)),
-			want:    domain.ACInfo{},
-			wantErr: true,
+			name:          "KeepPowerState",
+			desired:       domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50},
+			powerOnPolicy: domain.ACPowerOnKeep,
+			currentInfo:   domain.ACInfo{Enabled: false, Temperature: 24, Humidity: 40},
+			status:        http.StatusOK,
+			wantRequest:   &domain.ACInfo{Enabled: false, Temperature: 20, Humidity: 50},
+			want:          domain.ACInfo{Enabled: false, Temperature: 20, Humidity: 50},
+			wantErr:       false,
+		},
+		{
+			name:          "PowerStateFromRequest",
+			desired:       domain.ACInfo{Enabled: false, Temperature: 20, Humidity: 50},
+			powerOnPolicy: domain.ACPowerOnRequest,
+			status:        http.StatusOK,
+			wantRequest:   &domain.ACInfo{Enabled: false, Temperature: 20, Humidity: 50},
+			want:          domain.ACInfo{Enabled: false, Temperature: 20, Humidity: 50},
+			wantErr:       false,
+		},
+		{
+			name:          "ValidationFailure",
+			desired:       domain.ACInfo{Enabled: true, Temperature: 80, Humidity: -5},
+			powerOnPolicy: domain.ACPowerOnAlways,
+			status:        http.StatusOK,
+			wantRequest:   nil,
+			want:          domain.ACInfo{},
+			wantErr:       true,
+		},
+		{
+			name:          "Failure",
+			desired:       domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50},
+			powerOnPolicy: domain.ACPowerOnAlways,
+			status:        http.StatusInternalServerError,
+			wantRequest:   &domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50},
+			want:          domain.ACInfo{},
+			wantErr:       true,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			defer test.ts.Close()
-			service := &acService{ac: &domain.AC{Name: "test", Address: test.ts.URL}}
-			got, err := service.UpdateACSettings(test.desiredTemp, test.desiredHum)
+			var gotRequest *domain.ACInfo
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					json.NewEncoder(w).Encode(test.currentInfo)
+					return
+				}
+				gotRequest = &domain.ACInfo{}
+				json.NewDecoder(r.Body).Decode(gotRequest)
+				w.WriteHeader(test.status)
+				json.NewEncoder(w).Encode(gotRequest)
+			}))
+			defer ts.Close()
+			t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+			service := &acService{
+				ac:            &domain.AC{Name: "test", Address: ts.URL},
+				capabilities:  domain.DefaultACCapabilities(),
+				powerOnPolicy: test.powerOnPolicy,
+			}
+			got, err := service.UpdateACSettings(test.desired)
 
 			assert.Equal(t, test.want, got)
 			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.wantRequest, gotRequest)
 		})
 	}
 }
+
+func TestUpdateACSettings_ValidationErrors(t *testing.T) {
+	service := &acService{
+		ac:            &domain.AC{Name: "test", Address: "http://localhost:1234"},
+		capabilities:  domain.DefaultACCapabilities(),
+		powerOnPolicy: domain.ACPowerOnAlways,
+	}
+
+	_, err := service.UpdateACSettings(domain.ACInfo{Enabled: true, Temperature: 20.3, Humidity: 80})
+
+	var validationErr *controlStationUtils.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []controlStationUtils.FieldError{
+		{Field: "temperature", Message: "must be a multiple of 0.5 starting from 16"},
+		{Field: "humidity", Message: "must be between 30 and 70"},
+	}, validationErr.Errors)
+}
+
+func TestGetCapabilities(t *testing.T) {
+	capabilities := domain.DefaultACCapabilities()
+	service := NewACService(&domain.AC{Name: "test", Address: "http://test"}, capabilities, domain.ACPowerOnAlways)
+
+	assert.Equal(t, capabilities, service.GetCapabilities())
+}
+
+func TestUpdateACSettings_ExtendedValidation(t *testing.T) {
+	enabled := true
+	limitedCapabilities := domain.ExtendedACCapabilities()
+	limitedCapabilities.Modes = []domain.ACMode{domain.ACModeCool}
+	limitedCapabilities.FanSpeeds = 0
+	limitedCapabilities.SupportsSwing = false
+
+	tests := []struct {
+		name         string
+		capabilities domain.ACCapabilities
+		settings     domain.ACInfo
+		want         []controlStationUtils.FieldError
+	}{
+		{
+			name:         "UnsupportedMode",
+			capabilities: limitedCapabilities,
+			settings:     domain.ACInfo{Temperature: 20, Humidity: 50, Mode: domain.ACModeHeat},
+			want:         []controlStationUtils.FieldError{{Field: "mode", Message: "must be one of [cool]"}},
+		},
+		{
+			name:         "LegacyDefaults",
+			capabilities: domain.DefaultACCapabilities(),
+			settings:     domain.ACInfo{Temperature: 20, Humidity: 50, Mode: domain.ACModeCool, Eco: &enabled},
+			want: []controlStationUtils.FieldError{
+				{Field: "mode", Message: "is not supported"},
+				{Field: "eco", Message: "is not supported"},
+			},
+		},
+		{
+			name:         "FanSpeedNotSupported",
+			capabilities: limitedCapabilities,
+			settings:     domain.ACInfo{Temperature: 20, Humidity: 50, FanSpeed: 1},
+			want:         []controlStationUtils.FieldError{{Field: "fan_speed", Message: "is not supported"}},
+		},
+		{
+			name:         "FanSpeedOutOfRange",
+			capabilities: domain.ExtendedACCapabilities(),
+			settings:     domain.ACInfo{Temperature: 20, Humidity: 50, FanSpeed: 5},
+			want:         []controlStationUtils.FieldError{{Field: "fan_speed", Message: "must be between 1 and 3"}},
+		},
+		{
+			name:         "SwingNotSupported",
+			capabilities: limitedCapabilities,
+			settings:     domain.ACInfo{Temperature: 20, Humidity: 50, Swing: &enabled},
+			want:         []controlStationUtils.FieldError{{Field: "swing", Message: "is not supported"}},
+		},
+		{
+			name:         "EcoAndTurbo",
+			capabilities: domain.ExtendedACCapabilities(),
+			settings:     domain.ACInfo{Temperature: 20, Humidity: 50, Eco: &enabled, Turbo: &enabled},
+			want:         []controlStationUtils.FieldError{{Field: "turbo", Message: "cannot be enabled together with eco"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := &acService{
+				ac:            &domain.AC{Name: "test", Address: "http://localhost:1234"},
+				capabilities:  test.capabilities,
+				powerOnPolicy: domain.ACPowerOnAlways,
+			}
+
+			_, err := service.UpdateACSettings(test.settings)
+
+			var validationErr *controlStationUtils.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, test.want, validationErr.Errors)
+		})
+	}
+}
+
+func TestUpdateACSettings_ExtendedSettingsForwarded(t *testing.T) {
+	eco := true
+	var gotBody map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"enabled": true, "temperature": 20, "humidity": 50}`))
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := &acService{
+		ac:            &domain.AC{Name: "test", Address: ts.URL},
+		capabilities:  domain.ExtendedACCapabilities(),
+		powerOnPolicy: domain.ACPowerOnAlways,
+	}
+	got, err := service.UpdateACSettings(domain.ACInfo{Temperature: 20, Humidity: 50, Mode: domain.ACModeDry, Eco: &eco})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50}, got)
+	assert.Equal(t, "dry", gotBody["mode"])
+	assert.Equal(t, true, gotBody["eco"])
+	assert.NotContains(t, gotBody, "turbo")
+}
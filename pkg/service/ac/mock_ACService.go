@@ -3,6 +3,8 @@
 package service
 
 import (
+	context "context"
+
 	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -74,6 +76,47 @@ func (_c *MockACService_GetACLogsFromDataServiceLimitN_Call) RunAndReturn(run fu
 	return _c
 }
 
+// GetCapabilities provides a mock function with given fields:
+func (_m *MockACService) GetCapabilities() domain.ACCapabilities {
+	ret := _m.Called()
+
+	var r0 domain.ACCapabilities
+	if rf, ok := ret.Get(0).(func() domain.ACCapabilities); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.ACCapabilities)
+	}
+
+	return r0
+}
+
+// MockACService_GetCapabilities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCapabilities'
+type MockACService_GetCapabilities_Call struct {
+	*mock.Call
+}
+
+// GetCapabilities is a helper method to define mock.On call
+func (_e *MockACService_Expecter) GetCapabilities() *MockACService_GetCapabilities_Call {
+	return &MockACService_GetCapabilities_Call{Call: _e.mock.On("GetCapabilities")}
+}
+
+func (_c *MockACService_GetCapabilities_Call) Run(run func()) *MockACService_GetCapabilities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockACService_GetCapabilities_Call) Return(_a0 domain.ACCapabilities) *MockACService_GetCapabilities_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockACService_GetCapabilities_Call) RunAndReturn(run func() domain.ACCapabilities) *MockACService_GetCapabilities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetInfo provides a mock function with given fields:
 func (_m *MockACService) GetInfo() (domain.ACInfo, error) {
 	ret := _m.Called()
@@ -125,23 +168,127 @@ func (_c *MockACService_GetInfo_Call) RunAndReturn(run func() (domain.ACInfo, er
 	return _c
 }
 
-// ToggleEnabled provides a mock function with given fields:
-func (_m *MockACService) ToggleEnabled() (domain.ACInfo, error) {
-	ret := _m.Called()
+// QueryACLogsFromDataService provides a mock function with given fields: query
+func (_m *MockACService) QueryACLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.ACData], error) {
+	ret := _m.Called(query)
+
+	var r0 domain.LogPage[domain.ACData]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.LogQuery) (domain.LogPage[domain.ACData], error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(domain.LogQuery) domain.LogPage[domain.ACData]); ok {
+		r0 = rf(query)
+	} else {
+		r0 = ret.Get(0).(domain.LogPage[domain.ACData])
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.LogQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockACService_QueryACLogsFromDataService_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryACLogsFromDataService'
+type MockACService_QueryACLogsFromDataService_Call struct {
+	*mock.Call
+}
+
+// QueryACLogsFromDataService is a helper method to define mock.On call
+//   - query domain.LogQuery
+func (_e *MockACService_Expecter) QueryACLogsFromDataService(query interface{}) *MockACService_QueryACLogsFromDataService_Call {
+	return &MockACService_QueryACLogsFromDataService_Call{Call: _e.mock.On("QueryACLogsFromDataService", query)}
+}
+
+func (_c *MockACService_QueryACLogsFromDataService_Call) Run(run func(query domain.LogQuery)) *MockACService_QueryACLogsFromDataService_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.LogQuery))
+	})
+	return _c
+}
+
+func (_c *MockACService_QueryACLogsFromDataService_Call) Return(_a0 domain.LogPage[domain.ACData], _a1 error) *MockACService_QueryACLogsFromDataService_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockACService_QueryACLogsFromDataService_Call) RunAndReturn(run func(domain.LogQuery) (domain.LogPage[domain.ACData], error)) *MockACService_QueryACLogsFromDataService_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadInfo provides a mock function with given fields: ctx
+func (_m *MockACService) ReadInfo(ctx context.Context) (domain.ACInfo, error) {
+	ret := _m.Called(ctx)
 
 	var r0 domain.ACInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (domain.ACInfo, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.ACInfo, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() domain.ACInfo); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) domain.ACInfo); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(domain.ACInfo)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockACService_ReadInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadInfo'
+type MockACService_ReadInfo_Call struct {
+	*mock.Call
+}
+
+// ReadInfo is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockACService_Expecter) ReadInfo(ctx interface{}) *MockACService_ReadInfo_Call {
+	return &MockACService_ReadInfo_Call{Call: _e.mock.On("ReadInfo", ctx)}
+}
+
+func (_c *MockACService_ReadInfo_Call) Run(run func(ctx context.Context)) *MockACService_ReadInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockACService_ReadInfo_Call) Return(_a0 domain.ACInfo, _a1 error) *MockACService_ReadInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockACService_ReadInfo_Call) RunAndReturn(run func(context.Context) (domain.ACInfo, error)) *MockACService_ReadInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ToggleEnabled provides a mock function with given fields: ctx
+func (_m *MockACService) ToggleEnabled(ctx context.Context) (domain.ACInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 domain.ACInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.ACInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.ACInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.ACInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -155,13 +302,14 @@ type MockACService_ToggleEnabled_Call struct {
 }
 
 // ToggleEnabled is a helper method to define mock.On call
-func (_e *MockACService_Expecter) ToggleEnabled() *MockACService_ToggleEnabled_Call {
-	return &MockACService_ToggleEnabled_Call{Call: _e.mock.On("ToggleEnabled")}
+//   - ctx context.Context
+func (_e *MockACService_Expecter) ToggleEnabled(ctx interface{}) *MockACService_ToggleEnabled_Call {
+	return &MockACService_ToggleEnabled_Call{Call: _e.mock.On("ToggleEnabled", ctx)}
 }
 
-func (_c *MockACService_ToggleEnabled_Call) Run(run func()) *MockACService_ToggleEnabled_Call {
+func (_c *MockACService_ToggleEnabled_Call) Run(run func(ctx context.Context)) *MockACService_ToggleEnabled_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -171,28 +319,28 @@ func (_c *MockACService_ToggleEnabled_Call) Return(_a0 domain.ACInfo, _a1 error)
 	return _c
 }
 
-func (_c *MockACService_ToggleEnabled_Call) RunAndReturn(run func() (domain.ACInfo, error)) *MockACService_ToggleEnabled_Call {
+func (_c *MockACService_ToggleEnabled_Call) RunAndReturn(run func(context.Context) (domain.ACInfo, error)) *MockACService_ToggleEnabled_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateACSettings provides a mock function with given fields: desiredTemp, desiredHum
-func (_m *MockACService) UpdateACSettings(desiredTemp float32, desiredHum float32) (domain.ACInfo, error) {
-	ret := _m.Called(desiredTemp, desiredHum)
+// UpdateACSettings provides a mock function with given fields: desiredSettings
+func (_m *MockACService) UpdateACSettings(desiredSettings domain.ACInfo) (domain.ACInfo, error) {
+	ret := _m.Called(desiredSettings)
 
 	var r0 domain.ACInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func(float32, float32) (domain.ACInfo, error)); ok {
-		return rf(desiredTemp, desiredHum)
+	if rf, ok := ret.Get(0).(func(domain.ACInfo) (domain.ACInfo, error)); ok {
+		return rf(desiredSettings)
 	}
-	if rf, ok := ret.Get(0).(func(float32, float32) domain.ACInfo); ok {
-		r0 = rf(desiredTemp, desiredHum)
+	if rf, ok := ret.Get(0).(func(domain.ACInfo) domain.ACInfo); ok {
+		r0 = rf(desiredSettings)
 	} else {
 		r0 = ret.Get(0).(domain.ACInfo)
 	}
 
-	if rf, ok := ret.Get(1).(func(float32, float32) error); ok {
-		r1 = rf(desiredTemp, desiredHum)
+	if rf, ok := ret.Get(1).(func(domain.ACInfo) error); ok {
+		r1 = rf(desiredSettings)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -206,15 +354,14 @@ type MockACService_UpdateACSettings_Call struct {
 }
 
 // UpdateACSettings is a helper method to define mock.On call
-//   - desiredTemp float32
-//   - desiredHum float32
-func (_e *MockACService_Expecter) UpdateACSettings(desiredTemp interface{}, desiredHum interface{}) *MockACService_UpdateACSettings_Call {
-	return &MockACService_UpdateACSettings_Call{Call: _e.mock.On("UpdateACSettings", desiredTemp, desiredHum)}
+//   - desiredSettings domain.ACInfo
+func (_e *MockACService_Expecter) UpdateACSettings(desiredSettings interface{}) *MockACService_UpdateACSettings_Call {
+	return &MockACService_UpdateACSettings_Call{Call: _e.mock.On("UpdateACSettings", desiredSettings)}
 }
 
-func (_c *MockACService_UpdateACSettings_Call) Run(run func(desiredTemp float32, desiredHum float32)) *MockACService_UpdateACSettings_Call {
+func (_c *MockACService_UpdateACSettings_Call) Run(run func(desiredSettings domain.ACInfo)) *MockACService_UpdateACSettings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(float32), args[1].(float32))
+		run(args[0].(domain.ACInfo))
 	})
 	return _c
 }
@@ -224,7 +371,7 @@ func (_c *MockACService_UpdateACSettings_Call) Return(_a0 domain.ACInfo, _a1 err
 	return _c
 }
 
-func (_c *MockACService_UpdateACSettings_Call) RunAndReturn(run func(float32, float32) (domain.ACInfo, error)) *MockACService_UpdateACSettings_Call {
+func (_c *MockACService_UpdateACSettings_Call) RunAndReturn(run func(domain.ACInfo) (domain.ACInfo, error)) *MockACService_UpdateACSettings_Call {
 	_c.Call.Return(run)
 	return _c
 }
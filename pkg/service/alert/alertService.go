@@ -0,0 +1,71 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+const alertsLogName = "alerts"
+
+//go:generate --name AlertService --output mock_alertService.go
+type AlertService interface {
+	Raise(alert domain.Alert) error
+	GetAlertsFromDataServiceLimitN(limit int) ([]domain.Alert, error)
+}
+
+type alertService struct {
+	webhookURL string
+}
+
+// NewAlertService returns a service that logs the alerts, persists them in the
+// data service and posts them to the webhook, if one is configured.
+func NewAlertService(webhookURL string) AlertService {
+	return &alertService{webhookURL: webhookURL}
+}
+
+// Raise delivers the alert to every destination and returns the first delivery
+// error, if any.
+func (s *alertService) Raise(alert domain.Alert) error {
+	log.Printf("ALERT [%s] %s: %s\n", alert.Severity, alert.Source, alert.Message)
+
+	err := controlStationUtils.SendLogsToDataService(alertsLogName, alert)
+	if err != nil {
+		log.Printf("Failed to send '%s' logs to data service: %s\n", alertsLogName, err)
+	}
+
+	if s.webhookURL == "" {
+		return err
+	}
+	if webhookErr := s.postToWebhook(alert); webhookErr != nil {
+		log.Printf("Failed to post the alert to the webhook: %s\n", webhookErr)
+		return webhookErr
+	}
+	return err
+}
+
+func (s *alertService) GetAlertsFromDataServiceLimitN(limit int) ([]domain.Alert, error) {
+	return controlStationUtils.GetLogsFromDataServiceLimitN[domain.Alert](alertsLogName, limit)
+}
+
+func (s *alertService) postToWebhook(alert domain.Alert) error {
+	jsonValue, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(s.webhookURL, "application/json", bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("Webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
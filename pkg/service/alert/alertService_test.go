@@ -0,0 +1,65 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var testAlert = domain.Alert{Severity: domain.AlertCritical, Source: "gasSensor", Message: "Gas detected",
+	Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+func TestRaise(t *testing.T) {
+	var persisted, posted domain.Alert
+	dataService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/alerts/add", r.URL.Path)
+		json.NewDecoder(r.Body).Decode(&persisted)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer dataService.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", dataService.URL)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&posted)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer webhook.Close()
+
+	err := NewAlertService(webhook.URL).Raise(testAlert)
+
+	assert.NoError(t, err)
+	assert.Equal(t, testAlert, persisted)
+	assert.Equal(t, testAlert, posted)
+}
+
+func TestRaise_WebhookFailure(t *testing.T) {
+	dataService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer dataService.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", dataService.URL)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer webhook.Close()
+
+	err := NewAlertService(webhook.URL).Raise(testAlert)
+
+	assert.EqualError(t, err, "Webhook responded with status 502")
+}
+
+func TestRaise_WithoutWebhook(t *testing.T) {
+	dataService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer dataService.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", dataService.URL)
+
+	err := NewAlertService("").Raise(testAlert)
+
+	assert.NoError(t, err)
+}
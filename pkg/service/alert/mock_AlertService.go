@@ -0,0 +1,132 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAlertService is an autogenerated mock type for the AlertService type
+type MockAlertService struct {
+	mock.Mock
+}
+
+type MockAlertService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAlertService) EXPECT() *MockAlertService_Expecter {
+	return &MockAlertService_Expecter{mock: &_m.Mock}
+}
+
+// GetAlertsFromDataServiceLimitN provides a mock function with given fields: limit
+func (_m *MockAlertService) GetAlertsFromDataServiceLimitN(limit int) ([]domain.Alert, error) {
+	ret := _m.Called(limit)
+
+	var r0 []domain.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]domain.Alert, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []domain.Alert); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAlertService_GetAlertsFromDataServiceLimitN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlertsFromDataServiceLimitN'
+type MockAlertService_GetAlertsFromDataServiceLimitN_Call struct {
+	*mock.Call
+}
+
+// GetAlertsFromDataServiceLimitN is a helper method to define mock.On call
+//   - limit int
+func (_e *MockAlertService_Expecter) GetAlertsFromDataServiceLimitN(limit interface{}) *MockAlertService_GetAlertsFromDataServiceLimitN_Call {
+	return &MockAlertService_GetAlertsFromDataServiceLimitN_Call{Call: _e.mock.On("GetAlertsFromDataServiceLimitN", limit)}
+}
+
+func (_c *MockAlertService_GetAlertsFromDataServiceLimitN_Call) Run(run func(limit int)) *MockAlertService_GetAlertsFromDataServiceLimitN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockAlertService_GetAlertsFromDataServiceLimitN_Call) Return(_a0 []domain.Alert, _a1 error) *MockAlertService_GetAlertsFromDataServiceLimitN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAlertService_GetAlertsFromDataServiceLimitN_Call) RunAndReturn(run func(int) ([]domain.Alert, error)) *MockAlertService_GetAlertsFromDataServiceLimitN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Raise provides a mock function with given fields: alert
+func (_m *MockAlertService) Raise(alert domain.Alert) error {
+	ret := _m.Called(alert)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Alert) error); ok {
+		r0 = rf(alert)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAlertService_Raise_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Raise'
+type MockAlertService_Raise_Call struct {
+	*mock.Call
+}
+
+// Raise is a helper method to define mock.On call
+//   - alert domain.Alert
+func (_e *MockAlertService_Expecter) Raise(alert interface{}) *MockAlertService_Raise_Call {
+	return &MockAlertService_Raise_Call{Call: _e.mock.On("Raise", alert)}
+}
+
+func (_c *MockAlertService_Raise_Call) Run(run func(alert domain.Alert)) *MockAlertService_Raise_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Alert))
+	})
+	return _c
+}
+
+func (_c *MockAlertService_Raise_Call) Return(_a0 error) *MockAlertService_Raise_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAlertService_Raise_Call) RunAndReturn(run func(domain.Alert) error) *MockAlertService_Raise_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockAlertService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockAlertService creates a new instance of MockAlertService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockAlertService(t mockConstructorTestingTNewMockAlertService) *MockAlertService {
+	mock := &MockAlertService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
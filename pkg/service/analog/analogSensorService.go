@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/event"
+	alertService "github.com/pklimuk-eng-thesis/control-station/pkg/service/alert"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+//go:generate --name AnalogSensorService --output mock_analogSensorService.go
+type AnalogSensorService interface {
+	GetInfo() (domain.AnalogSensorInfo, error)
+	ReadInfo(ctx context.Context) (domain.AnalogSensorInfo, error)
+	ToggleEnabled(ctx context.Context) (domain.AnalogSensorInfo, error)
+	GetThreshold() domain.AnalogThreshold
+	GetAnalogSensorLogsFromDataServiceLimitN(limit int) ([]domain.AnalogSensorData, error)
+	QueryAnalogSensorLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.AnalogSensorData], error)
+}
+
+type analogSensorService struct {
+	sensor       *domain.AnalogSensor
+	eventBus     event.Bus
+	alertService alertService.AlertService
+	mu           sync.Mutex
+	detected     bool
+	now          func() time.Time
+}
+
+// NewAnalogSensorService returns the service of the sensor, which raises a
+// warning through the alert service whenever a reading crosses the threshold.
+func NewAnalogSensorService(sensor *domain.AnalogSensor, eventBus event.Bus,
+	alertService alertService.AlertService) AnalogSensorService {
+	return &analogSensorService{sensor: sensor, eventBus: eventBus, alertService: alertService, now: time.Now}
+}
+
+func (s *analogSensorService) GetInfo() (domain.AnalogSensorInfo, error) {
+	address := s.sensor.Address + controlStationUtils.InfoEndpoint
+	sensorInfo, err := controlStationUtils.FetchJSON[domain.AnalogSensorInfo](address, s.sensor.Name)
+	if err != nil {
+		return domain.AnalogSensorInfo{Enabled: false, Unit: s.sensor.Unit}, err
+	}
+	return s.processReading(sensorInfo), nil
+}
+
+// ReadInfo reads the sensor like GetInfo, but derives the threshold state
+// without changing it, publishing events or logging the reading.
+func (s *analogSensorService) ReadInfo(ctx context.Context) (domain.AnalogSensorInfo, error) {
+	address := s.sensor.Address + controlStationUtils.InfoEndpoint
+	sensorInfo, err := controlStationUtils.FetchJSONContext[domain.AnalogSensorInfo](ctx, address, s.sensor.Name)
+	if err != nil {
+		return domain.AnalogSensorInfo{Enabled: false, Unit: s.sensor.Unit}, err
+	}
+
+	sensorInfo = s.complete(sensorInfo)
+	s.mu.Lock()
+	sensorInfo.Detected = sensorInfo.Enabled && isDetected(sensorInfo.Value, s.sensor.Threshold, s.detected)
+	s.mu.Unlock()
+	return sensorInfo, nil
+}
+
+func (s *analogSensorService) ToggleEnabled(ctx context.Context) (domain.AnalogSensorInfo, error) {
+	address := s.sensor.Address + controlStationUtils.EnabledEndpoint
+	sensorInfo, err := controlStationUtils.PatchJSONContext[domain.AnalogSensorInfo](ctx, address, s.sensor.Name, nil)
+	if err != nil {
+		return domain.AnalogSensorInfo{Enabled: false, Unit: s.sensor.Unit}, err
+	}
+	return s.processReading(sensorInfo), nil
+}
+
+func (s *analogSensorService) GetThreshold() domain.AnalogThreshold {
+	return s.sensor.Threshold
+}
+
+func (s *analogSensorService) GetAnalogSensorLogsFromDataServiceLimitN(limit int) ([]domain.AnalogSensorData, error) {
+	return controlStationUtils.GetLogsFromDataServiceLimitN[domain.AnalogSensorData](s.sensor.Name, limit)
+}
+
+func (s *analogSensorService) QueryAnalogSensorLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.AnalogSensorData], error) {
+	return controlStationUtils.QueryLogsFromDataService[domain.AnalogSensorData](s.sensor.Name, query)
+}
+
+// processReading completes the reading reported by the sensor, derives whether
+// it crosses the threshold, publishes an event when that changes, raises an
+// alert when it starts crossing and sends the completed reading to the data
+// service.
+func (s *analogSensorService) processReading(sensorInfo domain.AnalogSensorInfo) domain.AnalogSensorInfo {
+	sensorInfo = s.complete(sensorInfo)
+
+	// The event is published under the lock, so that concurrent readings can not
+	// publish their changes in a different order than they were detected.
+	s.mu.Lock()
+	wasDetected := s.detected
+	if sensorInfo.Enabled {
+		s.detected = isDetected(sensorInfo.Value, s.sensor.Threshold, wasDetected)
+	} else {
+		s.detected = false
+	}
+	sensorInfo.Detected = s.detected
+	crossed := sensorInfo.Detected && !wasDetected
+	if sensorInfo.Detected != wasDetected {
+		eventType := domain.EventCleared
+		if sensorInfo.Detected {
+			eventType = domain.EventDetected
+		}
+		value := sensorInfo.Value
+		s.eventBus.Publish(domain.DeviceEvent{Device: s.sensor.Name, Type: eventType, Value: &value, Time: sensorInfo.Timestamp})
+	}
+	s.mu.Unlock()
+
+	if crossed {
+		alert := domain.Alert{Severity: domain.AlertWarning, Source: s.sensor.Name, Time: sensorInfo.Timestamp,
+			Message: fmt.Sprintf("Reading of %g %s crossed the threshold", sensorInfo.Value, sensorInfo.Unit)}
+		if err := s.alertService.Raise(alert); err != nil {
+			log.Printf("Failed to deliver the threshold alert of '%s': %s\n", s.sensor.Name, err)
+		}
+	}
+
+	err := controlStationUtils.SendLogsToDataService(s.sensor.Name, sensorInfo)
+	if err != nil {
+		errStr := fmt.Sprintf("Failed to send '%s' logs to data service: %s", s.sensor.Name, err)
+		log.Println(errStr)
+	}
+
+	return sensorInfo
+}
+
+// complete fills in the unit and timestamp a sensor may leave out.
+func (s *analogSensorService) complete(sensorInfo domain.AnalogSensorInfo) domain.AnalogSensorInfo {
+	if sensorInfo.Unit == "" {
+		sensorInfo.Unit = s.sensor.Unit
+	}
+	if sensorInfo.Timestamp.IsZero() {
+		sensorInfo.Timestamp = s.now()
+	}
+	return sensorInfo
+}
+
+func isDetected(value float32, threshold domain.AnalogThreshold, wasDetected bool) bool {
+	if threshold.Above != nil {
+		limit := *threshold.Above
+		if wasDetected {
+			limit -= threshold.Hysteresis
+		}
+		if value > limit {
+			return true
+		}
+	}
+	if threshold.Below != nil {
+		limit := *threshold.Below
+		if wasDetected {
+			limit += threshold.Hysteresis
+		}
+		if value < limit {
+			return true
+		}
+	}
+	return false
+}
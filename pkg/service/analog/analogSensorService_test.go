@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/event"
+	alertService "github.com/pklimuk-eng-thesis/control-station/pkg/service/alert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func float32Ptr(v float32) *float32 {
+	return &v
+}
+
+var fixedNow = time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestService(address string, threshold domain.AnalogThreshold, eventBus event.Bus) *analogSensorService {
+	alerts := new(alertService.MockAlertService)
+	alerts.EXPECT().Raise(mock.Anything).Return(nil).Maybe()
+	return &analogSensorService{
+		sensor:       &domain.AnalogSensor{Name: "co2Sensor", Address: address, Unit: "ppm", Threshold: threshold},
+		eventBus:     eventBus,
+		alertService: alerts,
+		now:          func() time.Time { return fixedNow },
+	}
+}
+
+func TestNewAnalogSensorService(t *testing.T) {
+	sensor := domain.AnalogSensor{Name: "test", Address: "http://test", Unit: "°C"}
+	service := NewAnalogSensorService(&sensor, event.NewBus(), new(alertService.MockAlertService))
+	assert.NotNil(t, service)
+}
+
+func TestGetInfo(t *testing.T) {
+	readingTime := time.Date(2023, 1, 1, 11, 59, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		ts      *httptest.Server
+		want    domain.AnalogSensorInfo
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(domain.AnalogSensorInfo{Enabled: true, Value: 650, Unit: "ppm", Timestamp: readingTime})
+			})),
+			want:    domain.AnalogSensorInfo{Enabled: true, Value: 650, Unit: "ppm", Timestamp: readingTime},
+			wantErr: false,
+		},
+		{
+			name: "MissingUnitAndTimestamp",
+			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"enabled": true, "value": 1200}`))
+			})),
+			want:    domain.AnalogSensorInfo{Enabled: true, Value: 1200, Unit: "ppm", Timestamp: fixedNow, Detected: true},
+			wantErr: false,
+		},
+		{
+			name: "Failure",
+			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			})),
+			want:    domain.AnalogSensorInfo{Enabled: false, Unit: "ppm"},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer test.ts.Close()
+			t.Setenv("DATA_SERVICE_ADDRESS", test.ts.URL)
+			service := newTestService(test.ts.URL, domain.AnalogThreshold{Above: float32Ptr(1000), Hysteresis: 100}, event.NewBus())
+			got, err := service.GetInfo()
+
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantErr, err != nil)
+		})
+	}
+}
+
+func TestGetInfo_DetectedEvents(t *testing.T) {
+	readings := []float32{900, 1050, 950, 880, 1010, 1010}
+	current := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		json.NewEncoder(w).Encode(domain.AnalogSensorInfo{Enabled: true, Value: readings[current]})
+		current++
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	eventBus := event.NewBus()
+	var events []domain.DeviceEvent
+	eventBus.Subscribe(func(e domain.DeviceEvent) { events = append(events, e) })
+	service := newTestService(ts.URL, domain.AnalogThreshold{Above: float32Ptr(1000), Hysteresis: 100}, eventBus)
+	alerts := new(alertService.MockAlertService)
+	var raised []domain.Alert
+	alerts.EXPECT().Raise(mock.Anything).Run(func(a domain.Alert) { raised = append(raised, a) }).Return(nil)
+	service.alertService = alerts
+
+	var detected []bool
+	for range readings {
+		sensorInfo, err := service.GetInfo()
+		assert.NoError(t, err)
+		detected = append(detected, sensorInfo.Detected)
+	}
+
+	assert.Equal(t, []bool{false, true, true, false, true, true}, detected)
+	assert.Equal(t, []domain.DeviceEvent{
+		{Device: "co2Sensor", Type: domain.EventDetected, Value: float32Ptr(1050), Time: fixedNow},
+		{Device: "co2Sensor", Type: domain.EventCleared, Value: float32Ptr(880), Time: fixedNow},
+		{Device: "co2Sensor", Type: domain.EventDetected, Value: float32Ptr(1010), Time: fixedNow},
+	}, events)
+	assert.Equal(t, []domain.Alert{
+		{Severity: domain.AlertWarning, Source: "co2Sensor", Message: "Reading of 1050 ppm crossed the threshold", Time: fixedNow},
+		{Severity: domain.AlertWarning, Source: "co2Sensor", Message: "Reading of 1010 ppm crossed the threshold", Time: fixedNow},
+	}, raised)
+}
+
+func TestGetInfo_LogsDerivedReading(t *testing.T) {
+	var logged domain.AnalogSensorInfo
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			json.NewDecoder(r.Body).Decode(&logged)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Write([]byte(`{"enabled": true, "value": 5}`))
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestService(ts.URL, domain.AnalogThreshold{Below: float32Ptr(10), Hysteresis: 5}, event.NewBus())
+	_, err := service.GetInfo()
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AnalogSensorInfo{Enabled: true, Value: 5, Unit: "ppm", Timestamp: fixedNow, Detected: true}, logged)
+}
+
+func TestToggleEnabled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"enabled": false, "value": 1500}`))
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestService(ts.URL, domain.AnalogThreshold{Above: float32Ptr(1000)}, event.NewBus())
+	got, err := service.ToggleEnabled(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AnalogSensorInfo{Enabled: false, Value: 1500, Unit: "ppm", Timestamp: fixedNow, Detected: false}, got)
+}
+
+func TestIsDetected(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       float32
+		threshold   domain.AnalogThreshold
+		wasDetected bool
+		want        bool
+	}{
+		{name: "AboveThreshold", value: 31, threshold: domain.AnalogThreshold{Above: float32Ptr(30), Hysteresis: 1}, want: true},
+		{name: "BelowAboveThreshold", value: 30, threshold: domain.AnalogThreshold{Above: float32Ptr(30), Hysteresis: 1}, want: false},
+		{name: "WithinHysteresis", value: 29.5, threshold: domain.AnalogThreshold{Above: float32Ptr(30), Hysteresis: 1}, wasDetected: true, want: true},
+		{name: "BelowThreshold", value: 4, threshold: domain.AnalogThreshold{Below: float32Ptr(10), Hysteresis: 5}, want: true},
+		{name: "ClearedBelowThreshold", value: 16, threshold: domain.AnalogThreshold{Below: float32Ptr(10), Hysteresis: 5}, wasDetected: true, want: false},
+		{name: "NoThreshold", value: 100, threshold: domain.AnalogThreshold{}, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, isDetected(test.value, test.threshold, test.wasDetected))
+		})
+	}
+}
+
+func TestGetThreshold(t *testing.T) {
+	threshold := domain.AnalogThreshold{Above: float32Ptr(1000), Hysteresis: 100}
+	service := newTestService("http://test", threshold, event.NewBus())
+
+	assert.Equal(t, threshold, service.GetThreshold())
+}
+
+func TestGetAnalogSensorLogsFromDataServiceLimitN(t *testing.T) {
+	logs := []domain.AnalogSensorData{
+		{ID: 1, CreatedAt: fixedNow, IsEnabled: true, Value: 1200, Unit: "ppm", Timestamp: fixedNow, Detected: true},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(logs)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestService(ts.URL, domain.AnalogThreshold{}, event.NewBus())
+	got, err := service.GetAnalogSensorLogsFromDataServiceLimitN(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, logs, got)
+}
@@ -0,0 +1,340 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAnalogSensorService is an autogenerated mock type for the AnalogSensorService type
+type MockAnalogSensorService struct {
+	mock.Mock
+}
+
+type MockAnalogSensorService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAnalogSensorService) EXPECT() *MockAnalogSensorService_Expecter {
+	return &MockAnalogSensorService_Expecter{mock: &_m.Mock}
+}
+
+// GetAnalogSensorLogsFromDataServiceLimitN provides a mock function with given fields: limit
+func (_m *MockAnalogSensorService) GetAnalogSensorLogsFromDataServiceLimitN(limit int) ([]domain.AnalogSensorData, error) {
+	ret := _m.Called(limit)
+
+	var r0 []domain.AnalogSensorData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]domain.AnalogSensorData, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []domain.AnalogSensorData); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AnalogSensorData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAnalogSensorService_GetAnalogSensorLogsFromDataServiceLimitN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAnalogSensorLogsFromDataServiceLimitN'
+type MockAnalogSensorService_GetAnalogSensorLogsFromDataServiceLimitN_Call struct {
+	*mock.Call
+}
+
+// GetAnalogSensorLogsFromDataServiceLimitN is a helper method to define mock.On call
+//   - limit int
+func (_e *MockAnalogSensorService_Expecter) GetAnalogSensorLogsFromDataServiceLimitN(limit interface{}) *MockAnalogSensorService_GetAnalogSensorLogsFromDataServiceLimitN_Call {
+	return &MockAnalogSensorService_GetAnalogSensorLogsFromDataServiceLimitN_Call{Call: _e.mock.On("GetAnalogSensorLogsFromDataServiceLimitN", limit)}
+}
+
+func (_c *MockAnalogSensorService_GetAnalogSensorLogsFromDataServiceLimitN_Call) Run(run func(limit int)) *MockAnalogSensorService_GetAnalogSensorLogsFromDataServiceLimitN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockAnalogSensorService_GetAnalogSensorLogsFromDataServiceLimitN_Call) Return(_a0 []domain.AnalogSensorData, _a1 error) *MockAnalogSensorService_GetAnalogSensorLogsFromDataServiceLimitN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAnalogSensorService_GetAnalogSensorLogsFromDataServiceLimitN_Call) RunAndReturn(run func(int) ([]domain.AnalogSensorData, error)) *MockAnalogSensorService_GetAnalogSensorLogsFromDataServiceLimitN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInfo provides a mock function with given fields:
+func (_m *MockAnalogSensorService) GetInfo() (domain.AnalogSensorInfo, error) {
+	ret := _m.Called()
+
+	var r0 domain.AnalogSensorInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (domain.AnalogSensorInfo, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() domain.AnalogSensorInfo); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.AnalogSensorInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAnalogSensorService_GetInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInfo'
+type MockAnalogSensorService_GetInfo_Call struct {
+	*mock.Call
+}
+
+// GetInfo is a helper method to define mock.On call
+func (_e *MockAnalogSensorService_Expecter) GetInfo() *MockAnalogSensorService_GetInfo_Call {
+	return &MockAnalogSensorService_GetInfo_Call{Call: _e.mock.On("GetInfo")}
+}
+
+func (_c *MockAnalogSensorService_GetInfo_Call) Run(run func()) *MockAnalogSensorService_GetInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAnalogSensorService_GetInfo_Call) Return(_a0 domain.AnalogSensorInfo, _a1 error) *MockAnalogSensorService_GetInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAnalogSensorService_GetInfo_Call) RunAndReturn(run func() (domain.AnalogSensorInfo, error)) *MockAnalogSensorService_GetInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetThreshold provides a mock function with given fields:
+func (_m *MockAnalogSensorService) GetThreshold() domain.AnalogThreshold {
+	ret := _m.Called()
+
+	var r0 domain.AnalogThreshold
+	if rf, ok := ret.Get(0).(func() domain.AnalogThreshold); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.AnalogThreshold)
+	}
+
+	return r0
+}
+
+// MockAnalogSensorService_GetThreshold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThreshold'
+type MockAnalogSensorService_GetThreshold_Call struct {
+	*mock.Call
+}
+
+// GetThreshold is a helper method to define mock.On call
+func (_e *MockAnalogSensorService_Expecter) GetThreshold() *MockAnalogSensorService_GetThreshold_Call {
+	return &MockAnalogSensorService_GetThreshold_Call{Call: _e.mock.On("GetThreshold")}
+}
+
+func (_c *MockAnalogSensorService_GetThreshold_Call) Run(run func()) *MockAnalogSensorService_GetThreshold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAnalogSensorService_GetThreshold_Call) Return(_a0 domain.AnalogThreshold) *MockAnalogSensorService_GetThreshold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAnalogSensorService_GetThreshold_Call) RunAndReturn(run func() domain.AnalogThreshold) *MockAnalogSensorService_GetThreshold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryAnalogSensorLogsFromDataService provides a mock function with given fields: query
+func (_m *MockAnalogSensorService) QueryAnalogSensorLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.AnalogSensorData], error) {
+	ret := _m.Called(query)
+
+	var r0 domain.LogPage[domain.AnalogSensorData]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.LogQuery) (domain.LogPage[domain.AnalogSensorData], error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(domain.LogQuery) domain.LogPage[domain.AnalogSensorData]); ok {
+		r0 = rf(query)
+	} else {
+		r0 = ret.Get(0).(domain.LogPage[domain.AnalogSensorData])
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.LogQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAnalogSensorService_QueryAnalogSensorLogsFromDataService_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryAnalogSensorLogsFromDataService'
+type MockAnalogSensorService_QueryAnalogSensorLogsFromDataService_Call struct {
+	*mock.Call
+}
+
+// QueryAnalogSensorLogsFromDataService is a helper method to define mock.On call
+//   - query domain.LogQuery
+func (_e *MockAnalogSensorService_Expecter) QueryAnalogSensorLogsFromDataService(query interface{}) *MockAnalogSensorService_QueryAnalogSensorLogsFromDataService_Call {
+	return &MockAnalogSensorService_QueryAnalogSensorLogsFromDataService_Call{Call: _e.mock.On("QueryAnalogSensorLogsFromDataService", query)}
+}
+
+func (_c *MockAnalogSensorService_QueryAnalogSensorLogsFromDataService_Call) Run(run func(query domain.LogQuery)) *MockAnalogSensorService_QueryAnalogSensorLogsFromDataService_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.LogQuery))
+	})
+	return _c
+}
+
+func (_c *MockAnalogSensorService_QueryAnalogSensorLogsFromDataService_Call) Return(_a0 domain.LogPage[domain.AnalogSensorData], _a1 error) *MockAnalogSensorService_QueryAnalogSensorLogsFromDataService_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAnalogSensorService_QueryAnalogSensorLogsFromDataService_Call) RunAndReturn(run func(domain.LogQuery) (domain.LogPage[domain.AnalogSensorData], error)) *MockAnalogSensorService_QueryAnalogSensorLogsFromDataService_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadInfo provides a mock function with given fields: ctx
+func (_m *MockAnalogSensorService) ReadInfo(ctx context.Context) (domain.AnalogSensorInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 domain.AnalogSensorInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.AnalogSensorInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.AnalogSensorInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.AnalogSensorInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAnalogSensorService_ReadInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadInfo'
+type MockAnalogSensorService_ReadInfo_Call struct {
+	*mock.Call
+}
+
+// ReadInfo is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAnalogSensorService_Expecter) ReadInfo(ctx interface{}) *MockAnalogSensorService_ReadInfo_Call {
+	return &MockAnalogSensorService_ReadInfo_Call{Call: _e.mock.On("ReadInfo", ctx)}
+}
+
+func (_c *MockAnalogSensorService_ReadInfo_Call) Run(run func(ctx context.Context)) *MockAnalogSensorService_ReadInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAnalogSensorService_ReadInfo_Call) Return(_a0 domain.AnalogSensorInfo, _a1 error) *MockAnalogSensorService_ReadInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAnalogSensorService_ReadInfo_Call) RunAndReturn(run func(context.Context) (domain.AnalogSensorInfo, error)) *MockAnalogSensorService_ReadInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ToggleEnabled provides a mock function with given fields: ctx
+func (_m *MockAnalogSensorService) ToggleEnabled(ctx context.Context) (domain.AnalogSensorInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 domain.AnalogSensorInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.AnalogSensorInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.AnalogSensorInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.AnalogSensorInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAnalogSensorService_ToggleEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ToggleEnabled'
+type MockAnalogSensorService_ToggleEnabled_Call struct {
+	*mock.Call
+}
+
+// ToggleEnabled is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAnalogSensorService_Expecter) ToggleEnabled(ctx interface{}) *MockAnalogSensorService_ToggleEnabled_Call {
+	return &MockAnalogSensorService_ToggleEnabled_Call{Call: _e.mock.On("ToggleEnabled", ctx)}
+}
+
+func (_c *MockAnalogSensorService_ToggleEnabled_Call) Run(run func(ctx context.Context)) *MockAnalogSensorService_ToggleEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAnalogSensorService_ToggleEnabled_Call) Return(_a0 domain.AnalogSensorInfo, _a1 error) *MockAnalogSensorService_ToggleEnabled_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAnalogSensorService_ToggleEnabled_Call) RunAndReturn(run func(context.Context) (domain.AnalogSensorInfo, error)) *MockAnalogSensorService_ToggleEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockAnalogSensorService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockAnalogSensorService creates a new instance of MockAnalogSensorService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockAnalogSensorService(t mockConstructorTestingTNewMockAnalogSensorService) *MockAnalogSensorService {
+	mock := &MockAnalogSensorService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
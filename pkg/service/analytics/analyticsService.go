@@ -0,0 +1,290 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+const defaultAnalyticsRange = 7 * 24 * time.Hour
+
+// maxCachedHistories is how many device histories are cached, the least
+// recently used ones are evicted beyond it.
+const maxCachedHistories = 32
+
+//go:generate --name AnalyticsService --output mock_analyticsService.go
+type AnalyticsService interface {
+	GetAnalytics(metric domain.AnalyticsMetric, query domain.AnalyticsQuery) ([]domain.AnalyticsSeries, error)
+}
+
+// metric tells which devices a metric is computed for and how a segment of
+// their history counts towards it.
+type metric struct {
+	devices       func(s *analyticsService) []string
+	defaultBucket domain.AnalyticsBucket
+	unit          string
+	value         func(a *accumulator) float64
+	observed      func(state sampleState) bool
+	active        func(state sampleState) bool
+	countsOpening bool
+	setpoint      bool
+}
+
+var metrics = map[domain.AnalyticsMetric]metric{
+	domain.MetricPresence: {
+		devices:       func(s *analyticsService) []string { return s.config.PresenceSensors },
+		defaultBucket: domain.BucketHourOfDay,
+		unit:          "ratio",
+		value: func(a *accumulator) float64 {
+			if a.observed == 0 {
+				return 0
+			}
+			return a.active.Seconds() / a.observed.Seconds()
+		},
+		observed: func(state sampleState) bool { return state.enabled },
+		active:   func(state sampleState) bool { return state.enabled && state.detected },
+	},
+	domain.MetricDoorOpenings: {
+		devices:       func(s *analyticsService) []string { return s.config.DoorSensors },
+		defaultBucket: domain.BucketDay,
+		unit:          "count",
+		value:         func(a *accumulator) float64 { return float64(a.count) },
+		observed:      func(state sampleState) bool { return state.enabled },
+		active:        func(state sampleState) bool { return state.enabled && state.detected },
+		countsOpening: true,
+	},
+	domain.MetricOnTime: {
+		devices: func(s *analyticsService) []string {
+			return s.devicesOfKinds(domain.KindLight, domain.KindPlug)
+		},
+		defaultBucket: domain.BucketDay,
+		unit:          "seconds",
+		value:         func(a *accumulator) float64 { return a.active.Seconds() },
+		observed:      func(state sampleState) bool { return true },
+		active:        func(state sampleState) bool { return state.enabled },
+	},
+	domain.MetricACRuntime: {
+		devices:       func(s *analyticsService) []string { return s.devicesOfKinds(domain.KindAC) },
+		defaultBucket: domain.BucketDay,
+		unit:          "seconds",
+		value:         func(a *accumulator) float64 { return a.active.Seconds() },
+		observed:      func(state sampleState) bool { return true },
+		active:        func(state sampleState) bool { return state.enabled },
+		setpoint:      true,
+	},
+}
+
+type analyticsService struct {
+	config   domain.AnalyticsConfig
+	registry registry.Registry
+	now      func() time.Time
+
+	mu        sync.Mutex
+	histories map[string]*history
+	uses      int
+}
+
+func NewAnalyticsService(config domain.AnalyticsConfig, registry registry.Registry) AnalyticsService {
+	if config.Location == nil {
+		config.Location = time.Local
+	}
+	return &analyticsService{config: config, registry: registry, now: time.Now, histories: map[string]*history{}}
+}
+
+// GetAnalytics computes the metric for each of its devices. The history of a
+// device is cached from the earliest requested time on; a request only
+// fetches the logs logged since the previous one, and the older logs when it
+// reaches further back. A series is marked as truncated when the logs of the
+// device could not be searched as far back as requested.
+func (s *analyticsService) GetAnalytics(metricName domain.AnalyticsMetric,
+	query domain.AnalyticsQuery) ([]domain.AnalyticsSeries, error) {
+	m, ok := metrics[metricName]
+	if !ok {
+		return nil, fmt.Errorf("Unknown analytics metric: %s", metricName)
+	}
+	from, to, bucket, err := s.resolve(m, query)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := m.devices(s)
+	series := make([]domain.AnalyticsSeries, 0, len(devices))
+	for _, device := range devices {
+		segments, scannedFrom, err := s.segments(device, from)
+		if err != nil {
+			return nil, err
+		}
+		deviceSeries := s.compute(m, metricName, device, segments, bucket, from, to)
+		deviceSeries.Truncated = scannedFrom != nil
+		deviceSeries.ScannedFrom = scannedFrom
+		series = append(series, deviceSeries)
+	}
+	return series, nil
+}
+
+func (s *analyticsService) resolve(m metric, query domain.AnalyticsQuery) (time.Time, time.Time,
+	domain.AnalyticsBucket, error) {
+	to := s.now()
+	if query.To != nil {
+		to = *query.To
+	}
+	from := to.Add(-defaultAnalyticsRange)
+	if query.From != nil {
+		from = *query.From
+	}
+	bucket := query.Bucket
+	if bucket == "" {
+		bucket = m.defaultBucket
+	}
+
+	validationErr := &controlStationUtils.ValidationError{}
+	if _, err := domain.ParseAnalyticsBucket(string(bucket)); err != nil {
+		validationErr.Add("bucket", err.Error())
+	}
+	if !from.Before(to) {
+		validationErr.Add("to", "must be after from")
+	} else if s.config.MaxRange > 0 && to.Sub(from) > s.config.MaxRange {
+		validationErr.Add("from", fmt.Sprintf("must be at most %s before to", s.config.MaxRange))
+	}
+	return from, to, bucket, validationErr.ErrorOrNil()
+}
+
+// segments brings the cached history of the device up to date and returns it,
+// together with the time it starts at when it is truncated after from.
+func (s *analyticsService) segments(device string, from time.Time) ([]segment, *time.Time, error) {
+	h := s.history(device, from)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if from.Before(h.from) && !h.truncated {
+		older := &history{from: from}
+		scannedFrom, err := fetchSamples(older, device, from, &h.from, s.config.MaxSampleGap)
+		var validationErr *controlStationUtils.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			// The query was valid, so it was refused because the logs before the
+			// history are older than any that can be searched.
+			older.truncated = true
+		case err != nil:
+			return nil, nil, err
+		case scannedFrom != nil:
+			older.cover(*scannedFrom)
+		}
+		h.prepend(older, s.config.MaxSampleGap)
+	}
+
+	since := h.from
+	if h.last != nil {
+		since = h.last.CreatedAt
+	}
+	scannedFrom, err := fetchSamples(h, device, since, nil, s.config.MaxSampleGap)
+	if err != nil {
+		return nil, nil, err
+	}
+	if scannedFrom != nil && scannedFrom.After(since) {
+		// The logs since the previous request were not all searched, so the
+		// history before them is no longer contiguous.
+		h.cover(*scannedFrom)
+	}
+
+	segments := h.until(s.now(), s.config.MaxSampleGap)
+	if h.truncated && from.Before(h.from) {
+		historyFrom := h.from
+		return segments, &historyFrom, nil
+	}
+	return segments, nil, nil
+}
+
+// history returns the cached history of the device, evicting the least
+// recently used one when a new history does not fit in the cache.
+func (s *analyticsService) history(device string, from time.Time) *history {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.histories[device]
+	if !ok {
+		for len(s.histories) >= maxCachedHistories {
+			var lru string
+			for name, cached := range s.histories {
+				if lru == "" || cached.used < s.histories[lru].used {
+					lru = name
+				}
+			}
+			delete(s.histories, lru)
+		}
+		h = &history{from: from}
+		s.histories[device] = h
+	}
+	s.uses++
+	h.used = s.uses
+	return h
+}
+
+func (s *analyticsService) compute(m metric, metricName domain.AnalyticsMetric, device string, segments []segment,
+	bucket domain.AnalyticsBucket, from time.Time, to time.Time) domain.AnalyticsSeries {
+	b := bucketer{bucket: bucket, location: s.config.Location}
+	keys, accumulators := b.points(from, to)
+
+	for i, seg := range segments {
+		if m.countsOpening && i > 0 && opened(segments[i-1], seg) && !seg.start.Before(from) &&
+			seg.start.Before(to) {
+			accumulators[b.key(b.start(seg.start))].count++
+		}
+
+		start, end := seg.start, seg.end
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !m.observed(seg.state) {
+			continue
+		}
+		b.split(start, end, func(key int64, d time.Duration) {
+			a := accumulators[key]
+			a.observed += d
+			if m.active(seg.state) {
+				a.active += d
+				a.setpointSeconds += seg.state.setpoint * d.Seconds()
+			}
+		})
+	}
+
+	points := make([]domain.AnalyticsPoint, 0, len(keys))
+	for _, key := range keys {
+		a := accumulators[key]
+		a.point.Value = m.value(a)
+		a.point.ObservedSeconds = a.observed.Seconds()
+		if m.setpoint && a.active > 0 {
+			average := a.setpointSeconds / a.active.Seconds()
+			a.point.AverageSetpoint = &average
+		}
+		points = append(points, a.point)
+	}
+	return domain.AnalyticsSeries{Metric: metricName, Device: device, Bucket: bucket, Unit: m.unit, From: from, To: to,
+		Points: points}
+}
+
+// opened reports whether a door went from closed to open between the segments.
+func opened(previous segment, current segment) bool {
+	return previous.end.Equal(current.start) && previous.state.enabled && !previous.state.detected &&
+		current.state.enabled && current.state.detected
+}
+
+func (s *analyticsService) devicesOfKinds(kinds ...domain.DeviceKind) []string {
+	var devices []string
+	for _, device := range s.registry.List() {
+		for _, kind := range kinds {
+			if device.Kind == kind {
+				devices = append(devices, device.Name)
+			}
+		}
+	}
+	return devices
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2023, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+// fakeDataService serves the latest logs of the devices up to the limit from a
+// data service without the query endpoint.
+type fakeDataService struct {
+	mu   sync.Mutex
+	logs map[string][]string
+}
+
+func (d *fakeDataService) add(device string, log string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.logs[device] = append(d.logs[device], fmt.Sprintf(`{"id": %d, %s}`, len(d.logs[device])+1, log))
+}
+
+func (d *fakeDataService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	device, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/"), "/latest")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil {
+		limit = len(d.logs[device])
+	}
+	latest := make([]string, 0, len(d.logs[device]))
+	for i := len(d.logs[device]) - 1; i >= 0 && len(latest) < limit; i-- {
+		latest = append(latest, d.logs[device][i])
+	}
+	fmt.Fprintf(w, "[%s]", strings.Join(latest, ","))
+}
+
+func newTestService(t *testing.T) (*analyticsService, *fakeDataService) {
+	dataService := &fakeDataService{logs: map[string][]string{}}
+	ts := httptest.NewServer(dataService)
+	t.Cleanup(ts.Close)
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	deviceRegistry := registry.NewRegistry()
+	deviceRegistry.Register(domain.RegisteredDevice{Name: "presenceSensor", Kind: domain.KindSensor})
+	deviceRegistry.Register(domain.RegisteredDevice{Name: "smartBulb", Kind: domain.KindLight})
+	deviceRegistry.Register(domain.RegisteredDevice{Name: "ac", Kind: domain.KindAC})
+	config := domain.AnalyticsConfig{PresenceSensors: []string{"presenceSensor"}, DoorSensors: []string{"presenceSensor"},
+		MaxSampleGap: time.Hour, MaxRange: 48 * time.Hour, Location: time.UTC}
+	service := NewAnalyticsService(config, deviceRegistry).(*analyticsService)
+	service.now = func() time.Time { return testNow }
+	return service, dataService
+}
+
+func addPresenceLogs(dataService *fakeDataService) {
+	dataService.add("presenceSensor", `"created_at": "2023-01-01T08:00:00Z", "is_enabled": true, "detected": false`)
+	dataService.add("presenceSensor", `"created_at": "2023-01-01T09:00:00Z", "is_enabled": true, "detected": true`)
+	dataService.add("presenceSensor", `"created_at": "2023-01-01T09:30:00Z", "is_enabled": true, "detected": false`)
+	dataService.add("presenceSensor", `"created_at": "2023-01-01T10:00:00Z", "is_enabled": true, "detected": false`)
+}
+
+func TestGetAnalytics_Presence(t *testing.T) {
+	service, dataService := newTestService(t)
+	addPresenceLogs(dataService)
+
+	series, err := service.GetAnalytics(domain.MetricPresence, domain.AnalyticsQuery{From: timePtr(at(8, 0))})
+
+	assert.NoError(t, err)
+	assert.Len(t, series, 1)
+	assert.Equal(t, domain.BucketHourOfDay, series[0].Bucket)
+	assert.Len(t, series[0].Points, 24)
+	hours := series[0].Points[8:12]
+	assert.Equal(t, []float64{0, 0.5, 0, 0}, []float64{hours[0].Value, hours[1].Value, hours[2].Value, hours[3].Value})
+	assert.Equal(t, []float64{3600, 3600, 3600, 0}, []float64{hours[0].ObservedSeconds, hours[1].ObservedSeconds,
+		hours[2].ObservedSeconds, hours[3].ObservedSeconds})
+}
+
+func TestGetAnalytics_DoorOpenings(t *testing.T) {
+	service, dataService := newTestService(t)
+	addPresenceLogs(dataService)
+
+	series, err := service.GetAnalytics(domain.MetricDoorOpenings, domain.AnalyticsQuery{Bucket: domain.BucketHour,
+		From: timePtr(at(8, 0)), To: timePtr(at(11, 0))})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AnalyticsPoint{
+		{Start: timePtr(at(8, 0)), Value: 0, ObservedSeconds: 3600},
+		{Start: timePtr(at(9, 0)), Value: 1, ObservedSeconds: 3600},
+		{Start: timePtr(at(10, 0)), Value: 0, ObservedSeconds: 3600},
+	}, series[0].Points)
+}
+
+func TestGetAnalytics_OnTimeAndACRuntime(t *testing.T) {
+	service, dataService := newTestService(t)
+	dataService.add("smartBulb", `"created_at": "2023-01-01T08:00:00Z", "is_enabled": true`)
+	dataService.add("smartBulb", `"created_at": "2023-01-01T08:30:00Z", "is_enabled": false`)
+	dataService.add("ac", `"created_at": "2023-01-01T08:00:00Z", "is_enabled": true, "temperature": 22`)
+	dataService.add("ac", `"created_at": "2023-01-01T09:00:00Z", "is_enabled": true, "temperature": 24`)
+	dataService.add("ac", `"created_at": "2023-01-01T10:00:00Z", "is_enabled": false, "temperature": 24`)
+	query := domain.AnalyticsQuery{From: timePtr(at(0, 0)), To: timePtr(at(0, 0).AddDate(0, 0, 1))}
+
+	onTime, err := service.GetAnalytics(domain.MetricOnTime, query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AnalyticsSeries{{Metric: domain.MetricOnTime, Device: "smartBulb", Bucket: domain.BucketDay,
+		Unit: "seconds", From: *query.From, To: *query.To,
+		Points: []domain.AnalyticsPoint{{Start: query.From, Value: 1800, ObservedSeconds: 5400}}}}, onTime)
+
+	acRuntime, err := service.GetAnalytics(domain.MetricACRuntime, query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AnalyticsPoint{{Start: query.From, Value: 7200, ObservedSeconds: 10800,
+		AverageSetpoint: floatPtr(23)}}, acRuntime[0].Points)
+}
+
+func TestGetAnalytics_UpdatesCachedHistory(t *testing.T) {
+	service, dataService := newTestService(t)
+	addPresenceLogs(dataService)
+	query := domain.AnalyticsQuery{Bucket: domain.BucketDay, From: timePtr(at(9, 0)), To: timePtr(at(12, 0))}
+
+	series, err := service.GetAnalytics(domain.MetricDoorOpenings, query)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), series[0].Points[0].Value)
+
+	dataService.add("presenceSensor", `"created_at": "2023-01-01T10:30:00Z", "is_enabled": true, "detected": true`)
+	series, err = service.GetAnalytics(domain.MetricDoorOpenings, query)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), series[0].Points[0].Value)
+
+	// Reaching back to 8:00 fetches the older logs, after which the opening at
+	// 9:00 is known to follow a closed door.
+	query.From = timePtr(at(8, 0))
+	series, err = service.GetAnalytics(domain.MetricDoorOpenings, query)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), series[0].Points[0].Value)
+	assert.Equal(t, 3.5*3600, series[0].Points[0].ObservedSeconds)
+	assert.Equal(t, at(8, 0), service.histories["presenceSensor"].from)
+}
+
+func TestGetAnalytics_InvalidQuery(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, err := service.GetAnalytics(domain.MetricPresence, domain.AnalyticsQuery{Bucket: "month",
+		From: timePtr(at(10, 0)), To: timePtr(at(9, 0))})
+
+	var validationErr *controlStationUtils.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []controlStationUtils.FieldError{
+		{Field: "bucket", Message: "Unknown analytics bucket: month"},
+		{Field: "to", Message: "must be after from"},
+	}, validationErr.Errors)
+
+	_, err = service.GetAnalytics(domain.MetricPresence, domain.AnalyticsQuery{From: timePtr(at(0, 0).AddDate(0, 0, -3))})
+
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "from", validationErr.Errors[0].Field)
+}
+
+func TestGetAnalytics_Truncated(t *testing.T) {
+	service, dataService := newTestService(t)
+	addPresenceLogs(dataService)
+	controlStationUtils.SetLogQueryLimits(controlStationUtils.DefaultMaxLogQueryLimit, 2)
+	t.Cleanup(func() {
+		controlStationUtils.SetLogQueryLimits(controlStationUtils.DefaultMaxLogQueryLimit,
+			controlStationUtils.DefaultLogQueryScanLimit)
+	})
+	query := domain.AnalyticsQuery{Bucket: domain.BucketHour, From: timePtr(at(8, 0)), To: timePtr(at(11, 0))}
+
+	series, err := service.GetAnalytics(domain.MetricPresence, query)
+
+	assert.NoError(t, err)
+	assert.True(t, series[0].Truncated)
+	assert.Equal(t, timePtr(at(9, 30)), series[0].ScannedFrom)
+	assert.Equal(t, []float64{0, 1800, 3600}, []float64{series[0].Points[0].ObservedSeconds,
+		series[0].Points[1].ObservedSeconds, series[0].Points[2].ObservedSeconds})
+
+	// The history can not reach further back, so the older logs are not asked
+	// for again.
+	query.From = timePtr(at(7, 0))
+	series, err = service.GetAnalytics(domain.MetricPresence, query)
+	assert.NoError(t, err)
+	assert.Equal(t, timePtr(at(9, 30)), series[0].ScannedFrom)
+}
+
+func TestGetAnalytics_KeepsHistoryStartWithoutOlderLogs(t *testing.T) {
+	service, dataService := newTestService(t)
+	addPresenceLogs(dataService)
+	query := domain.AnalyticsQuery{From: timePtr(at(8, 0)), To: timePtr(at(12, 0))}
+	_, err := service.GetAnalytics(domain.MetricPresence, query)
+	assert.NoError(t, err)
+
+	query.From = timePtr(at(6, 0))
+	series, err := service.GetAnalytics(domain.MetricPresence, query)
+
+	assert.NoError(t, err)
+	assert.False(t, series[0].Truncated)
+	assert.Equal(t, at(8, 0), service.histories["presenceSensor"].from)
+}
+
+func TestHistory_EvictsLeastRecentlyUsed(t *testing.T) {
+	service, _ := newTestService(t)
+	for i := 0; i < maxCachedHistories; i++ {
+		service.history(fmt.Sprintf("device%d", i), testNow)
+	}
+	service.history("device0", testNow)
+
+	service.history("newDevice", testNow)
+
+	assert.Len(t, service.histories, maxCachedHistories)
+	assert.Contains(t, service.histories, "device0")
+	assert.NotContains(t, service.histories, "device1")
+	assert.Contains(t, service.histories, "newDevice")
+}
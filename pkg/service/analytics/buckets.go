@@ -0,0 +1,89 @@
+package service
+
+import (
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+)
+
+// bucketer splits time into the buckets of the analytics. Hours of the day are
+// split like hours and added up by their local hour.
+type bucketer struct {
+	bucket   domain.AnalyticsBucket
+	location *time.Location
+}
+
+// start returns the start of the bucket t falls into.
+func (b bucketer) start(t time.Time) time.Time {
+	t = t.In(b.location)
+	switch b.bucket {
+	case domain.BucketDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, b.location)
+	case domain.BucketWeek:
+		monday := t.AddDate(0, 0, -(int(t.Weekday())+6)%7)
+		return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, b.location)
+	}
+	return t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second -
+		time.Duration(t.Nanosecond()))
+}
+
+func (b bucketer) next(start time.Time) time.Time {
+	switch b.bucket {
+	case domain.BucketDay:
+		return start.AddDate(0, 0, 1)
+	case domain.BucketWeek:
+		return start.AddDate(0, 0, 7)
+	}
+	return start.Add(time.Hour)
+}
+
+// key identifies the bucket starting at start among the points of a series.
+func (b bucketer) key(start time.Time) int64 {
+	if b.bucket == domain.BucketHourOfDay {
+		return int64(start.In(b.location).Hour())
+	}
+	return start.Unix()
+}
+
+// points returns the empty points of all buckets in the range, keyed by
+// bucketer.key.
+func (b bucketer) points(from time.Time, to time.Time) ([]int64, map[int64]*accumulator) {
+	var keys []int64
+	accumulators := map[int64]*accumulator{}
+	if b.bucket == domain.BucketHourOfDay {
+		for hour := 0; hour < 24; hour++ {
+			hourOfDay := hour
+			keys = append(keys, int64(hour))
+			accumulators[int64(hour)] = &accumulator{point: domain.AnalyticsPoint{HourOfDay: &hourOfDay}}
+		}
+		return keys, accumulators
+	}
+
+	for start := b.start(from); start.Before(to); start = b.next(start) {
+		bucketStart := start
+		keys = append(keys, b.key(start))
+		accumulators[b.key(start)] = &accumulator{point: domain.AnalyticsPoint{Start: &bucketStart}}
+	}
+	return keys, accumulators
+}
+
+// split calls add for every part of the range from start to end that falls
+// into a single bucket.
+func (b bucketer) split(start time.Time, end time.Time, add func(key int64, d time.Duration)) {
+	for t := start; t.Before(end); {
+		next := b.next(b.start(t))
+		if end.Before(next) {
+			next = end
+		}
+		add(b.key(b.start(t)), next.Sub(t))
+		t = next
+	}
+}
+
+type accumulator struct {
+	point           domain.AnalyticsPoint
+	observed        time.Duration
+	active          time.Duration
+	setpointSeconds float64
+	count           int
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/pklimuk-eng-thesis/control-station/utils"
+)
+
+// sample is a device log reduced to the fields the analytics look at.
+type sample struct {
+	ID          int       `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	IsEnabled   bool      `json:"is_enabled"`
+	Detected    bool      `json:"detected"`
+	Temperature float64   `json:"temperature"`
+}
+
+type sampleState struct {
+	enabled  bool
+	detected bool
+	setpoint float64
+}
+
+func (s sample) state() sampleState {
+	return sampleState{enabled: s.IsEnabled, detected: s.Detected, setpoint: s.Temperature}
+}
+
+// segment is a stretch of time in which the device kept the same state.
+// Segments are contiguous unless the logs were further apart than the maximum
+// sample gap.
+type segment struct {
+	start time.Time
+	end   time.Time
+	state sampleState
+}
+
+// history is the cached history of a device since from, compressed into
+// segments, so that only the logs after the last sample are fetched again. It
+// is truncated when older logs exist that could not be searched, so it can not
+// reach further back.
+type history struct {
+	mu        sync.Mutex
+	from      time.Time
+	segments  []segment
+	last      *sample
+	truncated bool
+	// used orders the cached histories by their last use, for eviction.
+	used int
+}
+
+// add extends the history by a sample later than the last one. The state of
+// the last sample is taken to hold until this one, at most for maxGap.
+func (h *history) add(s sample, maxGap time.Duration) {
+	if h.last != nil {
+		held := h.last.CreatedAt.Add(maxGap)
+		if s.CreatedAt.Before(held) {
+			held = s.CreatedAt
+		}
+		current := &h.segments[len(h.segments)-1]
+		current.end = held
+		if held.Equal(s.CreatedAt) && current.state == s.state() {
+			h.last = &s
+			return
+		}
+	}
+	h.segments = append(h.segments, segment{start: s.CreatedAt, end: s.CreatedAt, state: s.state()})
+	h.last = &s
+}
+
+// prepend puts an older history, which ends where this one starts, in front of
+// this one. The history only reaches back further when the older one has any
+// samples.
+func (h *history) prepend(older *history, maxGap time.Duration) {
+	h.truncated = h.truncated || older.truncated
+	if older.last == nil {
+		return
+	}
+
+	segments := older.segments
+	newer := h.segments
+	if older.last != nil && len(newer) > 0 {
+		tail := &segments[len(segments)-1]
+		tail.end = older.last.CreatedAt.Add(maxGap)
+		if newer[0].start.Before(tail.end) {
+			tail.end = newer[0].start
+		}
+		if tail.end.Equal(newer[0].start) && tail.state == newer[0].state {
+			tail.end = newer[0].end
+			newer = newer[1:]
+		}
+	}
+	h.segments = append(segments, newer...)
+	if h.last == nil {
+		h.last = older.last
+	}
+	h.from = older.from
+}
+
+// cover limits the history to the time since scannedFrom, when only the logs
+// since then could be searched.
+func (h *history) cover(scannedFrom time.Time) {
+	h.truncated = true
+	if !scannedFrom.After(h.from) {
+		return
+	}
+	h.from = scannedFrom
+	segments := h.segments[:0]
+	for _, seg := range h.segments {
+		if seg.end.Before(scannedFrom) {
+			continue
+		}
+		if seg.start.Before(scannedFrom) {
+			seg.start = scannedFrom
+		}
+		segments = append(segments, seg)
+	}
+	h.segments = segments
+}
+
+// until returns the segments with the last one held until now, at most for
+// maxGap.
+func (h *history) until(now time.Time, maxGap time.Duration) []segment {
+	segments := make([]segment, len(h.segments))
+	copy(segments, h.segments)
+	if h.last != nil {
+		end := h.last.CreatedAt.Add(maxGap)
+		if now.Before(end) {
+			end = now
+		}
+		if end.After(segments[len(segments)-1].end) {
+			segments[len(segments)-1].end = end
+		}
+	}
+	return segments
+}
+
+// fetchSamples fetches the logs of the device from the data service, oldest
+// first and page by page, and adds the ones after the last sample of the
+// history to it. When the logs could only be searched since some time after
+// from, that time is returned.
+func fetchSamples(h *history, deviceName string, from time.Time, to *time.Time,
+	maxGap time.Duration) (*time.Time, error) {
+	var scannedFrom *time.Time
+	query := domain.LogQuery{From: &from, To: to, Order: domain.SortAsc, Limit: controlStationUtils.MaxLogQueryLimit()}
+	for {
+		page, err := controlStationUtils.QueryLogsFromDataService[json.RawMessage](deviceName, query)
+		if err != nil {
+			return nil, err
+		}
+		if page.Truncated && page.ScannedFrom != nil {
+			scannedFrom = page.ScannedFrom
+		}
+		for _, raw := range page.Logs {
+			var s sample
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return nil, fmt.Errorf("%w: %s: %s", utils.ErrParsingFailed, deviceName, err)
+			}
+			if h.last != nil && !after(s, *h.last) {
+				continue
+			}
+			h.add(s, maxGap)
+		}
+
+		if page.NextCursor == "" {
+			return scannedFrom, nil
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+func after(a sample, b sample) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAnalyticsService is an autogenerated mock type for the AnalyticsService type
+type MockAnalyticsService struct {
+	mock.Mock
+}
+
+type MockAnalyticsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAnalyticsService) EXPECT() *MockAnalyticsService_Expecter {
+	return &MockAnalyticsService_Expecter{mock: &_m.Mock}
+}
+
+// GetAnalytics provides a mock function with given fields: metric, query
+func (_m *MockAnalyticsService) GetAnalytics(metric domain.AnalyticsMetric, query domain.AnalyticsQuery) ([]domain.AnalyticsSeries, error) {
+	ret := _m.Called(metric, query)
+
+	var r0 []domain.AnalyticsSeries
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.AnalyticsMetric, domain.AnalyticsQuery) ([]domain.AnalyticsSeries, error)); ok {
+		return rf(metric, query)
+	}
+	if rf, ok := ret.Get(0).(func(domain.AnalyticsMetric, domain.AnalyticsQuery) []domain.AnalyticsSeries); ok {
+		r0 = rf(metric, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AnalyticsSeries)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.AnalyticsMetric, domain.AnalyticsQuery) error); ok {
+		r1 = rf(metric, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAnalyticsService_GetAnalytics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAnalytics'
+type MockAnalyticsService_GetAnalytics_Call struct {
+	*mock.Call
+}
+
+// GetAnalytics is a helper method to define mock.On call
+//   - metric domain.AnalyticsMetric
+//   - query domain.AnalyticsQuery
+func (_e *MockAnalyticsService_Expecter) GetAnalytics(metric interface{}, query interface{}) *MockAnalyticsService_GetAnalytics_Call {
+	return &MockAnalyticsService_GetAnalytics_Call{Call: _e.mock.On("GetAnalytics", metric, query)}
+}
+
+func (_c *MockAnalyticsService_GetAnalytics_Call) Run(run func(metric domain.AnalyticsMetric, query domain.AnalyticsQuery)) *MockAnalyticsService_GetAnalytics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.AnalyticsMetric), args[1].(domain.AnalyticsQuery))
+	})
+	return _c
+}
+
+func (_c *MockAnalyticsService_GetAnalytics_Call) Return(_a0 []domain.AnalyticsSeries, _a1 error) *MockAnalyticsService_GetAnalytics_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAnalyticsService_GetAnalytics_Call) RunAndReturn(run func(domain.AnalyticsMetric, domain.AnalyticsQuery) ([]domain.AnalyticsSeries, error)) *MockAnalyticsService_GetAnalytics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockAnalyticsService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockAnalyticsService creates a new instance of MockAnalyticsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockAnalyticsService(t mockConstructorTestingTNewMockAnalyticsService) *MockAnalyticsService {
+	mock := &MockAnalyticsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+//go:generate --name ArbitrationService --output mock_arbitrationService.go
+type ArbitrationService interface {
+	ListControls() []domain.DeviceControl
+	GetControl(device string) (domain.DeviceControl, error)
+	Authorize(device string, source domain.CommandSource) error
+	Command(device string, source domain.CommandSource) error
+	Override(device string, window time.Duration) (domain.DeviceControl, error)
+	Release(device string) (domain.DeviceControl, error)
+}
+
+type arbitrationService struct {
+	registry       registry.Registry
+	overrideWindow time.Duration
+	now            func() time.Time
+
+	mu       sync.Mutex
+	controls map[string]domain.DeviceControl
+}
+
+// NewArbitrationService returns the service that decides whose commands reach
+// a device. A user command overrides the automation for overrideWindow, safety
+// commands are never refused and end the override.
+func NewArbitrationService(registry registry.Registry, overrideWindow time.Duration) ArbitrationService {
+	return &arbitrationService{registry: registry, overrideWindow: overrideWindow, now: time.Now,
+		controls: map[string]domain.DeviceControl{}}
+}
+
+// ListControls returns the control of every registered device, including those
+// that were not commanded yet.
+func (s *arbitrationService) ListControls() []domain.DeviceControl {
+	devices := s.registry.List()
+	controls := make([]domain.DeviceControl, 0, len(devices))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, device := range devices {
+		controls = append(controls, s.control(device.Name))
+	}
+	return controls
+}
+
+func (s *arbitrationService) GetControl(device string) (domain.DeviceControl, error) {
+	if _, err := s.registry.Get(device); err != nil {
+		return domain.DeviceControl{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.control(device), nil
+}
+
+// Authorize refuses an automated command while a user override is active. It
+// is checked before the command is sent, which is recorded with Command only
+// once it succeeded.
+func (s *arbitrationService) Authorize(device string, source domain.CommandSource) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.authorize(device, source)
+}
+
+// Command records the source of a successful command as the controlling one.
+// An automated command is refused and not recorded if a user override started
+// while it was being sent.
+func (s *arbitrationService) Command(device string, source domain.CommandSource) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.authorize(device, source); err != nil {
+		return err
+	}
+
+	current := s.control(device)
+	now := s.now().UTC()
+	control := domain.DeviceControl{Device: device, Source: source, Since: &now}
+	if source == domain.SourceUser {
+		until := now.Add(s.overrideWindow)
+		control.OverrideUntil = &until
+		if current.OverrideUntil == nil {
+			log.Printf("Manual override of '%s' until %s\n", device, until.Format(time.RFC3339))
+		}
+	} else if source == domain.SourceSafety && current.OverrideUntil != nil {
+		log.Printf("Manual override of '%s' ended by a safety command\n", device)
+	}
+	s.controls[device] = control
+	return nil
+}
+
+// Override keeps automation off the device for the window without commanding
+// it, e.g. for every device of a room. A zero window releases the device.
+func (s *arbitrationService) Override(device string, window time.Duration) (domain.DeviceControl, error) {
+	if window == 0 {
+		return s.Release(device)
+	}
+	if _, err := s.registry.Get(device); err != nil {
+		return domain.DeviceControl{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	control := s.control(device)
+	until := s.now().UTC().Add(window)
+	control.OverrideUntil = &until
+	s.controls[device] = control
+	log.Printf("Manual override of '%s' until %s\n", device, until.Format(time.RFC3339))
+	return control, nil
+}
+
+// Release ends the user override of the device, handing it back to automation.
+func (s *arbitrationService) Release(device string) (domain.DeviceControl, error) {
+	if _, err := s.registry.Get(device); err != nil {
+		return domain.DeviceControl{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	control := s.control(device)
+	if control.OverrideUntil != nil {
+		control.OverrideUntil = nil
+		s.controls[device] = control
+		log.Printf("Manual override of '%s' released\n", device)
+	}
+	return control, nil
+}
+
+// authorize must be called with the mutex held.
+func (s *arbitrationService) authorize(device string, source domain.CommandSource) error {
+	current := s.control(device)
+	if source.Automated() && current.OverrideUntil != nil {
+		return fmt.Errorf("%w: %s until %s", controlStationUtils.ErrOverridden, device,
+			current.OverrideUntil.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// control returns the control of the device with an expired override cleared.
+// It must be called with the mutex held.
+func (s *arbitrationService) control(device string) domain.DeviceControl {
+	control, ok := s.controls[device]
+	if !ok {
+		return domain.DeviceControl{Device: device}
+	}
+	if control.OverrideUntil != nil && !s.now().Before(*control.OverrideUntil) {
+		control.OverrideUntil = nil
+		s.controls[device] = control
+	}
+	return control
+}
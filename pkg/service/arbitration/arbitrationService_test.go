@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	lightService "github.com/pklimuk-eng-thesis/control-station/pkg/service/light"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestService() *arbitrationService {
+	deviceRegistry := registry.NewRegistry()
+	deviceRegistry.Register(domain.RegisteredDevice{Name: "smartBulb", Kind: domain.KindLight, Route: "/smartBulb"})
+	deviceRegistry.Register(domain.RegisteredDevice{Name: "smartPlug", Kind: domain.KindPlug, Route: "/smartPlug"})
+	service := NewArbitrationService(deviceRegistry, time.Hour).(*arbitrationService)
+	service.now = func() time.Time { return testNow }
+	return service
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestCommand_UserOverridesAutomation(t *testing.T) {
+	service := newTestService()
+
+	assert.NoError(t, service.Command("smartBulb", domain.SourceRule))
+	assert.NoError(t, service.Command("smartBulb", domain.SourceUser))
+	assert.ErrorIs(t, service.Command("smartBulb", domain.SourceRule), controlStationUtils.ErrOverridden)
+	assert.ErrorIs(t, service.Command("smartBulb", domain.SourceSchedule), controlStationUtils.ErrOverridden)
+	assert.NoError(t, service.Command("smartPlug", domain.SourceSchedule))
+
+	control, err := service.GetControl("smartBulb")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeviceControl{Device: "smartBulb", Source: domain.SourceUser, Since: timePtr(testNow),
+		OverrideUntil: timePtr(testNow.Add(time.Hour))}, control)
+}
+
+func TestCommand_OverrideExpires(t *testing.T) {
+	service := newTestService()
+	service.Command("smartBulb", domain.SourceUser)
+
+	service.now = func() time.Time { return testNow.Add(time.Hour) }
+
+	assert.NoError(t, service.Command("smartBulb", domain.SourceRule))
+	control, _ := service.GetControl("smartBulb")
+	assert.Equal(t, domain.SourceRule, control.Source)
+	assert.Nil(t, control.OverrideUntil)
+}
+
+func TestCommand_SafetyAlwaysWins(t *testing.T) {
+	service := newTestService()
+	service.Command("smartPlug", domain.SourceUser)
+
+	assert.NoError(t, service.Command("smartPlug", domain.SourceSafety))
+
+	control, _ := service.GetControl("smartPlug")
+	assert.Equal(t, domain.SourceSafety, control.Source)
+	assert.Nil(t, control.OverrideUntil)
+	assert.NoError(t, service.Command("smartPlug", domain.SourceRule))
+}
+
+func TestRelease(t *testing.T) {
+	service := newTestService()
+	service.Command("smartBulb", domain.SourceUser)
+
+	control, err := service.Release("smartBulb")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SourceUser, control.Source)
+	assert.Nil(t, control.OverrideUntil)
+	assert.NoError(t, service.Command("smartBulb", domain.SourceRule))
+
+	_, err = service.Release("unknown")
+	assert.ErrorIs(t, err, registry.ErrDeviceNotRegistered)
+}
+
+func TestListControls(t *testing.T) {
+	service := newTestService()
+	service.Command("smartPlug", domain.SourceSchedule)
+
+	assert.Equal(t, []domain.DeviceControl{
+		{Device: "smartBulb"},
+		{Device: "smartPlug", Source: domain.SourceSchedule, Since: timePtr(testNow)},
+	}, service.ListControls())
+}
+
+func TestSwitches(t *testing.T) {
+	service := newTestService()
+	service.Command("smartBulb", domain.SourceUser)
+	toggles := 0
+	switches := Switches(map[string]groupService.Switch{"smartBulb": {
+		ToggleEnabled: func(ctx context.Context) (domain.Switchable, error) {
+			toggles++
+			return domain.LightInfo{Enabled: true}, nil
+		},
+	}}, service, domain.SourceRule)
+
+	_, err := switches["smartBulb"].ToggleEnabled(context.Background())
+
+	assert.ErrorIs(t, err, controlStationUtils.ErrOverridden)
+	assert.Equal(t, 0, toggles)
+}
+
+func TestSwitches_RecordsOnlySucceededCommands(t *testing.T) {
+	service := newTestService()
+	var toggleErr error
+	switches := Switches(map[string]groupService.Switch{"smartBulb": {
+		ToggleEnabled: func(ctx context.Context) (domain.Switchable, error) {
+			return domain.LightInfo{Enabled: true}, toggleErr
+		},
+	}}, service, domain.SourceUser)
+
+	toggleErr = errors.New("unreachable")
+	_, err := switches["smartBulb"].ToggleEnabled(context.Background())
+	assert.Error(t, err)
+	control, _ := service.GetControl("smartBulb")
+	assert.Equal(t, domain.DeviceControl{Device: "smartBulb"}, control)
+
+	toggleErr = nil
+	_, err = switches["smartBulb"].ToggleEnabled(context.Background())
+	assert.NoError(t, err)
+	control, _ = service.GetControl("smartBulb")
+	assert.Equal(t, domain.SourceUser, control.Source)
+	assert.NotNil(t, control.OverrideUntil)
+}
+
+func TestLight(t *testing.T) {
+	service := newTestService()
+	request := domain.LightBrightnessRequest{Brightness: 40}
+	light := new(lightService.MockLightService)
+	light.EXPECT().SetBrightness(request).Return(domain.LightInfo{Enabled: true, Brightness: &request.Brightness}, nil).Once()
+
+	_, err := Light(light, "smartBulb", service, domain.SourceUser).SetBrightness(request)
+	assert.NoError(t, err)
+	control, _ := service.GetControl("smartBulb")
+	assert.Equal(t, domain.SourceUser, control.Source)
+
+	_, err = Light(light, "smartBulb", service, domain.SourceRule).SetBrightness(request)
+	assert.ErrorIs(t, err, controlStationUtils.ErrOverridden)
+	light.AssertExpectations(t)
+}
+
+func TestOverride(t *testing.T) {
+	service := newTestService()
+	service.Command("smartBulb", domain.SourceRule)
+
+	control, err := service.Override("smartBulb", 2*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeviceControl{Device: "smartBulb", Source: domain.SourceRule, Since: timePtr(testNow),
+		OverrideUntil: timePtr(testNow.Add(2 * time.Hour))}, control)
+	assert.ErrorIs(t, service.Authorize("smartBulb", domain.SourceRule), controlStationUtils.ErrOverridden)
+
+	control, err = service.Override("smartBulb", 0)
+	assert.NoError(t, err)
+	assert.Nil(t, control.OverrideUntil)
+	assert.NoError(t, service.Authorize("smartBulb", domain.SourceRule))
+
+	_, err = service.Override("unknown", time.Hour)
+	assert.ErrorIs(t, err, registry.ErrDeviceNotRegistered)
+}
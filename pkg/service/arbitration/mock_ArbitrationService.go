@@ -0,0 +1,324 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	time "time"
+
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockArbitrationService is an autogenerated mock type for the ArbitrationService type
+type MockArbitrationService struct {
+	mock.Mock
+}
+
+type MockArbitrationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockArbitrationService) EXPECT() *MockArbitrationService_Expecter {
+	return &MockArbitrationService_Expecter{mock: &_m.Mock}
+}
+
+// Authorize provides a mock function with given fields: device, source
+func (_m *MockArbitrationService) Authorize(device string, source domain.CommandSource) error {
+	ret := _m.Called(device, source)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, domain.CommandSource) error); ok {
+		r0 = rf(device, source)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockArbitrationService_Authorize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authorize'
+type MockArbitrationService_Authorize_Call struct {
+	*mock.Call
+}
+
+// Authorize is a helper method to define mock.On call
+//   - device string
+//   - source domain.CommandSource
+func (_e *MockArbitrationService_Expecter) Authorize(device interface{}, source interface{}) *MockArbitrationService_Authorize_Call {
+	return &MockArbitrationService_Authorize_Call{Call: _e.mock.On("Authorize", device, source)}
+}
+
+func (_c *MockArbitrationService_Authorize_Call) Run(run func(device string, source domain.CommandSource)) *MockArbitrationService_Authorize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(domain.CommandSource))
+	})
+	return _c
+}
+
+func (_c *MockArbitrationService_Authorize_Call) Return(_a0 error) *MockArbitrationService_Authorize_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockArbitrationService_Authorize_Call) RunAndReturn(run func(string, domain.CommandSource) error) *MockArbitrationService_Authorize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Command provides a mock function with given fields: device, source
+func (_m *MockArbitrationService) Command(device string, source domain.CommandSource) error {
+	ret := _m.Called(device, source)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, domain.CommandSource) error); ok {
+		r0 = rf(device, source)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockArbitrationService_Command_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Command'
+type MockArbitrationService_Command_Call struct {
+	*mock.Call
+}
+
+// Command is a helper method to define mock.On call
+//   - device string
+//   - source domain.CommandSource
+func (_e *MockArbitrationService_Expecter) Command(device interface{}, source interface{}) *MockArbitrationService_Command_Call {
+	return &MockArbitrationService_Command_Call{Call: _e.mock.On("Command", device, source)}
+}
+
+func (_c *MockArbitrationService_Command_Call) Run(run func(device string, source domain.CommandSource)) *MockArbitrationService_Command_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(domain.CommandSource))
+	})
+	return _c
+}
+
+func (_c *MockArbitrationService_Command_Call) Return(_a0 error) *MockArbitrationService_Command_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockArbitrationService_Command_Call) RunAndReturn(run func(string, domain.CommandSource) error) *MockArbitrationService_Command_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetControl provides a mock function with given fields: device
+func (_m *MockArbitrationService) GetControl(device string) (domain.DeviceControl, error) {
+	ret := _m.Called(device)
+
+	var r0 domain.DeviceControl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (domain.DeviceControl, error)); ok {
+		return rf(device)
+	}
+	if rf, ok := ret.Get(0).(func(string) domain.DeviceControl); ok {
+		r0 = rf(device)
+	} else {
+		r0 = ret.Get(0).(domain.DeviceControl)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockArbitrationService_GetControl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetControl'
+type MockArbitrationService_GetControl_Call struct {
+	*mock.Call
+}
+
+// GetControl is a helper method to define mock.On call
+//   - device string
+func (_e *MockArbitrationService_Expecter) GetControl(device interface{}) *MockArbitrationService_GetControl_Call {
+	return &MockArbitrationService_GetControl_Call{Call: _e.mock.On("GetControl", device)}
+}
+
+func (_c *MockArbitrationService_GetControl_Call) Run(run func(device string)) *MockArbitrationService_GetControl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockArbitrationService_GetControl_Call) Return(_a0 domain.DeviceControl, _a1 error) *MockArbitrationService_GetControl_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockArbitrationService_GetControl_Call) RunAndReturn(run func(string) (domain.DeviceControl, error)) *MockArbitrationService_GetControl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListControls provides a mock function with given fields:
+func (_m *MockArbitrationService) ListControls() []domain.DeviceControl {
+	ret := _m.Called()
+
+	var r0 []domain.DeviceControl
+	if rf, ok := ret.Get(0).(func() []domain.DeviceControl); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DeviceControl)
+		}
+	}
+
+	return r0
+}
+
+// MockArbitrationService_ListControls_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListControls'
+type MockArbitrationService_ListControls_Call struct {
+	*mock.Call
+}
+
+// ListControls is a helper method to define mock.On call
+func (_e *MockArbitrationService_Expecter) ListControls() *MockArbitrationService_ListControls_Call {
+	return &MockArbitrationService_ListControls_Call{Call: _e.mock.On("ListControls")}
+}
+
+func (_c *MockArbitrationService_ListControls_Call) Run(run func()) *MockArbitrationService_ListControls_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockArbitrationService_ListControls_Call) Return(_a0 []domain.DeviceControl) *MockArbitrationService_ListControls_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockArbitrationService_ListControls_Call) RunAndReturn(run func() []domain.DeviceControl) *MockArbitrationService_ListControls_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Override provides a mock function with given fields: device, window
+func (_m *MockArbitrationService) Override(device string, window time.Duration) (domain.DeviceControl, error) {
+	ret := _m.Called(device, window)
+
+	var r0 domain.DeviceControl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration) (domain.DeviceControl, error)); ok {
+		return rf(device, window)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Duration) domain.DeviceControl); ok {
+		r0 = rf(device, window)
+	} else {
+		r0 = ret.Get(0).(domain.DeviceControl)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(device, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockArbitrationService_Override_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Override'
+type MockArbitrationService_Override_Call struct {
+	*mock.Call
+}
+
+// Override is a helper method to define mock.On call
+//   - device string
+//   - window time.Duration
+func (_e *MockArbitrationService_Expecter) Override(device interface{}, window interface{}) *MockArbitrationService_Override_Call {
+	return &MockArbitrationService_Override_Call{Call: _e.mock.On("Override", device, window)}
+}
+
+func (_c *MockArbitrationService_Override_Call) Run(run func(device string, window time.Duration)) *MockArbitrationService_Override_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockArbitrationService_Override_Call) Return(_a0 domain.DeviceControl, _a1 error) *MockArbitrationService_Override_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockArbitrationService_Override_Call) RunAndReturn(run func(string, time.Duration) (domain.DeviceControl, error)) *MockArbitrationService_Override_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: device
+func (_m *MockArbitrationService) Release(device string) (domain.DeviceControl, error) {
+	ret := _m.Called(device)
+
+	var r0 domain.DeviceControl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (domain.DeviceControl, error)); ok {
+		return rf(device)
+	}
+	if rf, ok := ret.Get(0).(func(string) domain.DeviceControl); ok {
+		r0 = rf(device)
+	} else {
+		r0 = ret.Get(0).(domain.DeviceControl)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockArbitrationService_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type MockArbitrationService_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - device string
+func (_e *MockArbitrationService_Expecter) Release(device interface{}) *MockArbitrationService_Release_Call {
+	return &MockArbitrationService_Release_Call{Call: _e.mock.On("Release", device)}
+}
+
+func (_c *MockArbitrationService_Release_Call) Run(run func(device string)) *MockArbitrationService_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockArbitrationService_Release_Call) Return(_a0 domain.DeviceControl, _a1 error) *MockArbitrationService_Release_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockArbitrationService_Release_Call) RunAndReturn(run func(string) (domain.DeviceControl, error)) *MockArbitrationService_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockArbitrationService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockArbitrationService creates a new instance of MockArbitrationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockArbitrationService(t mockConstructorTestingTNewMockArbitrationService) *MockArbitrationService {
+	mock := &MockArbitrationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
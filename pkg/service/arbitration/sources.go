@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	acService "github.com/pklimuk-eng-thesis/control-station/pkg/service/ac"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	lightService "github.com/pklimuk-eng-thesis/control-station/pkg/service/light"
+)
+
+// Switches returns the switches that issue their toggles as the given source,
+// so that the arbitration service can refuse them, or record them once they
+// succeeded.
+func Switches(switches map[string]groupService.Switch, service ArbitrationService,
+	source domain.CommandSource) map[string]groupService.Switch {
+	arbitrated := make(map[string]groupService.Switch, len(switches))
+	for name, sw := range switches {
+		name, toggleEnabled := name, sw.ToggleEnabled
+		arbitrated[name] = groupService.Switch{
+			GetInfo: sw.GetInfo,
+			ToggleEnabled: func(ctx context.Context) (domain.Switchable, error) {
+				if err := service.Authorize(name, source); err != nil {
+					return nil, err
+				}
+				info, err := toggleEnabled(ctx)
+				if err == nil {
+					service.Command(name, source)
+				}
+				return info, err
+			},
+		}
+	}
+	return arbitrated
+}
+
+type arbitratedACService struct {
+	acService.ACService
+	name    string
+	service ArbitrationService
+	source  domain.CommandSource
+}
+
+// AC returns the AC service that issues its commands as the given source.
+func AC(ac acService.ACService, name string, service ArbitrationService, source domain.CommandSource) acService.ACService {
+	return &arbitratedACService{ACService: ac, name: name, service: service, source: source}
+}
+
+func (s *arbitratedACService) ToggleEnabled(ctx context.Context) (domain.ACInfo, error) {
+	if err := s.service.Authorize(s.name, s.source); err != nil {
+		return domain.ACInfo{}, err
+	}
+	acInfo, err := s.ACService.ToggleEnabled(ctx)
+	if err == nil {
+		s.service.Command(s.name, s.source)
+	}
+	return acInfo, err
+}
+
+func (s *arbitratedACService) UpdateACSettings(desiredSettings domain.ACInfo) (domain.ACInfo, error) {
+	if err := s.service.Authorize(s.name, s.source); err != nil {
+		return domain.ACInfo{}, err
+	}
+	acInfo, err := s.ACService.UpdateACSettings(desiredSettings)
+	if err == nil {
+		s.service.Command(s.name, s.source)
+	}
+	return acInfo, err
+}
+
+type arbitratedLightService struct {
+	lightService.LightService
+	name    string
+	service ArbitrationService
+	source  domain.CommandSource
+}
+
+// Light returns the light service that issues its commands as the given source.
+func Light(light lightService.LightService, name string, service ArbitrationService,
+	source domain.CommandSource) lightService.LightService {
+	return &arbitratedLightService{LightService: light, name: name, service: service, source: source}
+}
+
+func (s *arbitratedLightService) ToggleEnabled(ctx context.Context) (domain.LightInfo, error) {
+	return s.command(func() (domain.LightInfo, error) {
+		return s.LightService.ToggleEnabled(ctx)
+	})
+}
+
+func (s *arbitratedLightService) SetBrightness(request domain.LightBrightnessRequest) (domain.LightInfo, error) {
+	return s.command(func() (domain.LightInfo, error) {
+		return s.LightService.SetBrightness(request)
+	})
+}
+
+func (s *arbitratedLightService) SetColor(request domain.LightColorRequest) (domain.LightInfo, error) {
+	return s.command(func() (domain.LightInfo, error) {
+		return s.LightService.SetColor(request)
+	})
+}
+
+func (s *arbitratedLightService) command(send func() (domain.LightInfo, error)) (domain.LightInfo, error) {
+	if err := s.service.Authorize(s.name, s.source); err != nil {
+		return domain.LightInfo{}, err
+	}
+	lightInfo, err := send()
+	if err == nil {
+		s.service.Command(s.name, s.source)
+	}
+	return lightInfo, err
+}
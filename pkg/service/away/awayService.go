@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	acService "github.com/pklimuk-eng-thesis/control-station/pkg/service/ac"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	securityService "github.com/pklimuk-eng-thesis/control-station/pkg/service/security"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+var ErrAlreadyAway = errors.New("Away mode is already active")
+var ErrNotAway = errors.New("Away mode is not active")
+
+const awayLogName = "away"
+
+// defaultProbability is used for the hours without any history, so that a
+// device without logs is still switched now and then.
+const defaultProbability = 0.5
+
+//go:generate --name AwayService --output mock_awayService.go
+type AwayService interface {
+	GetState() domain.AwayState
+	Enter(source string) (domain.AwayState, error)
+	Leave(pin string, source string) (domain.AwayState, error)
+}
+
+type awayService struct {
+	config   domain.AwayConfig
+	ac       acService.ACService
+	switches map[string]groupService.Switch
+	// simulated are the switches the presence simulation uses, which give way to
+	// a manual override unlike those used to enter and leave the away mode.
+	simulated map[string]groupService.Switch
+	security  securityService.SecurityService
+	now       func() time.Time
+	random    func() float64
+
+	// transitionMu serializes entering and leaving, which wait for the devices.
+	transitionMu sync.Mutex
+	mu           sync.Mutex
+	state        domain.AwayState
+	stop         chan struct{}
+	done         chan struct{}
+}
+
+// NewAwayService resumes the away mode if it was active before a restart. The
+// AC and switches are used on behalf of whoever enters or leaves the away mode,
+// the simulated switches by the presence simulation. The security service is
+// nil when the security subsystem is disabled.
+func NewAwayService(config domain.AwayConfig, ac acService.ACService, switches map[string]groupService.Switch,
+	simulated map[string]groupService.Switch, security securityService.SecurityService) AwayService {
+	s := &awayService{config: config, ac: ac, switches: switches, simulated: simulated, security: security,
+		now: time.Now, random: rand.Float64}
+	s.restore()
+	return s
+}
+
+func (s *awayService) GetState() domain.AwayState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state
+}
+
+// Enter takes the snapshot of the house, arms the security, lowers the AC usage
+// and starts the presence simulation.
+func (s *awayService) Enter(source string) (domain.AwayState, error) {
+	s.transitionMu.Lock()
+	defer s.transitionMu.Unlock()
+
+	if s.GetState().Active {
+		return s.GetState(), ErrAlreadyAway
+	}
+
+	snapshot, err := s.snapshot()
+	if err != nil {
+		return s.GetState(), err
+	}
+	if s.security != nil && snapshot.SecurityMode != domain.SecurityArmedAway {
+		if _, err := s.security.Arm(domain.SecurityArmedAway, source); err != nil {
+			return s.GetState(), err
+		}
+	}
+	if snapshot.AC != nil && snapshot.AC.Enabled {
+		s.lowerAC(*snapshot.AC)
+	}
+
+	now := s.now().UTC()
+	s.start(domain.AwayState{Active: true, Since: &now, Source: source, Snapshot: &snapshot})
+	s.record(domain.AwayRecord{Active: true, Snapshot: &snapshot, Source: source, Time: now})
+	return s.GetState(), nil
+}
+
+// Leave restores the security mode first, which needs the PIN unless the house
+// was armed away before, and then the devices.
+func (s *awayService) Leave(pin string, source string) (domain.AwayState, error) {
+	s.transitionMu.Lock()
+	defer s.transitionMu.Unlock()
+
+	state := s.GetState()
+	if !state.Active {
+		return state, ErrNotAway
+	}
+
+	snapshot := state.Snapshot
+	if s.security != nil && snapshot.SecurityMode != domain.SecurityArmedAway {
+		if _, err := s.security.Disarm(pin, source); err != nil {
+			return state, err
+		}
+		if snapshot.SecurityMode == domain.SecurityArmedHome {
+			if _, err := s.security.Arm(domain.SecurityArmedHome, source); err != nil {
+				log.Printf("Failed to restore the security mode after away mode: %s\n", err)
+			}
+		}
+	}
+
+	s.stopSimulation()
+	for name, enabled := range snapshot.Devices {
+		if err := ensure(s.switches[name], enabled); err != nil {
+			log.Printf("Failed to restore '%s' after away mode: %s\n", name, err)
+		}
+	}
+	if snapshot.AC != nil {
+		if err := s.restoreAC(*snapshot.AC); err != nil {
+			log.Printf("Failed to restore '%s' after away mode: %s\n", s.config.AC, err)
+		}
+	}
+
+	s.mu.Lock()
+	s.state = domain.AwayState{Active: false}
+	s.mu.Unlock()
+	s.record(domain.AwayRecord{Active: false, Source: source, Time: s.now().UTC()})
+	return s.GetState(), nil
+}
+
+func (s *awayService) snapshot() (domain.AwaySnapshot, error) {
+	snapshot := domain.AwaySnapshot{Devices: map[string]bool{}}
+	for _, name := range s.config.SimulatedDevices {
+		deviceSwitch, ok := s.switches[name]
+		if !ok {
+			return snapshot, fmt.Errorf("%s: %w", name, groupService.ErrNotSwitchable)
+		}
+		info, err := deviceSwitch.GetInfo(context.Background())
+		if err != nil {
+			return snapshot, err
+		}
+		snapshot.Devices[name] = info.IsEnabled()
+	}
+
+	acInfo, err := s.ac.GetInfo()
+	if err != nil {
+		return snapshot, err
+	}
+	snapshot.AC = &acInfo
+
+	if s.security != nil {
+		snapshot.SecurityMode = s.security.GetState().Mode
+	}
+	return snapshot, nil
+}
+
+// lowerAC switches the AC off, or keeps it running at the away setpoint. An AC
+// that is off is never switched on.
+func (s *awayService) lowerAC(acInfo domain.ACInfo) {
+	var err error
+	if s.config.ACSetpoint == nil {
+		_, err = s.ac.ToggleEnabled(context.Background())
+	} else {
+		acInfo.Temperature = *s.config.ACSetpoint
+		_, err = s.ac.UpdateACSettings(acInfo)
+	}
+	if err != nil {
+		log.Printf("Failed to lower '%s' for away mode: %s\n", s.config.AC, err)
+	}
+}
+
+func (s *awayService) restoreAC(snapshot domain.ACInfo) error {
+	acInfo, err := s.ac.GetInfo()
+	if err != nil {
+		return err
+	}
+	if !snapshot.Enabled {
+		if acInfo.Enabled {
+			_, err = s.ac.ToggleEnabled(context.Background())
+		}
+		return err
+	}
+
+	acInfo, err = s.ac.UpdateACSettings(snapshot)
+	if err == nil && !acInfo.Enabled {
+		_, err = s.ac.ToggleEnabled(context.Background())
+	}
+	return err
+}
+
+// start learns the patterns and runs the simulation until the away mode is
+// left.
+func (s *awayService) start(state domain.AwayState) {
+	state.Patterns = map[string]domain.ActivityPattern{}
+	for _, name := range s.config.SimulatedDevices {
+		state.Patterns[name] = s.learnPattern(name)
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	s.mu.Lock()
+	s.state = state
+	s.stop = stop
+	s.done = done
+	s.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(s.config.SimulationInterval)
+		defer ticker.Stop()
+		for {
+			s.simulate(state.Patterns)
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// stopSimulation waits for the simulation to finish switching, so that the
+// devices can be restored without it interfering.
+func (s *awayService) stopSimulation() {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// simulate switches every simulated device on with the probability learned for
+// the current hour while inside a window, and off outside of the windows.
+func (s *awayService) simulate(patterns map[string]domain.ActivityPattern) {
+	now := s.now()
+	inWindow := s.inWindow(now)
+	for _, name := range s.config.SimulatedDevices {
+		pattern := patterns[name]
+		enabled := inWindow && s.random() < pattern[now.Hour()]
+		if err := ensure(s.simulated[name], enabled); err != nil {
+			log.Printf("Failed to simulate presence with '%s': %s\n", name, err)
+		}
+	}
+}
+
+func (s *awayService) inWindow(now time.Time) bool {
+	minute := now.Hour()*60 + now.Minute()
+	for _, window := range s.config.Windows {
+		// The windows are validated when the configuration is read.
+		if contains, _ := window.Contains(minute); contains {
+			return true
+		}
+	}
+	return false
+}
+
+// learnPattern derives the hourly probability of the device being on from the
+// share of its logs in every hour that report it enabled.
+func (s *awayService) learnPattern(name string) domain.ActivityPattern {
+	logs, err := controlStationUtils.GetLogsFromDataServiceLimitN[domain.DeviceData](name, s.config.HistoryLimit)
+	if err != nil {
+		log.Printf("Failed to read the history of '%s', using the default pattern: %s\n", name, err)
+		return LearnPattern(nil)
+	}
+	return LearnPattern(logs)
+}
+
+// LearnPattern returns the share of the logs reporting the device enabled for
+// every hour of the day, in local time. Hours without logs get the default
+// probability.
+func LearnPattern(logs []domain.DeviceData) domain.ActivityPattern {
+	var enabled, total [24]int
+	for _, deviceLog := range logs {
+		hour := deviceLog.CreatedAt.Local().Hour()
+		total[hour]++
+		if deviceLog.IsEnabled {
+			enabled[hour]++
+		}
+	}
+
+	var pattern domain.ActivityPattern
+	for hour := range pattern {
+		if total[hour] == 0 {
+			pattern[hour] = defaultProbability
+			continue
+		}
+		pattern[hour] = float64(enabled[hour]) / float64(total[hour])
+	}
+	return pattern
+}
+
+func (s *awayService) record(record domain.AwayRecord) {
+	log.Printf("AUDIT away active=%t source=%s\n", record.Active, record.Source)
+
+	err := controlStationUtils.SendLogsToDataService(awayLogName, record)
+	if err != nil {
+		log.Printf("Failed to send '%s' logs to data service: %s\n", awayLogName, err)
+	}
+}
+
+// restore resumes the simulation if the latest record shows the away mode was
+// active. The devices are left as they are.
+func (s *awayService) restore() {
+	records, err := controlStationUtils.GetLogsFromDataServiceLimitN[domain.AwayRecord](awayLogName, 1)
+	if err != nil {
+		log.Printf("Failed to restore the away mode: %s\n", err)
+		return
+	}
+	if len(records) == 0 || !records[0].Active || records[0].Snapshot == nil {
+		return
+	}
+
+	since := records[0].Time
+	s.start(domain.AwayState{Active: true, Since: &since, Source: records[0].Source, Snapshot: records[0].Snapshot})
+}
+
+func ensure(deviceSwitch groupService.Switch, enabled bool) error {
+	if deviceSwitch.GetInfo == nil {
+		return groupService.ErrNotSwitchable
+	}
+
+	info, err := deviceSwitch.GetInfo(context.Background())
+	if err != nil {
+		return err
+	}
+	if info.IsEnabled() == enabled {
+		return nil
+	}
+	_, err = deviceSwitch.ToggleEnabled(context.Background())
+	return err
+}
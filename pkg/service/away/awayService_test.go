@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	acService "github.com/pklimuk-eng-thesis/control-station/pkg/service/ac"
+	arbitrationService "github.com/pklimuk-eng-thesis/control-station/pkg/service/arbitration"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	securityService "github.com/pklimuk-eng-thesis/control-station/pkg/service/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeSwitch is a switchable device that records how often it was toggled.
+type fakeSwitch struct {
+	mu      sync.Mutex
+	enabled bool
+	toggles int
+}
+
+func (f *fakeSwitch) toSwitch() groupService.Switch {
+	return groupService.Switch{
+		GetInfo: func(ctx context.Context) (domain.Switchable, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return domain.PlugInfo{Enabled: f.enabled}, nil
+		},
+		ToggleEnabled: func(ctx context.Context) (domain.Switchable, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.toggles++
+			f.enabled = !f.enabled
+			return domain.PlugInfo{Enabled: f.enabled}, nil
+		},
+	}
+}
+
+func (f *fakeSwitch) isEnabled() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.enabled
+}
+
+// newDataService serves the persisted away records and the device history,
+// and collects the new away records.
+func newDataService(t *testing.T, persisted []domain.AwayRecord, history []domain.DeviceData) *[]domain.AwayRecord {
+	var mu sync.Mutex
+	recorded := &[]domain.AwayRecord{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/away/latest":
+			json.NewEncoder(w).Encode(persisted)
+		case "/away/add":
+			var record domain.AwayRecord
+			json.NewDecoder(r.Body).Decode(&record)
+			*recorded = append(*recorded, record)
+		default:
+			json.NewEncoder(w).Encode(history)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+	return recorded
+}
+
+var testConfig = domain.AwayConfig{
+	AC:                 "ac",
+	SimulatedDevices:   []string{"smartBulb", "smartPlug"},
+	Windows:            []domain.AwayWindow{{Start: "18:00", End: "23:00"}},
+	SimulationInterval: time.Hour,
+	HistoryLimit:       100,
+}
+
+func newTestAwayService(config domain.AwayConfig, ac acService.ACService, switches map[string]groupService.Switch,
+	security securityService.SecurityService, now time.Time) *awayService {
+	s := &awayService{config: config, ac: ac, switches: switches, simulated: switches, security: security,
+		now: func() time.Time { return now }, random: func() float64 { return 0.5 }}
+	s.restore()
+	return s
+}
+
+func TestLearnPattern(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2023, 1, 1, hour, 0, 0, 0, time.Local)
+	}
+	logs := []domain.DeviceData{
+		{CreatedAt: at(19), IsEnabled: true},
+		{CreatedAt: at(19), IsEnabled: true},
+		{CreatedAt: at(19), IsEnabled: false},
+		{CreatedAt: at(19), IsEnabled: true},
+		{CreatedAt: at(3), IsEnabled: false},
+	}
+
+	got := LearnPattern(logs)
+
+	assert.Equal(t, 0.75, got[19])
+	assert.Equal(t, 0.0, got[3])
+	assert.Equal(t, defaultProbability, got[12])
+}
+
+func TestAwayWindow_Contains(t *testing.T) {
+	overMidnight := domain.AwayWindow{Start: "22:00", End: "02:00"}
+
+	for minute, want := range map[int]bool{23 * 60: true, 60: true, 12 * 60: false, 2 * 60: false} {
+		got, err := overMidnight.Contains(minute)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, minute)
+	}
+}
+
+func TestEnterAndLeave(t *testing.T) {
+	recorded := newDataService(t, nil, []domain.DeviceData{
+		{CreatedAt: time.Date(2023, 1, 1, 20, 0, 0, 0, time.Local), IsEnabled: true},
+	})
+	light := &fakeSwitch{enabled: false}
+	plug := &fakeSwitch{enabled: true}
+	switches := map[string]groupService.Switch{"smartBulb": light.toSwitch(), "smartPlug": plug.toSwitch()}
+
+	acInfo := domain.ACInfo{Enabled: true, Temperature: 22, Humidity: 50, Mode: domain.ACModeCool}
+	ac := new(acService.MockACService)
+	ac.EXPECT().GetInfo().RunAndReturn(func() (domain.ACInfo, error) { return acInfo, nil })
+	ac.EXPECT().ToggleEnabled(mock.Anything).RunAndReturn(func(ctx context.Context) (domain.ACInfo, error) {
+		acInfo.Enabled = !acInfo.Enabled
+		return acInfo, nil
+	})
+	ac.EXPECT().UpdateACSettings(mock.Anything).RunAndReturn(func(settings domain.ACInfo) (domain.ACInfo, error) {
+		acInfo = settings
+		return acInfo, nil
+	})
+
+	security := new(securityService.MockSecurityService)
+	security.EXPECT().GetState().Return(domain.SecurityState{Mode: domain.SecurityArmedHome})
+	security.EXPECT().Arm(mock.Anything, mock.Anything).Return(domain.SecurityState{}, nil)
+	security.EXPECT().Disarm("1234", "10.0.0.1").Return(domain.SecurityState{}, nil)
+
+	service := newTestAwayService(testConfig, ac, switches, security, time.Date(2023, 1, 2, 20, 30, 0, 0, time.Local))
+
+	got, err := service.Enter("10.0.0.1")
+	assert.NoError(t, err)
+	assert.True(t, got.Active)
+	assert.Equal(t, domain.AwaySnapshot{AC: &domain.ACInfo{Enabled: true, Temperature: 22, Humidity: 50,
+		Mode: domain.ACModeCool}, Devices: map[string]bool{"smartBulb": false, "smartPlug": true},
+		SecurityMode: domain.SecurityArmedHome}, *got.Snapshot)
+	assert.Equal(t, 1.0, got.Patterns["smartBulb"][20])
+	security.AssertCalled(t, "Arm", domain.SecurityArmedAway, "10.0.0.1")
+	assert.False(t, acInfo.Enabled, "the AC must be switched off without an away setpoint")
+	assert.Eventually(t, light.isEnabled, time.Second, time.Millisecond,
+		"the light must be simulated on, the history shows it on at this hour")
+
+	_, err = service.Enter("10.0.0.1")
+	assert.ErrorIs(t, err, ErrAlreadyAway)
+
+	got, err = service.Leave("1234", "10.0.0.1")
+	assert.NoError(t, err)
+	assert.False(t, got.Active)
+	assert.False(t, light.isEnabled())
+	assert.True(t, plug.isEnabled())
+	assert.True(t, acInfo.Enabled)
+	assert.Equal(t, float32(22), acInfo.Temperature)
+	security.AssertCalled(t, "Arm", domain.SecurityArmedHome, "10.0.0.1")
+
+	assert.Len(t, *recorded, 2)
+	assert.True(t, (*recorded)[0].Active)
+	assert.False(t, (*recorded)[1].Active)
+
+	_, err = service.Leave("1234", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrNotAway)
+}
+
+func TestEnter_ACSetpoint(t *testing.T) {
+	newDataService(t, nil, nil)
+	ac := new(acService.MockACService)
+	ac.EXPECT().GetInfo().Return(domain.ACInfo{Enabled: true, Temperature: 22, Humidity: 50}, nil)
+	ac.EXPECT().UpdateACSettings(domain.ACInfo{Enabled: true, Temperature: 28, Humidity: 50}).
+		Return(domain.ACInfo{Enabled: true, Temperature: 28, Humidity: 50}, nil)
+	config := testConfig
+	config.SimulatedDevices = nil
+	setpoint := float32(28)
+	config.ACSetpoint = &setpoint
+
+	service := newTestAwayService(config, ac, nil, nil, time.Date(2023, 1, 2, 12, 0, 0, 0, time.Local))
+	_, err := service.Enter("10.0.0.1")
+
+	assert.NoError(t, err)
+	ac.AssertNotCalled(t, "ToggleEnabled", mock.Anything)
+	service.stopSimulation()
+}
+
+func TestEnter_OutsideWindowKeepsDevicesOff(t *testing.T) {
+	newDataService(t, nil, nil)
+	light := &fakeSwitch{enabled: true}
+	ac := new(acService.MockACService)
+	ac.EXPECT().GetInfo().Return(domain.ACInfo{Enabled: false}, nil)
+	config := testConfig
+	config.SimulatedDevices = []string{"smartBulb"}
+
+	service := newTestAwayService(config, ac, map[string]groupService.Switch{"smartBulb": light.toSwitch()}, nil,
+		time.Date(2023, 1, 2, 12, 0, 0, 0, time.Local))
+	_, err := service.Enter("10.0.0.1")
+
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return !light.isEnabled() }, time.Second, time.Millisecond)
+	ac.AssertNotCalled(t, "ToggleEnabled", mock.Anything)
+	service.stopSimulation()
+}
+
+func TestLeave_InvalidPIN(t *testing.T) {
+	newDataService(t, nil, nil)
+	ac := new(acService.MockACService)
+	ac.EXPECT().GetInfo().Return(domain.ACInfo{}, nil)
+	security := new(securityService.MockSecurityService)
+	security.EXPECT().GetState().Return(domain.SecurityState{Mode: domain.SecurityDisarmed})
+	security.EXPECT().Arm(mock.Anything, mock.Anything).Return(domain.SecurityState{}, nil)
+	security.EXPECT().Disarm("0000", mock.Anything).Return(domain.SecurityState{}, securityService.ErrInvalidPIN)
+	config := testConfig
+	config.SimulatedDevices = nil
+
+	service := newTestAwayService(config, ac, nil, security, time.Date(2023, 1, 2, 12, 0, 0, 0, time.Local))
+	service.Enter("10.0.0.1")
+	got, err := service.Leave("0000", "10.0.0.1")
+
+	assert.ErrorIs(t, err, securityService.ErrInvalidPIN)
+	assert.True(t, got.Active)
+	service.stopSimulation()
+}
+
+func TestLeave_RestoresOverriddenDevices(t *testing.T) {
+	newDataService(t, nil, nil)
+	deviceRegistry := registry.NewRegistry()
+	deviceRegistry.Register(domain.RegisteredDevice{Name: "smartBulb", Kind: domain.KindLight})
+	arbitration := arbitrationService.NewArbitrationService(deviceRegistry, time.Hour)
+	light := &fakeSwitch{enabled: true}
+	switches := map[string]groupService.Switch{"smartBulb": light.toSwitch()}
+	ac := new(acService.MockACService)
+	ac.EXPECT().GetInfo().Return(domain.ACInfo{Enabled: false}, nil)
+	config := testConfig
+	config.SimulatedDevices = []string{"smartBulb"}
+
+	service := newTestAwayService(config, ac, arbitrationService.Switches(switches, arbitration, domain.SourceUser), nil,
+		time.Date(2023, 1, 2, 12, 0, 0, 0, time.Local))
+	service.simulated = arbitrationService.Switches(switches, arbitration, domain.SourceSchedule)
+	_, err := service.Enter("10.0.0.1")
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return !light.isEnabled() }, time.Second, time.Millisecond)
+	arbitration.Override("smartBulb", time.Hour)
+
+	_, err = service.Leave("", "10.0.0.1")
+
+	assert.NoError(t, err)
+	assert.True(t, light.isEnabled(), "leaving must restore the light despite the override")
+}
+
+func TestNewAwayService_Restores(t *testing.T) {
+	snapshot := &domain.AwaySnapshot{Devices: map[string]bool{}}
+	newDataService(t, []domain.AwayRecord{{Active: true, Snapshot: snapshot, Source: "10.0.0.1",
+		Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}}, nil)
+	config := testConfig
+	config.SimulatedDevices = nil
+
+	service := newTestAwayService(config, nil, nil, nil, time.Date(2023, 1, 2, 12, 0, 0, 0, time.Local))
+
+	got := service.GetState()
+	assert.True(t, got.Active)
+	assert.Equal(t, snapshot, got.Snapshot)
+	service.stopSimulation()
+}
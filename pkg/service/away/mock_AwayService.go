@@ -0,0 +1,182 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAwayService is an autogenerated mock type for the AwayService type
+type MockAwayService struct {
+	mock.Mock
+}
+
+type MockAwayService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAwayService) EXPECT() *MockAwayService_Expecter {
+	return &MockAwayService_Expecter{mock: &_m.Mock}
+}
+
+// Enter provides a mock function with given fields: source
+func (_m *MockAwayService) Enter(source string) (domain.AwayState, error) {
+	ret := _m.Called(source)
+
+	var r0 domain.AwayState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (domain.AwayState, error)); ok {
+		return rf(source)
+	}
+	if rf, ok := ret.Get(0).(func(string) domain.AwayState); ok {
+		r0 = rf(source)
+	} else {
+		r0 = ret.Get(0).(domain.AwayState)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAwayService_Enter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enter'
+type MockAwayService_Enter_Call struct {
+	*mock.Call
+}
+
+// Enter is a helper method to define mock.On call
+//   - source string
+func (_e *MockAwayService_Expecter) Enter(source interface{}) *MockAwayService_Enter_Call {
+	return &MockAwayService_Enter_Call{Call: _e.mock.On("Enter", source)}
+}
+
+func (_c *MockAwayService_Enter_Call) Run(run func(source string)) *MockAwayService_Enter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockAwayService_Enter_Call) Return(_a0 domain.AwayState, _a1 error) *MockAwayService_Enter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAwayService_Enter_Call) RunAndReturn(run func(string) (domain.AwayState, error)) *MockAwayService_Enter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetState provides a mock function with given fields:
+func (_m *MockAwayService) GetState() domain.AwayState {
+	ret := _m.Called()
+
+	var r0 domain.AwayState
+	if rf, ok := ret.Get(0).(func() domain.AwayState); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.AwayState)
+	}
+
+	return r0
+}
+
+// MockAwayService_GetState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetState'
+type MockAwayService_GetState_Call struct {
+	*mock.Call
+}
+
+// GetState is a helper method to define mock.On call
+func (_e *MockAwayService_Expecter) GetState() *MockAwayService_GetState_Call {
+	return &MockAwayService_GetState_Call{Call: _e.mock.On("GetState")}
+}
+
+func (_c *MockAwayService_GetState_Call) Run(run func()) *MockAwayService_GetState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAwayService_GetState_Call) Return(_a0 domain.AwayState) *MockAwayService_GetState_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAwayService_GetState_Call) RunAndReturn(run func() domain.AwayState) *MockAwayService_GetState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Leave provides a mock function with given fields: pin, source
+func (_m *MockAwayService) Leave(pin string, source string) (domain.AwayState, error) {
+	ret := _m.Called(pin, source)
+
+	var r0 domain.AwayState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (domain.AwayState, error)); ok {
+		return rf(pin, source)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) domain.AwayState); ok {
+		r0 = rf(pin, source)
+	} else {
+		r0 = ret.Get(0).(domain.AwayState)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(pin, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAwayService_Leave_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Leave'
+type MockAwayService_Leave_Call struct {
+	*mock.Call
+}
+
+// Leave is a helper method to define mock.On call
+//   - pin string
+//   - source string
+func (_e *MockAwayService_Expecter) Leave(pin interface{}, source interface{}) *MockAwayService_Leave_Call {
+	return &MockAwayService_Leave_Call{Call: _e.mock.On("Leave", pin, source)}
+}
+
+func (_c *MockAwayService_Leave_Call) Run(run func(pin string, source string)) *MockAwayService_Leave_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAwayService_Leave_Call) Return(_a0 domain.AwayState, _a1 error) *MockAwayService_Leave_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAwayService_Leave_Call) RunAndReturn(run func(string, string) (domain.AwayState, error)) *MockAwayService_Leave_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockAwayService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockAwayService creates a new instance of MockAwayService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockAwayService(t mockConstructorTestingTNewMockAwayService) *MockAwayService {
+	mock := &MockAwayService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+var ErrAuditUnavailable = errors.New("Command can not be audited")
+
+func (s *deviceService) GetAuditLogsFromDataServiceLimitN(limit int) ([]domain.AuditEntry, error) {
+	return controlStationUtils.GetLogsFromDataServiceLimitN[domain.AuditEntry](s.auditLogName(), limit)
+}
+
+// auditAttempt records that a command is about to be carried out and refuses
+// it when the record can not be written.
+func (s *deviceService) auditAttempt(command string, source string) error {
+	err := s.audit(domain.AuditEntry{Command: command, Result: domain.AuditAttempt, Source: source})
+	if err != nil {
+		return controlStationUtils.NewServiceError(controlStationUtils.CodeDataServiceUnavailable,
+			fmt.Errorf("%w: %w", ErrAuditUnavailable, err))
+	}
+	return nil
+}
+
+func (s *deviceService) auditOutcome(command string, source string, deviceState domain.DeviceState, err error) {
+	entry := domain.AuditEntry{Command: command, Result: domain.AuditSuccess, Source: source,
+		State: deviceState.String("state")}
+	if err != nil {
+		entry.Result = domain.AuditFailure
+		entry.Detail = err.Error()
+	}
+	s.audit(entry)
+}
+
+// audit writes the entry to the service log and to a dedicated audit log in the
+// data service. Only the latter can fail.
+func (s *deviceService) audit(entry domain.AuditEntry) error {
+	entry.Device = s.device.Name
+	entry.Time = s.now().UTC()
+	log.Printf("AUDIT device=%s command=%s result=%s source=%s state=%s detail=%q\n",
+		entry.Device, entry.Command, entry.Result, entry.Source, entry.State, entry.Detail)
+
+	err := controlStationUtils.SendLogsToDataService(s.auditLogName(), entry)
+	if err != nil {
+		log.Printf("Failed to send '%s' audit logs to data service: %s\n", s.device.Name, err)
+	}
+	return err
+}
+
+func (s *deviceService) auditLogName() string {
+	return s.device.Name + "Audit"
+}
+
+// commandName names the command sent to the endpoint in the audit log.
+func commandName(endpoint string) string {
+	return strings.TrimPrefix(endpoint, "/")
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuditLogsFromDataServiceLimitN(t *testing.T) {
+	var gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"device": "test", "command": "lock", "result": "success", "source": "10.0.0.1",
+			"time": "2023-01-01T00:00:00Z"}]`))
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestLock(ts.URL, time.Now())
+	got, err := service.GetAuditLogsFromDataServiceLimitN(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/testAudit/latest", gotPath)
+	assert.Equal(t, []domain.AuditEntry{{Device: "test", Command: "lock", Result: domain.AuditSuccess,
+		Source: "10.0.0.1", Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}}, got)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+var ErrConfirmationRequired = errors.New("Command needs to be confirmed")
+var ErrInvalidConfirmationToken = errors.New("Invalid or expired confirmation token")
+
+// challengeKey binds a challenge to the requester and the command it was
+// issued for.
+type challengeKey struct {
+	source   string
+	endpoint string
+}
+
+// RequestConfirmation issues a single use confirmation token for a command
+// that needs confirmation, e.g. unlocking. The token is bound to the requester
+// and replaces the token issued to the same requester for the command before.
+func (s *deviceService) RequestConfirmation(capability domain.Capability, endpoint string,
+	source string) (domain.CommandChallenge, error) {
+	if !s.supports(capability, endpoint) || !controlStationUtils.RequiresConfirmation(endpoint) {
+		return domain.CommandChallenge{}, ErrCapabilityNotSupported
+	}
+
+	token, err := newConfirmationToken()
+	if err != nil {
+		return domain.CommandChallenge{}, err
+	}
+	challenge := domain.CommandChallenge{Token: token, ExpiresAt: s.now().Add(s.config.ConfirmationTTL)}
+
+	if controlStationUtils.IsAudited(capability) {
+		if err := s.auditAttempt(commandName(endpoint)+"_request", source); err != nil {
+			return domain.CommandChallenge{}, err
+		}
+	}
+
+	s.challengesMu.Lock()
+	defer s.challengesMu.Unlock()
+	if s.challenges == nil {
+		s.challenges = map[challengeKey]domain.CommandChallenge{}
+	}
+	for key, pending := range s.challenges {
+		if !s.now().Before(pending.ExpiresAt) {
+			delete(s.challenges, key)
+		}
+	}
+	s.challenges[challengeKey{source: source, endpoint: endpoint}] = challenge
+	return challenge, nil
+}
+
+// Confirm carries out the command when the token is the one issued to the same
+// requester for it and has not expired.
+func (s *deviceService) Confirm(ctx context.Context, capability domain.Capability, endpoint string, token string,
+	source string) (domain.DeviceState, error) {
+	if !s.supports(capability, endpoint) || !controlStationUtils.RequiresConfirmation(endpoint) {
+		return domain.DeviceState{}, ErrCapabilityNotSupported
+	}
+
+	if !s.consumeToken(endpoint, token, source) {
+		if controlStationUtils.IsAudited(capability) {
+			s.audit(domain.AuditEntry{Command: commandName(endpoint), Result: domain.AuditRejected, Source: source,
+				Detail: ErrInvalidConfirmationToken.Error()})
+		}
+		return domain.DeviceState{}, ErrInvalidConfirmationToken
+	}
+	return s.invoke(ctx, capability, endpoint, nil, source)
+}
+
+// consumeToken checks the token in constant time and invalidates the pending
+// challenge of the requester, so that every token can be tried only once.
+func (s *deviceService) consumeToken(endpoint string, token string, source string) bool {
+	s.challengesMu.Lock()
+	defer s.challengesMu.Unlock()
+
+	key := challengeKey{source: source, endpoint: endpoint}
+	challenge, ok := s.challenges[key]
+	delete(s.challenges, key)
+	if !ok || token == "" || !s.now().Before(challenge.ExpiresAt) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(challenge.Token), []byte(token)) == 1
+}
+
+func newConfirmationToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
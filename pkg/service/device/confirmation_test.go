@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var lockCapabilities = []domain.Capability{domain.CapabilityLockable}
+
+// fakeLock serves the lock endpoints and records the audit entries sent to the
+// data service.
+type fakeLock struct {
+	mu           sync.Mutex
+	state        domain.LockState
+	batteryLevel int
+	auditStatus  int
+	audits       []domain.AuditEntry
+}
+
+func (f *fakeLock) server() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/testAudit/add":
+			if f.auditStatus != http.StatusOK {
+				w.WriteHeader(f.auditStatus)
+				return
+			}
+			var entry domain.AuditEntry
+			json.NewDecoder(r.Body).Decode(&entry)
+			f.audits = append(f.audits, entry)
+		case strings.HasSuffix(r.URL.Path, "/add"):
+		case r.URL.Path == "/lock" && f.state != domain.LockStateJammed:
+			f.state = domain.LockStateLocked
+		case r.URL.Path == "/unlock" && f.state != domain.LockStateJammed:
+			f.state = domain.LockStateUnlocked
+		}
+		batteryLevel := f.batteryLevel
+		if batteryLevel == 0 {
+			batteryLevel = 80
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(domain.DeviceState{"state": f.state, "battery_level": batteryLevel})
+	}))
+}
+
+func (f *fakeLock) lockState() domain.LockState {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.state
+}
+
+func newTestLock(address string, now time.Time) *deviceService {
+	return &deviceService{
+		device: &domain.Device{Name: "test", Address: address, Capabilities: lockCapabilities},
+		config: domain.DeviceConfig{ConfirmationTTL: 30 * time.Second},
+		now:    func() time.Time { return now },
+	}
+}
+
+func TestInvoke_Lock(t *testing.T) {
+	device := &fakeLock{state: domain.LockStateUnlocked, auditStatus: http.StatusOK}
+	ts := device.server()
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestLock(ts.URL, now)
+	got, err := service.Invoke(context.Background(), domain.CapabilityLockable, controlStationUtils.LockEndpoint, nil, "10.0.0.1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(domain.LockStateLocked), got.String("state"))
+	assert.Equal(t, []domain.AuditEntry{
+		{Device: "test", Command: "lock", Result: domain.AuditAttempt, Source: "10.0.0.1", Time: now},
+		{Device: "test", Command: "lock", Result: domain.AuditSuccess, Source: "10.0.0.1",
+			State: string(domain.LockStateLocked), Time: now},
+	}, device.audits)
+}
+
+func TestInvoke_LockAuditUnavailable(t *testing.T) {
+	device := &fakeLock{state: domain.LockStateUnlocked, auditStatus: http.StatusInternalServerError}
+	ts := device.server()
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestLock(ts.URL, time.Now())
+	_, err := service.Invoke(context.Background(), domain.CapabilityLockable, controlStationUtils.LockEndpoint, nil, "10.0.0.1")
+
+	assert.ErrorIs(t, err, ErrAuditUnavailable)
+	assert.Equal(t, controlStationUtils.CodeDataServiceUnavailable, controlStationUtils.ErrorCodeOf(err))
+	assert.Equal(t, domain.LockStateUnlocked, device.lockState())
+}
+
+func TestInvoke_LockLowBattery(t *testing.T) {
+	device := &fakeLock{state: domain.LockStateUnlocked, batteryLevel: 15, auditStatus: http.StatusOK}
+	ts := device.server()
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestLock(ts.URL, time.Now())
+	got, err := service.Invoke(context.Background(), domain.CapabilityLockable, controlStationUtils.LockEndpoint, nil, "10.0.0.1")
+
+	assert.NoError(t, err)
+	assert.True(t, got.Bool("low_battery"))
+}
+
+func TestInvoke_LockJammed(t *testing.T) {
+	device := &fakeLock{state: domain.LockStateJammed, auditStatus: http.StatusOK}
+	ts := device.server()
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestLock(ts.URL, time.Now())
+	got, err := service.Invoke(context.Background(), domain.CapabilityLockable, controlStationUtils.LockEndpoint, nil, "10.0.0.1")
+
+	assert.ErrorIs(t, err, ErrJammed)
+	assert.Equal(t, string(domain.LockStateJammed), got.String("state"))
+	assert.Len(t, device.audits, 2)
+	assert.Equal(t, domain.AuditFailure, device.audits[1].Result)
+}
+
+func TestInvoke_UnlockNeedsConfirmation(t *testing.T) {
+	device := &fakeLock{state: domain.LockStateLocked, auditStatus: http.StatusOK}
+	ts := device.server()
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestLock(ts.URL, time.Now())
+	_, err := service.Invoke(context.Background(), domain.CapabilityLockable, controlStationUtils.UnlockEndpoint, nil, "10.0.0.1")
+
+	assert.ErrorIs(t, err, ErrConfirmationRequired)
+	assert.Equal(t, domain.LockStateLocked, device.lockState())
+	assert.Empty(t, device.audits)
+}
+
+func TestConfirm_Unlock(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		token       func(challenge domain.CommandChallenge) string
+		elapsed     time.Duration
+		wantState   domain.LockState
+		wantResults []domain.AuditResult
+		wantErr     error
+	}{
+		{
+			name:        "Confirmed",
+			token:       func(challenge domain.CommandChallenge) string { return challenge.Token },
+			wantState:   domain.LockStateUnlocked,
+			wantResults: []domain.AuditResult{domain.AuditAttempt, domain.AuditAttempt, domain.AuditSuccess},
+		},
+		{
+			name:        "WrongToken",
+			token:       func(challenge domain.CommandChallenge) string { return "wrong" },
+			wantState:   domain.LockStateLocked,
+			wantResults: []domain.AuditResult{domain.AuditAttempt, domain.AuditRejected},
+			wantErr:     ErrInvalidConfirmationToken,
+		},
+		{
+			name:        "EmptyToken",
+			token:       func(challenge domain.CommandChallenge) string { return "" },
+			wantState:   domain.LockStateLocked,
+			wantResults: []domain.AuditResult{domain.AuditAttempt, domain.AuditRejected},
+			wantErr:     ErrInvalidConfirmationToken,
+		},
+		{
+			name:        "Expired",
+			token:       func(challenge domain.CommandChallenge) string { return challenge.Token },
+			elapsed:     30 * time.Second,
+			wantState:   domain.LockStateLocked,
+			wantResults: []domain.AuditResult{domain.AuditAttempt, domain.AuditRejected},
+			wantErr:     ErrInvalidConfirmationToken,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			device := &fakeLock{state: domain.LockStateLocked, auditStatus: http.StatusOK}
+			ts := device.server()
+			defer ts.Close()
+			t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+			service := newTestLock(ts.URL, now)
+			challenge, err := service.RequestConfirmation(domain.CapabilityLockable, controlStationUtils.UnlockEndpoint,
+				"10.0.0.1")
+			assert.NoError(t, err)
+			assert.Len(t, challenge.Token, 32)
+			assert.Equal(t, now.Add(30*time.Second), challenge.ExpiresAt)
+
+			service.now = func() time.Time { return now.Add(test.elapsed) }
+			_, err = service.Confirm(context.Background(), domain.CapabilityLockable, controlStationUtils.UnlockEndpoint,
+				test.token(challenge), "10.0.0.1")
+
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantState, device.lockState())
+			var results []domain.AuditResult
+			for _, entry := range device.audits {
+				results = append(results, entry.Result)
+			}
+			assert.Equal(t, test.wantResults, results)
+			assert.Equal(t, "unlock_request", device.audits[0].Command)
+			assert.Equal(t, "unlock", device.audits[len(device.audits)-1].Command)
+		})
+	}
+}
+
+func TestConfirm_TokenIsSingleUse(t *testing.T) {
+	device := &fakeLock{state: domain.LockStateLocked, auditStatus: http.StatusOK}
+	ts := device.server()
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestLock(ts.URL, time.Now())
+	challenge, err := service.RequestConfirmation(domain.CapabilityLockable, controlStationUtils.UnlockEndpoint, "10.0.0.1")
+	assert.NoError(t, err)
+
+	_, err = service.Confirm(context.Background(), domain.CapabilityLockable, controlStationUtils.UnlockEndpoint, "wrong", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidConfirmationToken)
+	_, err = service.Confirm(context.Background(), domain.CapabilityLockable, controlStationUtils.UnlockEndpoint, challenge.Token, "10.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidConfirmationToken)
+	assert.Equal(t, domain.LockStateLocked, device.lockState())
+}
+
+func TestConfirm_ConcurrentRequesters(t *testing.T) {
+	device := &fakeLock{state: domain.LockStateLocked, auditStatus: http.StatusOK}
+	ts := device.server()
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestLock(ts.URL, time.Now())
+	first, err := service.RequestConfirmation(domain.CapabilityLockable, controlStationUtils.UnlockEndpoint, "10.0.0.1")
+	assert.NoError(t, err)
+	_, err = service.RequestConfirmation(domain.CapabilityLockable, controlStationUtils.UnlockEndpoint, "10.0.0.2")
+	assert.NoError(t, err)
+
+	_, err = service.Confirm(context.Background(), domain.CapabilityLockable, controlStationUtils.UnlockEndpoint, first.Token, "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.LockStateUnlocked, device.lockState())
+}
+
+func TestConfirm_TokenIsBoundToRequester(t *testing.T) {
+	device := &fakeLock{state: domain.LockStateLocked, auditStatus: http.StatusOK}
+	ts := device.server()
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestLock(ts.URL, time.Now())
+	challenge, err := service.RequestConfirmation(domain.CapabilityLockable, controlStationUtils.UnlockEndpoint, "10.0.0.1")
+	assert.NoError(t, err)
+
+	_, err = service.Confirm(context.Background(), domain.CapabilityLockable, controlStationUtils.UnlockEndpoint, challenge.Token, "10.0.0.2")
+	assert.ErrorIs(t, err, ErrInvalidConfirmationToken)
+	assert.Equal(t, domain.LockStateLocked, device.lockState())
+}
+
+func TestConfirm_WithoutRequest(t *testing.T) {
+	device := &fakeLock{state: domain.LockStateLocked, auditStatus: http.StatusOK}
+	ts := device.server()
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestLock(ts.URL, time.Now())
+	_, err := service.Confirm(context.Background(), domain.CapabilityLockable, controlStationUtils.UnlockEndpoint, "token", "10.0.0.1")
+
+	assert.ErrorIs(t, err, ErrInvalidConfirmationToken)
+	assert.Equal(t, domain.AuditRejected, device.audits[0].Result)
+}
+
+func TestConfirm_NotConfirmedEndpoint(t *testing.T) {
+	service := newTestLock("http://test", time.Now())
+
+	_, err := service.RequestConfirmation(domain.CapabilityLockable, controlStationUtils.LockEndpoint, "10.0.0.1")
+	assert.ErrorIs(t, err, ErrCapabilityNotSupported)
+	_, err = service.Confirm(context.Background(), domain.CapabilityLockable, controlStationUtils.LockEndpoint, "token", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrCapabilityNotSupported)
+}
+
+func TestRequestConfirmation_AuditUnavailable(t *testing.T) {
+	device := &fakeLock{state: domain.LockStateLocked, auditStatus: http.StatusInternalServerError}
+	ts := device.server()
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestLock(ts.URL, time.Now())
+	challenge, err := service.RequestConfirmation(domain.CapabilityLockable, controlStationUtils.UnlockEndpoint, "10.0.0.1")
+
+	assert.ErrorIs(t, err, ErrAuditUnavailable)
+	assert.Equal(t, domain.CommandChallenge{}, challenge)
+	assert.Empty(t, service.challenges)
+}
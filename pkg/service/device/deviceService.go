@@ -1,35 +1,297 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
 	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/event"
 	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/state"
 )
 
+var ErrCapabilityNotSupported = errors.New("Capability is not supported by the device")
+var ErrJammed = errors.New("Lock is jammed")
+
+const lowBatteryLevel = 20
+
 //go:generate --name DeviceService --output mock_deviceService.go
 type DeviceService interface {
-	GetInfo() (domain.DeviceInfo, error)
-	ToggleEnabled() (domain.DeviceInfo, error)
-	GetDeviceLogsFromDataServiceLimitN(limit int) ([]domain.DeviceData, error)
+	GetInfo() (domain.DeviceState, error)
+	ReadInfo(ctx context.Context) (domain.DeviceState, error)
+	Invoke(ctx context.Context, capability domain.Capability, endpoint string, reqBody domain.DeviceState,
+		source string) (domain.DeviceState, error)
+	RequestConfirmation(capability domain.Capability, endpoint string, source string) (domain.CommandChallenge, error)
+	Confirm(ctx context.Context, capability domain.Capability, endpoint string, token string,
+		source string) (domain.DeviceState, error)
+	GetState() (domain.CachedState, bool)
+	GetCapabilities() []domain.Capability
+	GetDeviceLogsFromDataServiceLimitN(limit int) ([]domain.DeviceState, error)
+	QueryDeviceLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.DeviceState], error)
+	GetAuditLogsFromDataServiceLimitN(limit int) ([]domain.AuditEntry, error)
 }
 
 type deviceService struct {
-	device *domain.Device
+	device     *domain.Device
+	eventBus   event.Bus
+	stateCache state.Cache
+	config     domain.DeviceConfig
+	mu         sync.Mutex
+	detected   bool
+	// reported is set once the first detected state was published, so that
+	// subscribers learn the state also when nothing is detected.
+	reported bool
+	now      func() time.Time
+
+	challengesMu sync.Mutex
+	// challenges holds the pending challenge of every requester and command.
+	challenges map[challengeKey]domain.CommandChallenge
+
+	trackingMu sync.Mutex
+	tracking   bool
+	// movementStarted is set when a movement starts while another one is
+	// tracked, so that the tracker does not stop before following it.
+	movementStarted bool
 }
 
-func NewDeviceService(device *domain.Device) DeviceService {
-	return &deviceService{device: device}
+// NewDeviceService publishes detected and cleared events on the event bus for
+// devices with the detectable capability, and keeps the last known state of
+// the device in the state cache.
+func NewDeviceService(device *domain.Device, eventBus event.Bus, stateCache state.Cache,
+	config domain.DeviceConfig) DeviceService {
+	return &deviceService{device: device, eventBus: eventBus, stateCache: stateCache, config: config, now: time.Now}
 }
 
-func (s *deviceService) GetInfo() (domain.DeviceInfo, error) {
+func (s *deviceService) GetInfo() (domain.DeviceState, error) {
 	address := s.device.Address + controlStationUtils.InfoEndpoint
-	return controlStationUtils.MakeGetRequest(address, s.device.Name, domain.DeviceInfo{Enabled: false})
+	deviceState, err := controlStationUtils.MakeGetRequest(address, s.device.Name, domain.DeviceState{})
+	if err != nil {
+		return deviceState, err
+	}
+
+	deviceState = withBatteryState(deviceState)
+	s.cacheState(deviceState)
+	s.publishDetection(deviceState)
+	return deviceState, nil
+}
+
+func (s *deviceService) ReadInfo(ctx context.Context) (domain.DeviceState, error) {
+	address := s.device.Address + controlStationUtils.InfoEndpoint
+	deviceState, err := controlStationUtils.FetchJSONContext[domain.DeviceState](ctx, address, s.device.Name)
+	if err != nil {
+		return deviceState, err
+	}
+	return withBatteryState(deviceState), nil
+}
+
+// Invoke changes the device state through one of the endpoints of the
+// capability. Endpoints that need confirmation are invoked through
+// RequestConfirmation and Confirm instead.
+func (s *deviceService) Invoke(ctx context.Context, capability domain.Capability, endpoint string,
+	reqBody domain.DeviceState, source string) (domain.DeviceState, error) {
+	if !s.supports(capability, endpoint) {
+		return domain.DeviceState{}, ErrCapabilityNotSupported
+	}
+	if controlStationUtils.RequiresConfirmation(endpoint) {
+		return domain.DeviceState{}, ErrConfirmationRequired
+	}
+	return s.invoke(ctx, capability, endpoint, reqBody, source)
+}
+
+// GetState returns the last known state of the device without querying it.
+// While the device is moving the state is refreshed every poll interval.
+func (s *deviceService) GetState() (domain.CachedState, bool) {
+	if s.stateCache == nil {
+		return domain.CachedState{}, false
+	}
+	return s.stateCache.Get(s.device.Name)
+}
+
+func (s *deviceService) GetCapabilities() []domain.Capability {
+	return s.device.Capabilities
 }
 
-func (s *deviceService) ToggleEnabled() (domain.DeviceInfo, error) {
-	address := s.device.Address + controlStationUtils.EnabledEndpoint
-	return controlStationUtils.MakePatchRequest(address, s.device.Name, nil, domain.DeviceInfo{Enabled: false})
+func (s *deviceService) GetDeviceLogsFromDataServiceLimitN(limit int) ([]domain.DeviceState, error) {
+	return controlStationUtils.GetLogsFromDataServiceLimitN[domain.DeviceState](s.device.Name, limit)
+}
+
+func (s *deviceService) QueryDeviceLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.DeviceState], error) {
+	return controlStationUtils.QueryLogsFromDataService[domain.DeviceState](s.device.Name, query)
+}
+
+func (s *deviceService) supports(capability domain.Capability, endpoint string) bool {
+	return domain.HasCapability(s.device.Capabilities, capability) &&
+		controlStationUtils.HasCapabilityEndpoint(capability, endpoint)
+}
+
+// invoke validates and audits the command, sends it to the device and tracks
+// the movement it starts.
+func (s *deviceService) invoke(ctx context.Context, capability domain.Capability, endpoint string,
+	reqBody domain.DeviceState, source string) (domain.DeviceState, error) {
+	request, err := s.validateRequest(capability, endpoint, reqBody)
+	if err != nil {
+		return domain.DeviceState{}, err
+	}
+
+	audited := controlStationUtils.IsAudited(capability)
+	if audited {
+		if err := s.auditAttempt(commandName(endpoint), source); err != nil {
+			return domain.DeviceState{}, err
+		}
+	}
+
+	address := s.device.Address + endpoint
+	var deviceState domain.DeviceState
+	if request == nil {
+		deviceState, err = controlStationUtils.MakePatchRequestContext(ctx, address, s.device.Name, nil,
+			domain.DeviceState{})
+	} else {
+		deviceState, err = controlStationUtils.MakePatchRequestWithBodyContext(ctx, address, s.device.Name, request,
+			domain.DeviceState{})
+	}
+	if err == nil {
+		deviceState = withBatteryState(deviceState)
+		if capability == domain.CapabilityLockable && deviceState.String("state") == string(domain.LockStateJammed) {
+			err = ErrJammed
+		}
+	}
+	if audited {
+		s.auditOutcome(commandName(endpoint), source, deviceState, err)
+	}
+	if err != nil && !errors.Is(err, ErrJammed) {
+		return deviceState, err
+	}
+
+	s.cacheState(deviceState)
+	s.publishDetection(deviceState)
+	if err == nil && deviceState.Bool("moving") {
+		s.trackMovement()
+	}
+	return deviceState, err
+}
+
+func (s *deviceService) cacheState(deviceState domain.DeviceState) {
+	if s.stateCache != nil {
+		s.stateCache.Set(s.device.Name, deviceState)
+	}
+}
+
+// validateRequest checks the request of a capability with the validation the
+// device kinds serving the capability use, and returns the request to send to
+// the device. The optional body of the other capabilities is passed on as is.
+func (s *deviceService) validateRequest(capability domain.Capability, endpoint string,
+	reqBody domain.DeviceState) (any, error) {
+	validationErr := &controlStationUtils.ValidationError{}
+	var request any
+	if reqBody != nil {
+		request = reqBody
+	}
+	switch capability {
+	case domain.CapabilityDimmable:
+		var brightnessRequest domain.LightBrightnessRequest
+		if decodeRequest(validationErr, reqBody, &brightnessRequest, "brightness") {
+			controlStationUtils.ValidateBrightness(validationErr, brightnessRequest, s.lightCapabilities())
+		}
+		request = &brightnessRequest
+	case domain.CapabilityColor:
+		var colorRequest domain.LightColorRequest
+		if decodeRequest(validationErr, reqBody, &colorRequest) {
+			controlStationUtils.ValidateColor(validationErr, colorRequest, s.lightCapabilities())
+		}
+		request = &colorRequest
+	case domain.CapabilityThermostat:
+		var settings domain.ACInfo
+		if decodeRequest(validationErr, reqBody, &settings, "temperature", "humidity") {
+			controlStationUtils.ValidateACSettings(validationErr, settings, s.acCapabilities())
+		}
+		request = &settings
+	case domain.CapabilityPositionable:
+		if endpoint != controlStationUtils.PositionEndpoint {
+			break
+		}
+		var positionRequest domain.CoverPositionRequest
+		if decodeRequest(validationErr, reqBody, &positionRequest) {
+			controlStationUtils.ValidatePosition(validationErr, positionRequest)
+		}
+		request = &positionRequest
+	}
+	return request, validationErr.ErrorOrNil()
+}
+
+// lightCapabilities returns the configured light capabilities, or plain
+// dimming when the device declares the dimmable capability without them.
+func (s *deviceService) lightCapabilities() domain.LightCapabilities {
+	if s.device.LightCapabilities != nil {
+		return *s.device.LightCapabilities
+	}
+	return domain.LightCapabilities{Dimmable: domain.HasCapability(s.device.Capabilities, domain.CapabilityDimmable)}
+}
+
+func (s *deviceService) acCapabilities() domain.ACCapabilities {
+	if s.device.ACCapabilities != nil {
+		return *s.device.ACCapabilities
+	}
+	return domain.DefaultACCapabilities()
+}
+
+// decodeRequest decodes the request body into the request of a capability and
+// reports whether it could, adding the missing required fields otherwise.
+func decodeRequest(validationErr *controlStationUtils.ValidationError, reqBody domain.DeviceState, request any,
+	required ...string) bool {
+	for _, field := range required {
+		if _, ok := reqBody[field]; !ok {
+			validationErr.Add(field, "is required")
+		}
+	}
+	if len(validationErr.Errors) > 0 {
+		return false
+	}
+
+	encoded, err := json.Marshal(reqBody)
+	if err == nil {
+		err = json.Unmarshal(encoded, request)
+	}
+	if err != nil {
+		validationErr.Add("body", "does not match the request of the capability")
+		return false
+	}
+	return true
+}
+
+// publishDetection publishes the first detected state of the device and an
+// event whenever it changes afterwards. A disabled device does not detect
+// anything.
+func (s *deviceService) publishDetection(deviceState domain.DeviceState) {
+	if s.eventBus == nil || !domain.HasCapability(s.device.Capabilities, domain.CapabilityDetectable) {
+		return
+	}
+
+	// The event is published under the lock, so that concurrent readings can not
+	// publish their changes in a different order than they were detected.
+	detected := deviceState.IsEnabled() && deviceState.Bool("detected")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reported && detected == s.detected {
+		return
+	}
+	s.detected = detected
+	s.reported = true
+
+	eventType := domain.EventCleared
+	if detected {
+		eventType = domain.EventDetected
+	}
+	s.eventBus.Publish(domain.DeviceEvent{Device: s.device.Name, Type: eventType, Time: s.now()})
 }
 
-func (s *deviceService) GetDeviceLogsFromDataServiceLimitN(limit int) ([]domain.DeviceData, error) {
-	return controlStationUtils.GetLogsFromDataServiceLimitN[domain.DeviceData](s.device.Name, limit)
+// withBatteryState flags a low battery of a device that reports its battery
+// level but not whether the battery is low.
+func withBatteryState(deviceState domain.DeviceState) domain.DeviceState {
+	if level, ok := deviceState.Int("battery_level"); ok && level <= lowBatteryLevel {
+		deviceState["low_battery"] = true
+	}
+	return deviceState
 }
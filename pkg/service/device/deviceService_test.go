@@ -1,19 +1,25 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/event"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
 	"github.com/stretchr/testify/assert"
 )
 
+var sensorCapabilities = []domain.Capability{domain.CapabilitySwitchable, domain.CapabilityDetectable}
+
 func TestNewDeviceService(t *testing.T) {
-	device := domain.Device{Name: "test", Address: "http://test"}
-	service := NewDeviceService(&device)
+	device := domain.Device{Name: "test", Address: "http://test", Capabilities: sensorCapabilities}
+	service := NewDeviceService(&device, event.NewBus(), nil, domain.DeviceConfig{})
 	assert.NotNil(t, service)
 }
 
@@ -21,7 +27,7 @@ func TestGetInfo(t *testing.T) {
 	tests := []struct {
 		name    string
 		ts      *httptest.Server
-		want    domain.DeviceInfo
+		want    domain.DeviceState
 		wantErr bool
 	}{
 		{
@@ -29,9 +35,9 @@ func TestGetInfo(t *testing.T) {
 			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusOK)
-				json.NewEncoder(w).Encode(domain.DeviceInfo{Enabled: true})
+				json.NewEncoder(w).Encode(domain.SensorInfo{Enabled: true, Detected: false})
 			})),
-			want:    domain.DeviceInfo{Enabled: true},
+			want:    domain.DeviceState{"enabled": true, "detected": false},
 			wantErr: false,
 		},
 		{
@@ -41,14 +47,24 @@ func TestGetInfo(t *testing.T) {
 				w.WriteHeader(http.StatusInternalServerError)
 			},
 			)),
-			want:    domain.DeviceInfo{},
+			want:    domain.DeviceState{},
+			wantErr: true,
+		},
+		{
+			name: "Unmarshal failure",
+			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("[true]"))
+			},
+			)),
+			want:    domain.DeviceState{},
 			wantErr: true,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			defer test.ts.Close()
-			service := &deviceService{device: &domain.Device{Name: "test", Address: test.ts.URL}}
+			service := &deviceService{device: &domain.Device{Name: "test", Address: test.ts.URL, Capabilities: sensorCapabilities}}
 			got, err := service.GetInfo()
 
 			assert.Equal(t, test.want, got)
@@ -57,67 +73,119 @@ func TestGetInfo(t *testing.T) {
 	}
 }
 
-func TestToggleEnabled(t *testing.T) {
+func TestInvoke(t *testing.T) {
 	tests := []struct {
-		name    string
-		ts      *httptest.Server
-		want    domain.DeviceInfo
-		wantErr bool
+		name         string
+		capability   domain.Capability
+		reqBody      domain.DeviceState
+		status       int
+		wantPath     string
+		wantReqBody  string
+		want         domain.DeviceState
+		wantErr      bool
+		wantNotFound bool
 	}{
 		{
-			name: "Success",
-			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusOK)
-				json.NewEncoder(w).Encode(domain.DeviceInfo{Enabled: true})
-			})),
-			want:    domain.DeviceInfo{Enabled: true},
-			wantErr: false,
+			name:       "ToggleEnabled",
+			capability: domain.CapabilitySwitchable,
+			status:     http.StatusOK,
+			wantPath:   "/enabled",
+			want:       domain.DeviceState{"enabled": true, "detected": false},
 		},
 		{
-			name: "Failure",
-			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusInternalServerError)
-			},
-			)),
-			want:    domain.DeviceInfo{},
-			wantErr: true,
+			name:       "ToggleDetected",
+			capability: domain.CapabilityDetectable,
+			status:     http.StatusOK,
+			wantPath:   "/detected",
+			want:       domain.DeviceState{"enabled": true, "detected": false},
+		},
+		{
+			name:        "WithBody",
+			capability:  domain.CapabilitySwitchable,
+			reqBody:     domain.DeviceState{"enabled": true},
+			status:      http.StatusOK,
+			wantPath:    "/enabled",
+			wantReqBody: `{"enabled":true}`,
+			want:        domain.DeviceState{"enabled": true, "detected": false},
+		},
+		{
+			name:       "Failure",
+			capability: domain.CapabilitySwitchable,
+			status:     http.StatusInternalServerError,
+			wantPath:   "/enabled",
+			want:       domain.DeviceState{},
+			wantErr:    true,
+		},
+		{
+			name:         "NotDeclared",
+			capability:   domain.CapabilityDimmable,
+			want:         domain.DeviceState{},
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:         "ReadOnly",
+			capability:   domain.CapabilityMetering,
+			want:         domain.DeviceState{},
+			wantErr:      true,
+			wantNotFound: true,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			defer test.ts.Close()
-			service := &deviceService{device: &domain.Device{Name: "test", Address: test.ts.URL}}
-			got, err := service.ToggleEnabled()
+			var gotPath, gotReqBody string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPatch {
+					gotPath = r.URL.Path
+					body := make([]byte, 64)
+					n, _ := r.Body.Read(body)
+					gotReqBody = string(body[:n])
+				}
+				w.WriteHeader(test.status)
+				json.NewEncoder(w).Encode(domain.SensorInfo{Enabled: true, Detected: false})
+			}))
+			defer ts.Close()
+			t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+			capabilities := append(sensorCapabilities, domain.CapabilityMetering)
+			service := &deviceService{device: &domain.Device{Name: "test", Address: ts.URL, Capabilities: capabilities}}
+			endpoint, _ := controlStationUtils.CapabilityEndpoint(test.capability)
+			got, err := service.Invoke(context.Background(), test.capability, endpoint, test.reqBody, "")
 
 			assert.Equal(t, test.want, got)
 			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.wantNotFound, err == ErrCapabilityNotSupported)
+			assert.Equal(t, test.wantPath, gotPath)
+			assert.Equal(t, test.wantReqBody, gotReqBody)
 		})
 	}
 }
 
+func TestGetCapabilities(t *testing.T) {
+	service := NewDeviceService(&domain.Device{Name: "test", Address: "http://test", Capabilities: sensorCapabilities}, nil, nil, domain.DeviceConfig{})
+	assert.Equal(t, sensorCapabilities, service.GetCapabilities())
+}
+
 func TestGetDeviceLogsFromDataServiceLimitN(t *testing.T) {
 	tests := []struct {
 		name    string
 		ts      *httptest.Server
-		want    []domain.DeviceData
+		want    []domain.DeviceState
 		limit   int
 		wantErr bool
 	}{
 		{
 			name: "Success",
 			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusOK)
-				json.NewEncoder(w).Encode([]domain.DeviceData{
-					{ID: 1, CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), IsEnabled: true},
-					{ID: 2, CreatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), IsEnabled: false},
-				})
+				w.Write([]byte(`[
+					{"id": 1, "created_at": "2023-01-01T00:00:00Z", "is_enabled": true, "detected": false},
+					{"id": 2, "created_at": "2023-01-02T00:00:00Z", "is_enabled": false, "detected": true}
+				]`))
 			})),
-			want: []domain.DeviceData{
-				{ID: 1, CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), IsEnabled: true},
-				{ID: 2, CreatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), IsEnabled: false},
+			want: []domain.DeviceState{
+				{"id": float64(1), "created_at": "2023-01-01T00:00:00Z", "is_enabled": true, "detected": false},
+				{"id": float64(2), "created_at": "2023-01-02T00:00:00Z", "is_enabled": false, "detected": true},
 			},
 			limit:   2,
 			wantErr: false,
@@ -129,7 +197,7 @@ func TestGetDeviceLogsFromDataServiceLimitN(t *testing.T) {
 				w.WriteHeader(http.StatusInternalServerError)
 			},
 			)),
-			want:    []domain.DeviceData(nil),
+			want:    []domain.DeviceState(nil),
 			limit:   2,
 			wantErr: true,
 		},
@@ -138,11 +206,11 @@ func TestGetDeviceLogsFromDataServiceLimitN(t *testing.T) {
 			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusOK)
-				json.NewEncoder(w).Encode("invalid json")
+				w.Write([]byte("invalid json"))
 			},
 			)),
-			want:    []domain.DeviceData(nil),
-			limit:   10,
+			want:    []domain.DeviceState(nil),
+			limit:   2,
 			wantErr: true,
 		},
 	}
@@ -158,3 +226,158 @@ func TestGetDeviceLogsFromDataServiceLimitN(t *testing.T) {
 		})
 	}
 }
+
+func TestGetInfo_PublishesDetection(t *testing.T) {
+	readings := []domain.SensorInfo{
+		{Enabled: true, Detected: false},
+		{Enabled: true, Detected: true},
+		{Enabled: true, Detected: true},
+		{Enabled: false, Detected: true},
+	}
+	reading := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Path == "/info" {
+			json.NewEncoder(w).Encode(readings[reading])
+			reading++
+		}
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	eventBus := event.NewBus()
+	var events []domain.DeviceEvent
+	eventBus.Subscribe(func(e domain.DeviceEvent) { events = append(events, e) })
+	service := &deviceService{device: &domain.Device{Name: "doorsSensor", Address: ts.URL, Capabilities: sensorCapabilities},
+		eventBus: eventBus, now: func() time.Time { return now }}
+
+	for range readings {
+		_, err := service.GetInfo()
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, []domain.DeviceEvent{
+		{Device: "doorsSensor", Type: domain.EventCleared, Time: now},
+		{Device: "doorsSensor", Type: domain.EventDetected, Time: now},
+		{Device: "doorsSensor", Type: domain.EventCleared, Time: now},
+	}, events)
+}
+
+func TestReadInfo_DoesNotLog(t *testing.T) {
+	var logged bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			logged = true
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(domain.SensorInfo{Enabled: true, Detected: true})
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	eventBus := event.NewBus()
+	var events []domain.DeviceEvent
+	eventBus.Subscribe(func(e domain.DeviceEvent) { events = append(events, e) })
+	device := domain.Device{Name: "doorsSensor", Address: ts.URL, Capabilities: sensorCapabilities}
+	service := NewDeviceService(&device, eventBus, nil, domain.DeviceConfig{})
+
+	got, err := service.ReadInfo(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeviceState{"enabled": true, "detected": true}, got)
+	assert.False(t, logged)
+	assert.Empty(t, events)
+}
+
+func TestInvoke_ValidatesCapabilityRequests(t *testing.T) {
+	position := 150
+	lightCapabilities := domain.LightCapabilities{Dimmable: true, SupportsRGB: true, MaxTransitionMs: 1000}
+	tests := []struct {
+		name        string
+		capability  domain.Capability
+		reqBody     domain.DeviceState
+		wantReqBody string
+		wantErrors  []controlStationUtils.FieldError
+	}{
+		{
+			name:        "Brightness",
+			capability:  domain.CapabilityDimmable,
+			reqBody:     domain.DeviceState{"brightness": 40, "transition_ms": 500, "unknown": true},
+			wantReqBody: `{"brightness":40,"transition_ms":500}`,
+		},
+		{
+			name:       "BrightnessMissing",
+			capability: domain.CapabilityDimmable,
+			wantErrors: []controlStationUtils.FieldError{{Field: "brightness", Message: "is required"}},
+		},
+		{
+			name:       "BrightnessOutOfRange",
+			capability: domain.CapabilityDimmable,
+			reqBody:    domain.DeviceState{"brightness": 140},
+			wantErrors: []controlStationUtils.FieldError{{Field: "brightness", Message: "must be between 0 and 100"}},
+		},
+		{
+			name:        "Color",
+			capability:  domain.CapabilityColor,
+			reqBody:     domain.DeviceState{"rgb": map[string]int{"red": 255, "green": 0, "blue": 0}},
+			wantReqBody: `{"rgb":{"red":255,"green":0,"blue":0}}`,
+		},
+		{
+			name:       "ColorNotSupported",
+			capability: domain.CapabilityColor,
+			reqBody:    domain.DeviceState{"color_temperature": 3000},
+			wantErrors: []controlStationUtils.FieldError{{Field: "color_temperature", Message: "is not supported"}},
+		},
+		{
+			name:       "Thermostat",
+			capability: domain.CapabilityThermostat,
+			reqBody:    domain.DeviceState{"temperature": 21.5, "humidity": 45, "mode": "cool"},
+			wantErrors: []controlStationUtils.FieldError{{Field: "mode", Message: "is not supported"}},
+		},
+		{
+			name:       "Position",
+			capability: domain.CapabilityPositionable,
+			reqBody:    domain.DeviceState{"position": position},
+			wantErrors: []controlStationUtils.FieldError{{Field: "position", Message: "must be between 0 and 100"}},
+		},
+		{
+			name:       "InvalidBody",
+			capability: domain.CapabilityDimmable,
+			reqBody:    domain.DeviceState{"brightness": "bright"},
+			wantErrors: []controlStationUtils.FieldError{{Field: "body", Message: "does not match the request of the capability"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotReqBody string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPatch {
+					body, _ := io.ReadAll(r.Body)
+					gotReqBody = string(body)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"enabled": true}`))
+			}))
+			defer ts.Close()
+			t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+			device := domain.Device{Name: "test", Address: ts.URL, LightCapabilities: &lightCapabilities,
+				Capabilities: []domain.Capability{domain.CapabilityDimmable, domain.CapabilityColor,
+					domain.CapabilityThermostat, domain.CapabilityPositionable}}
+			service := NewDeviceService(&device, nil, nil, domain.DeviceConfig{})
+			endpoint, _ := controlStationUtils.CapabilityEndpoint(test.capability)
+			_, err := service.Invoke(context.Background(), test.capability, endpoint, test.reqBody, "")
+
+			if test.wantErrors == nil {
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.wantReqBody, gotReqBody)
+				return
+			}
+			var validationErr *controlStationUtils.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, test.wantErrors, validationErr.Errors)
+			assert.Empty(t, gotReqBody)
+		})
+	}
+}
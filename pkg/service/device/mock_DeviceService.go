@@ -3,6 +3,8 @@
 package service
 
 import (
+	context "context"
+
 	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -20,20 +22,175 @@ func (_m *MockDeviceService) EXPECT() *MockDeviceService_Expecter {
 	return &MockDeviceService_Expecter{mock: &_m.Mock}
 }
 
+// Confirm provides a mock function with given fields: ctx, capability, endpoint, token, source
+func (_m *MockDeviceService) Confirm(ctx context.Context, capability domain.Capability, endpoint string, token string, source string) (domain.DeviceState, error) {
+	ret := _m.Called(ctx, capability, endpoint, token, source)
+
+	var r0 domain.DeviceState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Capability, string, string, string) (domain.DeviceState, error)); ok {
+		return rf(ctx, capability, endpoint, token, source)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Capability, string, string, string) domain.DeviceState); ok {
+		r0 = rf(ctx, capability, endpoint, token, source)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.DeviceState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Capability, string, string, string) error); ok {
+		r1 = rf(ctx, capability, endpoint, token, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeviceService_Confirm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Confirm'
+type MockDeviceService_Confirm_Call struct {
+	*mock.Call
+}
+
+// Confirm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - capability domain.Capability
+//   - endpoint string
+//   - token string
+//   - source string
+func (_e *MockDeviceService_Expecter) Confirm(ctx interface{}, capability interface{}, endpoint interface{}, token interface{}, source interface{}) *MockDeviceService_Confirm_Call {
+	return &MockDeviceService_Confirm_Call{Call: _e.mock.On("Confirm", ctx, capability, endpoint, token, source)}
+}
+
+func (_c *MockDeviceService_Confirm_Call) Run(run func(ctx context.Context, capability domain.Capability, endpoint string, token string, source string)) *MockDeviceService_Confirm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Capability), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockDeviceService_Confirm_Call) Return(_a0 domain.DeviceState, _a1 error) *MockDeviceService_Confirm_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeviceService_Confirm_Call) RunAndReturn(run func(context.Context, domain.Capability, string, string, string) (domain.DeviceState, error)) *MockDeviceService_Confirm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuditLogsFromDataServiceLimitN provides a mock function with given fields: limit
+func (_m *MockDeviceService) GetAuditLogsFromDataServiceLimitN(limit int) ([]domain.AuditEntry, error) {
+	ret := _m.Called(limit)
+
+	var r0 []domain.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]domain.AuditEntry, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []domain.AuditEntry); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeviceService_GetAuditLogsFromDataServiceLimitN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogsFromDataServiceLimitN'
+type MockDeviceService_GetAuditLogsFromDataServiceLimitN_Call struct {
+	*mock.Call
+}
+
+// GetAuditLogsFromDataServiceLimitN is a helper method to define mock.On call
+//   - limit int
+func (_e *MockDeviceService_Expecter) GetAuditLogsFromDataServiceLimitN(limit interface{}) *MockDeviceService_GetAuditLogsFromDataServiceLimitN_Call {
+	return &MockDeviceService_GetAuditLogsFromDataServiceLimitN_Call{Call: _e.mock.On("GetAuditLogsFromDataServiceLimitN", limit)}
+}
+
+func (_c *MockDeviceService_GetAuditLogsFromDataServiceLimitN_Call) Run(run func(limit int)) *MockDeviceService_GetAuditLogsFromDataServiceLimitN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockDeviceService_GetAuditLogsFromDataServiceLimitN_Call) Return(_a0 []domain.AuditEntry, _a1 error) *MockDeviceService_GetAuditLogsFromDataServiceLimitN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeviceService_GetAuditLogsFromDataServiceLimitN_Call) RunAndReturn(run func(int) ([]domain.AuditEntry, error)) *MockDeviceService_GetAuditLogsFromDataServiceLimitN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCapabilities provides a mock function with given fields:
+func (_m *MockDeviceService) GetCapabilities() []domain.Capability {
+	ret := _m.Called()
+
+	var r0 []domain.Capability
+	if rf, ok := ret.Get(0).(func() []domain.Capability); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Capability)
+		}
+	}
+
+	return r0
+}
+
+// MockDeviceService_GetCapabilities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCapabilities'
+type MockDeviceService_GetCapabilities_Call struct {
+	*mock.Call
+}
+
+// GetCapabilities is a helper method to define mock.On call
+func (_e *MockDeviceService_Expecter) GetCapabilities() *MockDeviceService_GetCapabilities_Call {
+	return &MockDeviceService_GetCapabilities_Call{Call: _e.mock.On("GetCapabilities")}
+}
+
+func (_c *MockDeviceService_GetCapabilities_Call) Run(run func()) *MockDeviceService_GetCapabilities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockDeviceService_GetCapabilities_Call) Return(_a0 []domain.Capability) *MockDeviceService_GetCapabilities_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDeviceService_GetCapabilities_Call) RunAndReturn(run func() []domain.Capability) *MockDeviceService_GetCapabilities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetDeviceLogsFromDataServiceLimitN provides a mock function with given fields: limit
-func (_m *MockDeviceService) GetDeviceLogsFromDataServiceLimitN(limit int) ([]domain.DeviceData, error) {
+func (_m *MockDeviceService) GetDeviceLogsFromDataServiceLimitN(limit int) ([]domain.DeviceState, error) {
 	ret := _m.Called(limit)
 
-	var r0 []domain.DeviceData
+	var r0 []domain.DeviceState
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) ([]domain.DeviceData, error)); ok {
+	if rf, ok := ret.Get(0).(func(int) ([]domain.DeviceState, error)); ok {
 		return rf(limit)
 	}
-	if rf, ok := ret.Get(0).(func(int) []domain.DeviceData); ok {
+	if rf, ok := ret.Get(0).(func(int) []domain.DeviceState); ok {
 		r0 = rf(limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.DeviceData)
+			r0 = ret.Get(0).([]domain.DeviceState)
 		}
 	}
 
@@ -64,29 +221,31 @@ func (_c *MockDeviceService_GetDeviceLogsFromDataServiceLimitN_Call) Run(run fun
 	return _c
 }
 
-func (_c *MockDeviceService_GetDeviceLogsFromDataServiceLimitN_Call) Return(_a0 []domain.DeviceData, _a1 error) *MockDeviceService_GetDeviceLogsFromDataServiceLimitN_Call {
+func (_c *MockDeviceService_GetDeviceLogsFromDataServiceLimitN_Call) Return(_a0 []domain.DeviceState, _a1 error) *MockDeviceService_GetDeviceLogsFromDataServiceLimitN_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDeviceService_GetDeviceLogsFromDataServiceLimitN_Call) RunAndReturn(run func(int) ([]domain.DeviceData, error)) *MockDeviceService_GetDeviceLogsFromDataServiceLimitN_Call {
+func (_c *MockDeviceService_GetDeviceLogsFromDataServiceLimitN_Call) RunAndReturn(run func(int) ([]domain.DeviceState, error)) *MockDeviceService_GetDeviceLogsFromDataServiceLimitN_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetInfo provides a mock function with given fields:
-func (_m *MockDeviceService) GetInfo() (domain.DeviceInfo, error) {
+func (_m *MockDeviceService) GetInfo() (domain.DeviceState, error) {
 	ret := _m.Called()
 
-	var r0 domain.DeviceInfo
+	var r0 domain.DeviceState
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (domain.DeviceInfo, error)); ok {
+	if rf, ok := ret.Get(0).(func() (domain.DeviceState, error)); ok {
 		return rf()
 	}
-	if rf, ok := ret.Get(0).(func() domain.DeviceInfo); ok {
+	if rf, ok := ret.Get(0).(func() domain.DeviceState); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(domain.DeviceInfo)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.DeviceState)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func() error); ok {
@@ -115,63 +274,281 @@ func (_c *MockDeviceService_GetInfo_Call) Run(run func()) *MockDeviceService_Get
 	return _c
 }
 
-func (_c *MockDeviceService_GetInfo_Call) Return(_a0 domain.DeviceInfo, _a1 error) *MockDeviceService_GetInfo_Call {
+func (_c *MockDeviceService_GetInfo_Call) Return(_a0 domain.DeviceState, _a1 error) *MockDeviceService_GetInfo_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDeviceService_GetInfo_Call) RunAndReturn(run func() (domain.DeviceInfo, error)) *MockDeviceService_GetInfo_Call {
+func (_c *MockDeviceService_GetInfo_Call) RunAndReturn(run func() (domain.DeviceState, error)) *MockDeviceService_GetInfo_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ToggleEnabled provides a mock function with given fields:
-func (_m *MockDeviceService) ToggleEnabled() (domain.DeviceInfo, error) {
+// GetState provides a mock function with given fields:
+func (_m *MockDeviceService) GetState() (domain.CachedState, bool) {
 	ret := _m.Called()
 
-	var r0 domain.DeviceInfo
-	var r1 error
-	if rf, ok := ret.Get(0).(func() (domain.DeviceInfo, error)); ok {
+	var r0 domain.CachedState
+	var r1 bool
+	if rf, ok := ret.Get(0).(func() (domain.CachedState, bool)); ok {
 		return rf()
 	}
-	if rf, ok := ret.Get(0).(func() domain.DeviceInfo); ok {
+	if rf, ok := ret.Get(0).(func() domain.CachedState); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(domain.DeviceInfo)
+		r0 = ret.Get(0).(domain.CachedState)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
+	if rf, ok := ret.Get(1).(func() bool); ok {
 		r1 = rf()
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(bool)
 	}
 
 	return r0, r1
 }
 
-// MockDeviceService_ToggleEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ToggleEnabled'
-type MockDeviceService_ToggleEnabled_Call struct {
+// MockDeviceService_GetState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetState'
+type MockDeviceService_GetState_Call struct {
 	*mock.Call
 }
 
-// ToggleEnabled is a helper method to define mock.On call
-func (_e *MockDeviceService_Expecter) ToggleEnabled() *MockDeviceService_ToggleEnabled_Call {
-	return &MockDeviceService_ToggleEnabled_Call{Call: _e.mock.On("ToggleEnabled")}
+// GetState is a helper method to define mock.On call
+func (_e *MockDeviceService_Expecter) GetState() *MockDeviceService_GetState_Call {
+	return &MockDeviceService_GetState_Call{Call: _e.mock.On("GetState")}
 }
 
-func (_c *MockDeviceService_ToggleEnabled_Call) Run(run func()) *MockDeviceService_ToggleEnabled_Call {
+func (_c *MockDeviceService_GetState_Call) Run(run func()) *MockDeviceService_GetState_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *MockDeviceService_ToggleEnabled_Call) Return(_a0 domain.DeviceInfo, _a1 error) *MockDeviceService_ToggleEnabled_Call {
+func (_c *MockDeviceService_GetState_Call) Return(_a0 domain.CachedState, _a1 bool) *MockDeviceService_GetState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeviceService_GetState_Call) RunAndReturn(run func() (domain.CachedState, bool)) *MockDeviceService_GetState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Invoke provides a mock function with given fields: ctx, capability, endpoint, reqBody, source
+func (_m *MockDeviceService) Invoke(ctx context.Context, capability domain.Capability, endpoint string, reqBody domain.DeviceState, source string) (domain.DeviceState, error) {
+	ret := _m.Called(ctx, capability, endpoint, reqBody, source)
+
+	var r0 domain.DeviceState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Capability, string, domain.DeviceState, string) (domain.DeviceState, error)); ok {
+		return rf(ctx, capability, endpoint, reqBody, source)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Capability, string, domain.DeviceState, string) domain.DeviceState); ok {
+		r0 = rf(ctx, capability, endpoint, reqBody, source)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.DeviceState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Capability, string, domain.DeviceState, string) error); ok {
+		r1 = rf(ctx, capability, endpoint, reqBody, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeviceService_Invoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invoke'
+type MockDeviceService_Invoke_Call struct {
+	*mock.Call
+}
+
+// Invoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - capability domain.Capability
+//   - endpoint string
+//   - reqBody domain.DeviceState
+//   - source string
+func (_e *MockDeviceService_Expecter) Invoke(ctx interface{}, capability interface{}, endpoint interface{}, reqBody interface{}, source interface{}) *MockDeviceService_Invoke_Call {
+	return &MockDeviceService_Invoke_Call{Call: _e.mock.On("Invoke", ctx, capability, endpoint, reqBody, source)}
+}
+
+func (_c *MockDeviceService_Invoke_Call) Run(run func(ctx context.Context, capability domain.Capability, endpoint string, reqBody domain.DeviceState, source string)) *MockDeviceService_Invoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Capability), args[2].(string), args[3].(domain.DeviceState), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockDeviceService_Invoke_Call) Return(_a0 domain.DeviceState, _a1 error) *MockDeviceService_Invoke_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeviceService_Invoke_Call) RunAndReturn(run func(context.Context, domain.Capability, string, domain.DeviceState, string) (domain.DeviceState, error)) *MockDeviceService_Invoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryDeviceLogsFromDataService provides a mock function with given fields: query
+func (_m *MockDeviceService) QueryDeviceLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.DeviceState], error) {
+	ret := _m.Called(query)
+
+	var r0 domain.LogPage[domain.DeviceState]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.LogQuery) (domain.LogPage[domain.DeviceState], error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(domain.LogQuery) domain.LogPage[domain.DeviceState]); ok {
+		r0 = rf(query)
+	} else {
+		r0 = ret.Get(0).(domain.LogPage[domain.DeviceState])
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.LogQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeviceService_QueryDeviceLogsFromDataService_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryDeviceLogsFromDataService'
+type MockDeviceService_QueryDeviceLogsFromDataService_Call struct {
+	*mock.Call
+}
+
+// QueryDeviceLogsFromDataService is a helper method to define mock.On call
+//   - query domain.LogQuery
+func (_e *MockDeviceService_Expecter) QueryDeviceLogsFromDataService(query interface{}) *MockDeviceService_QueryDeviceLogsFromDataService_Call {
+	return &MockDeviceService_QueryDeviceLogsFromDataService_Call{Call: _e.mock.On("QueryDeviceLogsFromDataService", query)}
+}
+
+func (_c *MockDeviceService_QueryDeviceLogsFromDataService_Call) Run(run func(query domain.LogQuery)) *MockDeviceService_QueryDeviceLogsFromDataService_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.LogQuery))
+	})
+	return _c
+}
+
+func (_c *MockDeviceService_QueryDeviceLogsFromDataService_Call) Return(_a0 domain.LogPage[domain.DeviceState], _a1 error) *MockDeviceService_QueryDeviceLogsFromDataService_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeviceService_QueryDeviceLogsFromDataService_Call) RunAndReturn(run func(domain.LogQuery) (domain.LogPage[domain.DeviceState], error)) *MockDeviceService_QueryDeviceLogsFromDataService_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadInfo provides a mock function with given fields: ctx
+func (_m *MockDeviceService) ReadInfo(ctx context.Context) (domain.DeviceState, error) {
+	ret := _m.Called(ctx)
+
+	var r0 domain.DeviceState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.DeviceState, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.DeviceState); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.DeviceState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeviceService_ReadInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadInfo'
+type MockDeviceService_ReadInfo_Call struct {
+	*mock.Call
+}
+
+// ReadInfo is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeviceService_Expecter) ReadInfo(ctx interface{}) *MockDeviceService_ReadInfo_Call {
+	return &MockDeviceService_ReadInfo_Call{Call: _e.mock.On("ReadInfo", ctx)}
+}
+
+func (_c *MockDeviceService_ReadInfo_Call) Run(run func(ctx context.Context)) *MockDeviceService_ReadInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDeviceService_ReadInfo_Call) Return(_a0 domain.DeviceState, _a1 error) *MockDeviceService_ReadInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeviceService_ReadInfo_Call) RunAndReturn(run func(context.Context) (domain.DeviceState, error)) *MockDeviceService_ReadInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestConfirmation provides a mock function with given fields: capability, endpoint, source
+func (_m *MockDeviceService) RequestConfirmation(capability domain.Capability, endpoint string, source string) (domain.CommandChallenge, error) {
+	ret := _m.Called(capability, endpoint, source)
+
+	var r0 domain.CommandChallenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Capability, string, string) (domain.CommandChallenge, error)); ok {
+		return rf(capability, endpoint, source)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Capability, string, string) domain.CommandChallenge); ok {
+		r0 = rf(capability, endpoint, source)
+	} else {
+		r0 = ret.Get(0).(domain.CommandChallenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Capability, string, string) error); ok {
+		r1 = rf(capability, endpoint, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeviceService_RequestConfirmation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestConfirmation'
+type MockDeviceService_RequestConfirmation_Call struct {
+	*mock.Call
+}
+
+// RequestConfirmation is a helper method to define mock.On call
+//   - capability domain.Capability
+//   - endpoint string
+//   - source string
+func (_e *MockDeviceService_Expecter) RequestConfirmation(capability interface{}, endpoint interface{}, source interface{}) *MockDeviceService_RequestConfirmation_Call {
+	return &MockDeviceService_RequestConfirmation_Call{Call: _e.mock.On("RequestConfirmation", capability, endpoint, source)}
+}
+
+func (_c *MockDeviceService_RequestConfirmation_Call) Run(run func(capability domain.Capability, endpoint string, source string)) *MockDeviceService_RequestConfirmation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.Capability), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDeviceService_RequestConfirmation_Call) Return(_a0 domain.CommandChallenge, _a1 error) *MockDeviceService_RequestConfirmation_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDeviceService_ToggleEnabled_Call) RunAndReturn(run func() (domain.DeviceInfo, error)) *MockDeviceService_ToggleEnabled_Call {
+func (_c *MockDeviceService_RequestConfirmation_Call) RunAndReturn(run func(domain.Capability, string, string) (domain.CommandChallenge, error)) *MockDeviceService_RequestConfirmation_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+// maxMovementDuration bounds the tracking of a single movement, so that a device
+// that never reports to have stopped is not polled forever.
+const maxMovementDuration = 2 * time.Minute
+
+// trackMovement polls a device that reported to be moving, e.g. a cover, until
+// it stops and keeps the state cache up to date meanwhile. At most one
+// movement is tracked at a time; a command issued during a movement is picked
+// up by the running tracker, also when the tracker is about to stop.
+func (s *deviceService) trackMovement() {
+	if s.stateCache == nil {
+		return
+	}
+
+	s.trackingMu.Lock()
+	defer s.trackingMu.Unlock()
+
+	if s.tracking {
+		s.movementStarted = true
+		return
+	}
+	s.tracking = true
+	s.movementStarted = false
+
+	go func() {
+		for {
+			s.followMovement()
+			if s.stopTracking() {
+				return
+			}
+		}
+	}()
+}
+
+// stopTracking ends the tracking unless a movement started while the last one
+// was followed, which the tracker follows next instead.
+func (s *deviceService) stopTracking() bool {
+	s.trackingMu.Lock()
+	defer s.trackingMu.Unlock()
+
+	if s.movementStarted {
+		s.movementStarted = false
+		return false
+	}
+	s.tracking = false
+	return true
+}
+
+// followMovement polls the device until it stops moving, fails to respond or
+// moves for longer than maxMovementDuration.
+func (s *deviceService) followMovement() {
+	address := s.device.Address + controlStationUtils.InfoEndpoint
+	deadline := time.Now().Add(maxMovementDuration)
+	for time.Now().Before(deadline) {
+		time.Sleep(s.config.PollInterval)
+
+		deviceState, err := controlStationUtils.FetchJSON[domain.DeviceState](address, s.device.Name)
+		if err != nil {
+			log.Printf("Failed to track '%s' movement: %s\n", s.device.Name, err)
+			return
+		}
+
+		s.cacheState(deviceState)
+		if !deviceState.Bool("moving") {
+			err = controlStationUtils.SendLogsToDataService(s.device.Name, deviceState)
+			if err != nil {
+				log.Printf("Failed to send '%s' logs to data service: %s\n", s.device.Name, err)
+			}
+			return
+		}
+	}
+	log.Printf("'%s' is still moving after %s, stopped tracking\n", s.device.Name, maxMovementDuration)
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+var coverCapabilities = []domain.Capability{domain.CapabilityPositionable}
+
+// fakeCover moves by step percent on every info request until it reaches the
+// target position.
+type fakeCover struct {
+	mu      sync.Mutex
+	info    domain.CoverInfo
+	step    int
+	gotPath string
+	gotBody string
+}
+
+func (f *fakeCover) server() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if r.Method == http.MethodPatch {
+			body, _ := io.ReadAll(r.Body)
+			f.gotBody = string(body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		switch r.URL.Path {
+		case "/open":
+			f.startMoving(domain.CoverPositionOpen)
+		case "/close":
+			f.startMoving(domain.CoverPositionClosed)
+		case "/position":
+			var request domain.CoverPositionRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			f.startMoving(*request.Position)
+		case "/stop":
+			f.info = domain.CoverInfo{CurrentPosition: f.info.CurrentPosition, TargetPosition: f.info.CurrentPosition}
+		case "/info":
+			f.advance()
+		}
+		if r.Method == http.MethodPatch {
+			f.gotPath = r.URL.Path
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(f.info)
+	}))
+}
+
+func (f *fakeCover) startMoving(target int) {
+	f.info.TargetPosition = target
+	f.info.Moving = target != f.info.CurrentPosition
+	f.info.Direction = ""
+	if target > f.info.CurrentPosition {
+		f.info.Direction = domain.CoverOpening
+	} else if target < f.info.CurrentPosition {
+		f.info.Direction = domain.CoverClosing
+	}
+}
+
+func (f *fakeCover) advance() {
+	if !f.info.Moving {
+		return
+	}
+	if f.info.Direction == domain.CoverOpening {
+		f.info.CurrentPosition += f.step
+	} else {
+		f.info.CurrentPosition -= f.step
+	}
+	distance := f.info.TargetPosition - f.info.CurrentPosition
+	if f.info.Direction == domain.CoverOpening && distance <= 0 || f.info.Direction == domain.CoverClosing && distance >= 0 {
+		f.info.CurrentPosition = f.info.TargetPosition
+		f.info.Moving = false
+		f.info.Direction = ""
+	}
+}
+
+func newTestCover(address string) DeviceService {
+	device := &domain.Device{Name: "test", Address: address, Capabilities: coverCapabilities}
+	return NewDeviceService(device, nil, state.NewCache(), domain.DeviceConfig{PollInterval: time.Millisecond})
+}
+
+func cachedPosition(service DeviceService) (int, bool) {
+	cached, ok := service.GetState()
+	if !ok {
+		return 0, false
+	}
+	deviceState := cached.State.(domain.DeviceState)
+	position, _ := deviceState.Int("current_position")
+	return position, !deviceState.Bool("moving")
+}
+
+func TestInvoke_TracksMovementUntilStopped(t *testing.T) {
+	tests := []struct {
+		name         string
+		start        int
+		endpoint     string
+		reqBody      domain.DeviceState
+		wantBody     string
+		wantPosition int
+	}{
+		{name: "Open", start: 0, endpoint: controlStationUtils.OpenEndpoint, wantPosition: 100},
+		{name: "Close", start: 100, endpoint: controlStationUtils.CloseEndpoint, wantPosition: 0},
+		{
+			name:         "SetPosition",
+			start:        0,
+			endpoint:     controlStationUtils.PositionEndpoint,
+			reqBody:      domain.DeviceState{"position": float64(60)},
+			wantBody:     `{"position":60}`,
+			wantPosition: 60,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			device := &fakeCover{info: domain.CoverInfo{CurrentPosition: test.start, TargetPosition: test.start}, step: 25}
+			ts := device.server()
+			defer ts.Close()
+			t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+			service := newTestCover(ts.URL)
+			got, err := service.Invoke(context.Background(), domain.CapabilityPositionable, test.endpoint, test.reqBody, "")
+
+			assert.NoError(t, err)
+			assert.True(t, got.Bool("moving"))
+			assert.Equal(t, test.endpoint, device.gotPath)
+			assert.Equal(t, test.wantBody, device.gotBody)
+			assert.Eventually(t, func() bool {
+				position, stopped := cachedPosition(service)
+				return stopped && position == test.wantPosition
+			}, time.Second, time.Millisecond)
+		})
+	}
+}
+
+func TestInvoke_TracksMovementStartedWhileTrackerStops(t *testing.T) {
+	device := &fakeCover{step: 50}
+	ts := device.server()
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestCover(ts.URL).(*deviceService)
+	// A tracker is running and has just seen the last movement stop.
+	service.tracking = true
+	got, err := service.Invoke(context.Background(), domain.CapabilityPositionable, controlStationUtils.OpenEndpoint, nil, "")
+
+	assert.NoError(t, err)
+	assert.True(t, got.Bool("moving"))
+	assert.False(t, service.stopTracking())
+	assert.True(t, service.tracking)
+
+	service.followMovement()
+	position, stopped := cachedPosition(service)
+	assert.True(t, stopped)
+	assert.Equal(t, 100, position)
+	assert.True(t, service.stopTracking())
+	assert.False(t, service.tracking)
+}
+
+func TestInvoke_Stop(t *testing.T) {
+	device := &fakeCover{info: domain.CoverInfo{CurrentPosition: 30, TargetPosition: 100, Moving: true,
+		Direction: domain.CoverOpening}}
+	ts := device.server()
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := newTestCover(ts.URL)
+	got, err := service.Invoke(context.Background(), domain.CapabilityPositionable, controlStationUtils.StopEndpoint, nil, "")
+
+	assert.NoError(t, err)
+	assert.False(t, got.Bool("moving"))
+	position, stopped := cachedPosition(service)
+	assert.True(t, stopped)
+	assert.Equal(t, 30, position)
+}
+
+func TestInvoke_PositionValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		reqBody domain.DeviceState
+		want    []controlStationUtils.FieldError
+	}{
+		{name: "Missing", want: []controlStationUtils.FieldError{{Field: "position", Message: "is required"}}},
+		{name: "BelowRange", reqBody: domain.DeviceState{"position": float64(-1)},
+			want: []controlStationUtils.FieldError{{Field: "position", Message: "must be between 0 and 100"}}},
+		{name: "AboveRange", reqBody: domain.DeviceState{"position": float64(101)},
+			want: []controlStationUtils.FieldError{{Field: "position", Message: "must be between 0 and 100"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := newTestCover("http://test")
+			_, err := service.Invoke(context.Background(), domain.CapabilityPositionable, controlStationUtils.PositionEndpoint,
+				test.reqBody, "")
+
+			var validationErr *controlStationUtils.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, test.want, validationErr.Errors)
+		})
+	}
+}
+
+func TestGetState_Empty(t *testing.T) {
+	service := newTestCover("http://test")
+	_, ok := service.GetState()
+	assert.False(t, ok)
+}
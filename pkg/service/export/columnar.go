@@ -0,0 +1,114 @@
+package service
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/utils"
+)
+
+// The columnar format stores the logs column by column in row groups, one per
+// page of logs, so that it can be written while the logs are streamed:
+//
+//	file     = magic header rowGroup* end
+//	magic    = "CSLOG" 0x01
+//	header   = uvarint(columns) (uvarint(len) name type)*
+//	rowGroup = uvarint(rows) chunk*          one chunk per column
+//	chunk    = nulls value*                  nulls has a bit per row, set when it is null
+//	end      = uvarint(0)
+//
+// The types are 1 int, 2 float, 3 bool, 4 time and 5 string. Only the rows
+// that are not null have a value: ints as zigzag varints, floats as
+// little-endian float64, bools as one byte, times as zigzag varints of Unix
+// nanoseconds and strings as uvarint length and bytes. A file without the end
+// marker was cut off.
+var columnarMagic = []byte{'C', 'S', 'L', 'O', 'G', 0x01}
+
+type columnarWriter struct {
+	w       io.Writer
+	columns []column
+}
+
+func newColumnarWriter(w io.Writer, columns []column) (*columnarWriter, error) {
+	header := append([]byte{}, columnarMagic...)
+	header = binary.AppendUvarint(header, uint64(len(columns)))
+	for _, c := range columns {
+		header = binary.AppendUvarint(header, uint64(len(c.name)))
+		header = append(header, c.name...)
+		header = append(header, byte(c.typ))
+	}
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &columnarWriter{w: w, columns: columns}, nil
+}
+
+func (w *columnarWriter) write(logs []exportedLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	group := binary.AppendUvarint(nil, uint64(len(logs)))
+	for _, c := range w.columns {
+		nulls := make([]byte, (len(logs)+7)/8)
+		var values []byte
+		for i, log := range logs {
+			if c == deviceColumn {
+				values = appendString(values, log.device)
+				continue
+			}
+			raw := log.fields[c.name]
+			if isNull(raw) {
+				nulls[i/8] |= 1 << (i % 8)
+				continue
+			}
+			var err error
+			if values, err = appendValue(values, c.typ, raw); err != nil {
+				return fmt.Errorf("%w: %s column %s: %w", utils.ErrParsingFailed, log.device, c.name, err)
+			}
+		}
+		group = append(group, nulls...)
+		group = append(group, values...)
+	}
+	_, err := w.w.Write(group)
+	return err
+}
+
+func (w *columnarWriter) close() error {
+	_, err := w.w.Write(binary.AppendUvarint(nil, 0))
+	return err
+}
+
+func appendValue(buf []byte, typ columnType, raw json.RawMessage) ([]byte, error) {
+	switch typ {
+	case columnInt:
+		var v int64
+		err := json.Unmarshal(raw, &v)
+		return binary.AppendVarint(buf, v), err
+	case columnFloat:
+		var v float64
+		err := json.Unmarshal(raw, &v)
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(v)), err
+	case columnBool:
+		var v bool
+		err := json.Unmarshal(raw, &v)
+		if v {
+			return append(buf, 1), err
+		}
+		return append(buf, 0), err
+	case columnTime:
+		var v time.Time
+		err := json.Unmarshal(raw, &v)
+		return binary.AppendVarint(buf, v.UnixNano()), err
+	}
+	return appendString(buf, text(raw)), nil
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+)
+
+type columnType byte
+
+const (
+	columnInt columnType = iota + 1
+	columnFloat
+	columnBool
+	columnTime
+	columnString
+)
+
+type column struct {
+	name string
+	typ  columnType
+}
+
+var deviceColumn = column{name: "device", typ: columnString}
+
+// kindLogs are the logs the devices of each kind send to the data service.
+// Generic devices log DeviceData.
+var kindLogs = map[domain.DeviceKind]interface{}{
+	domain.KindSensor:       domain.SensorData{},
+	domain.KindDevice:       domain.DeviceData{},
+	domain.KindAC:           domain.ACData{},
+	domain.KindLight:        domain.LightData{},
+	domain.KindPlug:         domain.PlugData{},
+	domain.KindAnalogSensor: domain.AnalogSensorData{},
+	domain.KindLock:         domain.LockData{},
+	domain.KindCover:        domain.CoverData{},
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// logColumns returns the device column followed by the fields of the logs of
+// the devices, in the order they first appear in.
+func logColumns(devices []domain.RegisteredDevice) []column {
+	columns := []column{deviceColumn}
+	seen := map[string]bool{deviceColumn.name: true}
+	for _, device := range devices {
+		for _, c := range deviceLogColumns(device) {
+			if !seen[c.name] {
+				seen[c.name] = true
+				columns = append(columns, c)
+			}
+		}
+	}
+	return columns
+}
+
+func deviceLogColumns(device domain.RegisteredDevice) []column {
+	log, ok := kindLogs[device.Kind]
+	if !ok {
+		log = domain.DeviceData{}
+	}
+	columns := structColumns(reflect.TypeOf(log))
+	if device.Kind == domain.KindDevice && domain.HasCapability(device.Capabilities, domain.CapabilityDetectable) {
+		columns = append(columns, column{name: "detected", typ: columnBool})
+	}
+	return columns
+}
+
+func structColumns(t reflect.Type) []column {
+	columns := make([]column, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		columns = append(columns, column{name: name, typ: columnTypeOf(field.Type)})
+	}
+	return columns
+}
+
+// columnTypeOf maps a field type to a column type. Nested values, like the
+// color of a light, are kept as JSON strings.
+func columnTypeOf(t reflect.Type) columnType {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return columnTime
+	case t.Kind() == reflect.Bool:
+		return columnBool
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		return columnInt
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return columnFloat
+	}
+	return columnString
+}
+
+func isNull(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(raw) == "null"
+}
+
+// text returns JSON strings unquoted and any other value as JSON.
+func text(raw json.RawMessage) string {
+	if isNull(raw) {
+		return ""
+	}
+	var s string
+	if raw[0] == '"' && json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
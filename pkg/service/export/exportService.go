@@ -0,0 +1,141 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/pklimuk-eng-thesis/control-station/utils"
+)
+
+var exportFormats = []domain.ExportFormat{domain.ExportCSV, domain.ExportNDJSON, domain.ExportColumnar}
+
+//go:generate --name ExportService --output mock_exportService.go
+type ExportService interface {
+	Export(w io.Writer, request domain.ExportRequest) (domain.ExportSummary, error)
+}
+
+type exportService struct {
+	registry registry.Registry
+}
+
+func NewExportService(registry registry.Registry) ExportService {
+	return &exportService{registry: registry}
+}
+
+// Export streams the logs of the devices to w, device by device and oldest
+// first. The logs are fetched from the data service page by page, so that only
+// one page is held in memory. Nothing is written when the request is invalid
+// or the first page can not be fetched. The summary reports the devices whose
+// logs could not be searched as far back as requested.
+func (s *exportService) Export(w io.Writer, request domain.ExportRequest) (domain.ExportSummary, error) {
+	var summary domain.ExportSummary
+	devices, err := s.devices(request)
+	if err != nil {
+		return summary, err
+	}
+
+	columns := logColumns(devices)
+	var out logWriter
+	for _, device := range devices {
+		query := domain.LogQuery{From: request.From, To: request.To, Order: domain.SortAsc,
+			Limit: controlStationUtils.MaxLogQueryLimit()}
+		for {
+			page, err := controlStationUtils.QueryLogsFromDataService[json.RawMessage](device.Name, query)
+			if err != nil {
+				return summary, err
+			}
+			logs, err := decodeLogs(device.Name, page.Logs)
+			if err != nil {
+				return summary, err
+			}
+			if page.Truncated {
+				summary.Truncated = true
+				if summary.ScannedFrom == nil || (page.ScannedFrom != nil && page.ScannedFrom.After(*summary.ScannedFrom)) {
+					summary.ScannedFrom = page.ScannedFrom
+				}
+			}
+
+			if out == nil {
+				if out, err = newLogWriter(w, request.Format, columns); err != nil {
+					return summary, err
+				}
+			}
+			if err := out.write(logs); err != nil {
+				return summary, err
+			}
+
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+	}
+	// Without any device nothing was fetched, the export is still a valid empty
+	// file.
+	if out == nil {
+		if out, err = newLogWriter(w, request.Format, columns); err != nil {
+			return summary, err
+		}
+	}
+	return summary, out.close()
+}
+
+func (s *exportService) devices(request domain.ExportRequest) ([]domain.RegisteredDevice, error) {
+	validationErr := &controlStationUtils.ValidationError{}
+	if !validFormat(request.Format) {
+		validationErr.Add("format", fmt.Sprintf("must be one of %v", exportFormats))
+	}
+	if request.From != nil && request.To != nil && !request.From.Before(*request.To) {
+		validationErr.Add("to", "must be after from")
+	}
+	if err := validationErr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	if len(request.Devices) == 0 {
+		return s.registry.List(), nil
+	}
+	devices := make([]domain.RegisteredDevice, 0, len(request.Devices))
+	for _, name := range request.Devices {
+		device, err := s.registry.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+func validFormat(format domain.ExportFormat) bool {
+	for _, f := range exportFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// exportedLog is a log with its fields left encoded, as the writers convert
+// them by the type of their column.
+type exportedLog struct {
+	device string
+	raw    json.RawMessage
+	fields map[string]json.RawMessage
+}
+
+func decodeLogs(deviceName string, logs []json.RawMessage) ([]exportedLog, error) {
+	exported := make([]exportedLog, 0, len(logs))
+	for _, raw := range logs {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, controlStationUtils.NewServiceError(controlStationUtils.CodeInvalidResponse,
+				fmt.Errorf("%w: %s: %w", utils.ErrParsingFailed, deviceName, err))
+		}
+		exported = append(exported, exportedLog{device: deviceName, raw: raw, fields: fields})
+	}
+	return exported, nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var testLogs = map[string]string{
+	"presenceSensor": `[
+		{"id": 2, "created_at": "2023-01-01T02:00:00Z", "is_enabled": true, "detected": true},
+		{"id": 1, "created_at": "2023-01-01T01:00:00Z", "is_enabled": true, "detected": false}
+	]`,
+	"smartPlug": `[{"id": 7, "created_at": "2023-01-01T03:00:00Z", "is_enabled": false, "power": 12.5}]`,
+}
+
+// newTestService serves the logs from a data service without the query
+// endpoint and pages through them one log at a time.
+func newTestService(t *testing.T) ExportService {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, logs := range testLogs {
+			if r.URL.Path == "/"+name+"/latest" {
+				fmt.Fprintln(w, logs)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(ts.Close)
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+	controlStationUtils.SetLogQueryLimits(1, 100)
+	t.Cleanup(func() {
+		controlStationUtils.SetLogQueryLimits(controlStationUtils.DefaultMaxLogQueryLimit,
+			controlStationUtils.DefaultLogQueryScanLimit)
+	})
+
+	deviceRegistry := registry.NewRegistry()
+	deviceRegistry.Register(domain.RegisteredDevice{Name: "presenceSensor", Kind: domain.KindSensor})
+	deviceRegistry.Register(domain.RegisteredDevice{Name: "smartPlug", Kind: domain.KindPlug})
+	return NewExportService(deviceRegistry)
+}
+
+func TestExport_CSV(t *testing.T) {
+	service := newTestService(t)
+	var buf bytes.Buffer
+
+	_, err := service.Export(&buf, domain.ExportRequest{Format: domain.ExportCSV})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "device,id,created_at,is_enabled,detected,power,voltage,current,energy\n"+
+		"presenceSensor,1,2023-01-01T01:00:00Z,true,false,,,,\n"+
+		"presenceSensor,2,2023-01-01T02:00:00Z,true,true,,,,\n"+
+		"smartPlug,7,2023-01-01T03:00:00Z,false,,12.5,,,\n", buf.String())
+}
+
+func TestExport_NDJSON(t *testing.T) {
+	service := newTestService(t)
+	var buf bytes.Buffer
+	from := time.Date(2023, 1, 1, 2, 0, 0, 0, time.UTC)
+
+	_, err := service.Export(&buf, domain.ExportRequest{Devices: []string{"presenceSensor"}, Format: domain.ExportNDJSON,
+		From: &from})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"device":"presenceSensor","id":2,"created_at":"2023-01-01T02:00:00Z","is_enabled":true,"detected":true}`+"\n",
+		buf.String())
+}
+
+func TestExport_Columnar(t *testing.T) {
+	service := newTestService(t)
+	var buf bytes.Buffer
+
+	_, err := service.Export(&buf, domain.ExportRequest{Devices: []string{"smartPlug"}, Format: domain.ExportColumnar})
+
+	assert.NoError(t, err)
+	expected := append([]byte{}, columnarMagic...)
+	expected = append(expected, 8)
+	for _, c := range []column{{"device", columnString}, {"id", columnInt}, {"created_at", columnTime},
+		{"is_enabled", columnBool}, {"power", columnFloat}, {"voltage", columnFloat}, {"current", columnFloat},
+		{"energy", columnFloat}} {
+		expected = appendString(expected, c.name)
+		expected = append(expected, byte(c.typ))
+	}
+	expected = append(expected, 1)
+	expected = appendString(append(expected, 0), "smartPlug")
+	expected = binary.AppendVarint(append(expected, 0), 7)
+	expected = binary.AppendVarint(append(expected, 0), time.Date(2023, 1, 1, 3, 0, 0, 0, time.UTC).UnixNano())
+	expected = append(expected, 0, 0)
+	expected = binary.LittleEndian.AppendUint64(append(expected, 0), math.Float64bits(12.5))
+	expected = append(expected, 1, 1, 1)
+	expected = append(expected, 0)
+	assert.Equal(t, expected, buf.Bytes())
+}
+
+func TestExport_InvalidRequest(t *testing.T) {
+	service := newTestService(t)
+	from := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+
+	_, err := service.Export(&buf, domain.ExportRequest{Format: "xlsx", From: &from, To: &to})
+
+	var validationErr *controlStationUtils.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []controlStationUtils.FieldError{
+		{Field: "format", Message: "must be one of [csv ndjson columnar]"},
+		{Field: "to", Message: "must be after from"},
+	}, validationErr.Errors)
+
+	_, err = service.Export(&buf, domain.ExportRequest{Devices: []string{"unknown"}, Format: domain.ExportCSV})
+
+	assert.ErrorIs(t, err, registry.ErrDeviceNotRegistered)
+	assert.Empty(t, buf.String())
+}
+
+func TestExport_NoDevices(t *testing.T) {
+	newTestService(t)
+	var buf bytes.Buffer
+
+	_, err := NewExportService(registry.NewRegistry()).Export(&buf, domain.ExportRequest{Format: domain.ExportCSV})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "device\n", buf.String())
+}
+
+func TestExport_Truncated(t *testing.T) {
+	service := newTestService(t)
+	controlStationUtils.SetLogQueryLimits(1, 2)
+	var buf bytes.Buffer
+
+	summary, err := service.Export(&buf, domain.ExportRequest{Devices: []string{"presenceSensor", "smartPlug"},
+		Format: domain.ExportNDJSON})
+
+	assert.NoError(t, err)
+	scannedFrom := time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC)
+	assert.Equal(t, domain.ExportSummary{Truncated: true, ScannedFrom: &scannedFrom}, summary)
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	io "io"
+
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockExportService is an autogenerated mock type for the ExportService type
+type MockExportService struct {
+	mock.Mock
+}
+
+type MockExportService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportService) EXPECT() *MockExportService_Expecter {
+	return &MockExportService_Expecter{mock: &_m.Mock}
+}
+
+// Export provides a mock function with given fields: w, request
+func (_m *MockExportService) Export(w io.Writer, request domain.ExportRequest) (domain.ExportSummary, error) {
+	ret := _m.Called(w, request)
+
+	var r0 domain.ExportSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(io.Writer, domain.ExportRequest) (domain.ExportSummary, error)); ok {
+		return rf(w, request)
+	}
+	if rf, ok := ret.Get(0).(func(io.Writer, domain.ExportRequest) domain.ExportSummary); ok {
+		r0 = rf(w, request)
+	} else {
+		r0 = ret.Get(0).(domain.ExportSummary)
+	}
+
+	if rf, ok := ret.Get(1).(func(io.Writer, domain.ExportRequest) error); ok {
+		r1 = rf(w, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExportService_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockExportService_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - w io.Writer
+//   - request domain.ExportRequest
+func (_e *MockExportService_Expecter) Export(w interface{}, request interface{}) *MockExportService_Export_Call {
+	return &MockExportService_Export_Call{Call: _e.mock.On("Export", w, request)}
+}
+
+func (_c *MockExportService_Export_Call) Run(run func(w io.Writer, request domain.ExportRequest)) *MockExportService_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(io.Writer), args[1].(domain.ExportRequest))
+	})
+	return _c
+}
+
+func (_c *MockExportService_Export_Call) Return(_a0 domain.ExportSummary, _a1 error) *MockExportService_Export_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExportService_Export_Call) RunAndReturn(run func(io.Writer, domain.ExportRequest) (domain.ExportSummary, error)) *MockExportService_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockExportService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockExportService creates a new instance of MockExportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockExportService(t mockConstructorTestingTNewMockExportService) *MockExportService {
+	mock := &MockExportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+)
+
+// logWriter encodes the exported logs in one of the export formats. The logs
+// are written page by page, close ends the export.
+type logWriter interface {
+	write(logs []exportedLog) error
+	close() error
+}
+
+func newLogWriter(w io.Writer, format domain.ExportFormat, columns []column) (logWriter, error) {
+	switch format {
+	case domain.ExportNDJSON:
+		return &ndjsonWriter{w: w}, nil
+	case domain.ExportColumnar:
+		return newColumnarWriter(w, columns)
+	}
+	return newCSVWriter(w, columns)
+}
+
+type csvWriter struct {
+	w       *csv.Writer
+	columns []column
+}
+
+func newCSVWriter(w io.Writer, columns []column) (*csvWriter, error) {
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.name
+	}
+	writer := &csvWriter{w: csv.NewWriter(w), columns: columns}
+	if err := writer.w.Write(header); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *csvWriter) write(logs []exportedLog) error {
+	record := make([]string, len(w.columns))
+	for _, log := range logs {
+		for i, c := range w.columns {
+			if c == deviceColumn {
+				record[i] = log.device
+				continue
+			}
+			record[i] = text(log.fields[c.name])
+		}
+		if err := w.w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvWriter) close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// ndjsonWriter writes every log on its own line, as sent by the data service
+// with the device added in front.
+type ndjsonWriter struct {
+	w io.Writer
+}
+
+func (w *ndjsonWriter) write(logs []exportedLog) error {
+	var buf bytes.Buffer
+	for _, log := range logs {
+		device, _ := json.Marshal(log.device)
+		buf.WriteString(`{"device":`)
+		buf.Write(device)
+
+		start := buf.Len()
+		if err := json.Compact(&buf, log.raw); err != nil {
+			return err
+		}
+		// Replace the opening brace of the log, which is at least "{}".
+		fields := buf.Bytes()[start:]
+		if len(fields) > 2 {
+			fields[0] = ','
+		} else {
+			buf.Truncate(start)
+			buf.WriteByte('}')
+		}
+		buf.WriteByte('\n')
+	}
+	_, err := w.w.Write(buf.Bytes())
+	return err
+}
+
+func (w *ndjsonWriter) close() error {
+	return nil
+}
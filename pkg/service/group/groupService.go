@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+)
+
+var ErrNotSwitchable = errors.New("Device can not be switched on and off")
+var ErrDeviceTimeout = errors.New("Device did not respond in time")
+
+// Switch reads and toggles the power state of a single device through the
+// service of its kind. Neither starts a request once the context is done.
+type Switch struct {
+	GetInfo       func(ctx context.Context) (domain.Switchable, error)
+	ToggleEnabled func(ctx context.Context) (domain.Switchable, error)
+}
+
+//go:generate --name GroupService --output mock_groupService.go
+type GroupService interface {
+	ListGroups() []domain.Group
+	GetGroupState(name string) (domain.GroupState, error)
+	SetGroupEnabled(name string, enabled bool) (domain.GroupState, error)
+}
+
+type groupService struct {
+	registry       registry.Registry
+	switches       map[string]Switch
+	maxConcurrency int
+	deviceTimeout  time.Duration
+}
+
+// NewGroupService takes the switches keyed by device name. At most
+// maxConcurrency members are contacted at once and each of them gets
+// deviceTimeout to respond.
+func NewGroupService(registry registry.Registry, switches map[string]Switch, maxConcurrency int,
+	deviceTimeout time.Duration) GroupService {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	return &groupService{registry: registry, switches: switches, maxConcurrency: maxConcurrency,
+		deviceTimeout: deviceTimeout}
+}
+
+func (s *groupService) ListGroups() []domain.Group {
+	return s.registry.ListGroups()
+}
+
+func (s *groupService) GetGroupState(name string) (domain.GroupState, error) {
+	group, err := s.registry.GetGroup(name)
+	if err != nil {
+		return domain.GroupState{}, err
+	}
+
+	return s.fanOut(group, func(ctx context.Context, sw Switch) (domain.Switchable, error) {
+		return sw.GetInfo(ctx)
+	}), nil
+}
+
+// SetGroupEnabled switches every member that is not in the requested state yet.
+// Members that fail or time out are listed in the result, the others are
+// switched regardless. A member that times out while its state is read is not
+// switched.
+func (s *groupService) SetGroupEnabled(name string, enabled bool) (domain.GroupState, error) {
+	group, err := s.registry.GetGroup(name)
+	if err != nil {
+		return domain.GroupState{}, err
+	}
+
+	return s.fanOut(group, func(ctx context.Context, sw Switch) (domain.Switchable, error) {
+		info, err := sw.GetInfo(ctx)
+		if err != nil || info.IsEnabled() == enabled {
+			return info, err
+		}
+		return sw.ToggleEnabled(ctx)
+	}), nil
+}
+
+func (s *groupService) fanOut(group domain.Group,
+	operation func(ctx context.Context, sw Switch) (domain.Switchable, error)) domain.GroupState {
+	results := make([]domain.GroupMemberResult, len(group.Members))
+	semaphore := make(chan struct{}, s.maxConcurrency)
+
+	var wg sync.WaitGroup
+	for i, member := range group.Members {
+		results[i].Name = member
+		sw, ok := s.switches[member]
+		if !ok {
+			results[i].Error = ErrNotSwitchable.Error()
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *domain.GroupMemberResult, sw Switch) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			info, err := s.withTimeout(sw, operation)
+			if err != nil {
+				result.Error = err.Error()
+				return
+			}
+			enabled := info.IsEnabled()
+			result.Enabled = &enabled
+		}(&results[i], sw)
+	}
+	wg.Wait()
+
+	state := domain.GroupState{Name: group.Name, Succeeded: []string{}, Failed: []string{}, Members: results}
+	var enabled []bool
+	for _, result := range results {
+		if result.Error != "" {
+			state.Failed = append(state.Failed, result.Name)
+			continue
+		}
+		state.Succeeded = append(state.Succeeded, result.Name)
+		enabled = append(enabled, *result.Enabled)
+	}
+	state.State = domain.AggregateOf(enabled)
+	return state
+}
+
+// withTimeout gives the operation the device timeout, after which its requests
+// are cancelled.
+func (s *groupService) withTimeout(sw Switch,
+	operation func(ctx context.Context, sw Switch) (domain.Switchable, error)) (domain.Switchable, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.deviceTimeout)
+	defer cancel()
+
+	info, err := operation(ctx, sw)
+	if err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("%w after %s", ErrDeviceTimeout, s.deviceTimeout)
+	}
+	return info, err
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSwitch keeps the power state in memory and optionally fails or answers
+// slowly. A slow answer ignores the context, like a response that arrives late.
+type fakeSwitch struct {
+	mu      sync.Mutex
+	enabled bool
+	err     error
+	delay   time.Duration
+	toggles int
+}
+
+func (f *fakeSwitch) asSwitch() Switch {
+	return Switch{
+		GetInfo: func(ctx context.Context) (domain.Switchable, error) {
+			time.Sleep(f.delay)
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return domain.DeviceInfo{Enabled: f.enabled}, f.err
+		},
+		ToggleEnabled: func(ctx context.Context) (domain.Switchable, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.enabled = !f.enabled
+			f.toggles++
+			return domain.DeviceInfo{Enabled: f.enabled}, f.err
+		},
+	}
+}
+
+func newTestRegistry(members ...string) registry.Registry {
+	r := registry.NewRegistry()
+	for _, member := range members {
+		r.Register(domain.RegisteredDevice{Name: member})
+	}
+	r.RegisterGroup(domain.Group{Name: "test", Members: members})
+	return r
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func TestGetGroupState(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled []bool
+		want    domain.GroupAggregate
+	}{
+		{name: "AllOn", enabled: []bool{true, true}, want: domain.GroupAllOn},
+		{name: "AllOff", enabled: []bool{false, false}, want: domain.GroupAllOff},
+		{name: "Mixed", enabled: []bool{true, false}, want: domain.GroupMixed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			switches := map[string]Switch{
+				"plugA": (&fakeSwitch{enabled: test.enabled[0]}).asSwitch(),
+				"plugB": (&fakeSwitch{enabled: test.enabled[1]}).asSwitch(),
+			}
+			service := NewGroupService(newTestRegistry("plugA", "plugB"), switches, 2, time.Second)
+
+			got, err := service.GetGroupState("test")
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got.State)
+			assert.Equal(t, []string{"plugA", "plugB"}, got.Succeeded)
+			assert.Empty(t, got.Failed)
+		})
+	}
+}
+
+func TestGetGroupState_NotFound(t *testing.T) {
+	service := NewGroupService(registry.NewRegistry(), nil, 2, time.Second)
+
+	_, err := service.GetGroupState("unknown")
+
+	assert.ErrorIs(t, err, registry.ErrGroupNotRegistered)
+}
+
+func TestSetGroupEnabled(t *testing.T) {
+	plugA := &fakeSwitch{enabled: false}
+	plugB := &fakeSwitch{enabled: true}
+	switches := map[string]Switch{"plugA": plugA.asSwitch(), "plugB": plugB.asSwitch()}
+	service := NewGroupService(newTestRegistry("plugA", "plugB"), switches, 2, time.Second)
+
+	got, err := service.SetGroupEnabled("test", true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.GroupState{
+		Name:      "test",
+		State:     domain.GroupAllOn,
+		Succeeded: []string{"plugA", "plugB"},
+		Failed:    []string{},
+		Members:   []domain.GroupMemberResult{{Name: "plugA", Enabled: boolPtr(true)}, {Name: "plugB", Enabled: boolPtr(true)}},
+	}, got)
+	assert.Equal(t, 1, plugA.toggles)
+	assert.Equal(t, 0, plugB.toggles)
+}
+
+func TestSetGroupEnabled_PartialFailure(t *testing.T) {
+	slow := &fakeSwitch{enabled: true, delay: 200 * time.Millisecond}
+	switches := map[string]Switch{
+		"plugA": (&fakeSwitch{enabled: true}).asSwitch(),
+		"plugB": (&fakeSwitch{err: errors.New("unreachable")}).asSwitch(),
+		"slow":  slow.asSwitch(),
+	}
+	service := NewGroupService(newTestRegistry("plugA", "plugB", "slow", "doorLock"), switches, 2, 20*time.Millisecond)
+
+	got, err := service.SetGroupEnabled("test", false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.GroupAllOff, got.State)
+	assert.Equal(t, []string{"plugA"}, got.Succeeded)
+	assert.Equal(t, []string{"plugB", "slow", "doorLock"}, got.Failed)
+	assert.Equal(t, "unreachable", got.Members[1].Error)
+	assert.Equal(t, "Device did not respond in time after 20ms", got.Members[2].Error)
+	assert.Equal(t, ErrNotSwitchable.Error(), got.Members[3].Error)
+	assert.Equal(t, 0, slow.toggles)
+}
+
+func TestSetGroupEnabled_AllFailed(t *testing.T) {
+	switches := map[string]Switch{"plugA": (&fakeSwitch{err: errors.New("unreachable")}).asSwitch()}
+	service := NewGroupService(newTestRegistry("plugA"), switches, 2, time.Second)
+
+	got, err := service.SetGroupEnabled("test", true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.GroupUnknown, got.State)
+	assert.Empty(t, got.Succeeded)
+}
+
+func TestSetGroupEnabled_BoundedConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	members := []string{"a", "b", "c", "d", "e", "f"}
+	switches := map[string]Switch{}
+	for _, member := range members {
+		switches[member] = Switch{
+			GetInfo: func(ctx context.Context) (domain.Switchable, error) {
+				current := atomic.AddInt32(&running, 1)
+				for {
+					seen := atomic.LoadInt32(&maxRunning)
+					if current <= seen || atomic.CompareAndSwapInt32(&maxRunning, seen, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return domain.DeviceInfo{Enabled: true}, nil
+			},
+		}
+	}
+	service := NewGroupService(newTestRegistry(members...), switches, 2, time.Second)
+
+	got, err := service.SetGroupEnabled("test", true)
+
+	assert.NoError(t, err)
+	assert.Len(t, got.Succeeded, 6)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(2))
+}
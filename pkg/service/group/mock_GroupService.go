@@ -0,0 +1,184 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGroupService is an autogenerated mock type for the GroupService type
+type MockGroupService struct {
+	mock.Mock
+}
+
+type MockGroupService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGroupService) EXPECT() *MockGroupService_Expecter {
+	return &MockGroupService_Expecter{mock: &_m.Mock}
+}
+
+// GetGroupState provides a mock function with given fields: name
+func (_m *MockGroupService) GetGroupState(name string) (domain.GroupState, error) {
+	ret := _m.Called(name)
+
+	var r0 domain.GroupState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (domain.GroupState, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) domain.GroupState); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(domain.GroupState)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGroupService_GetGroupState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroupState'
+type MockGroupService_GetGroupState_Call struct {
+	*mock.Call
+}
+
+// GetGroupState is a helper method to define mock.On call
+//   - name string
+func (_e *MockGroupService_Expecter) GetGroupState(name interface{}) *MockGroupService_GetGroupState_Call {
+	return &MockGroupService_GetGroupState_Call{Call: _e.mock.On("GetGroupState", name)}
+}
+
+func (_c *MockGroupService_GetGroupState_Call) Run(run func(name string)) *MockGroupService_GetGroupState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockGroupService_GetGroupState_Call) Return(_a0 domain.GroupState, _a1 error) *MockGroupService_GetGroupState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGroupService_GetGroupState_Call) RunAndReturn(run func(string) (domain.GroupState, error)) *MockGroupService_GetGroupState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListGroups provides a mock function with given fields:
+func (_m *MockGroupService) ListGroups() []domain.Group {
+	ret := _m.Called()
+
+	var r0 []domain.Group
+	if rf, ok := ret.Get(0).(func() []domain.Group); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Group)
+		}
+	}
+
+	return r0
+}
+
+// MockGroupService_ListGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListGroups'
+type MockGroupService_ListGroups_Call struct {
+	*mock.Call
+}
+
+// ListGroups is a helper method to define mock.On call
+func (_e *MockGroupService_Expecter) ListGroups() *MockGroupService_ListGroups_Call {
+	return &MockGroupService_ListGroups_Call{Call: _e.mock.On("ListGroups")}
+}
+
+func (_c *MockGroupService_ListGroups_Call) Run(run func()) *MockGroupService_ListGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockGroupService_ListGroups_Call) Return(_a0 []domain.Group) *MockGroupService_ListGroups_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGroupService_ListGroups_Call) RunAndReturn(run func() []domain.Group) *MockGroupService_ListGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetGroupEnabled provides a mock function with given fields: name, enabled
+func (_m *MockGroupService) SetGroupEnabled(name string, enabled bool) (domain.GroupState, error) {
+	ret := _m.Called(name, enabled)
+
+	var r0 domain.GroupState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, bool) (domain.GroupState, error)); ok {
+		return rf(name, enabled)
+	}
+	if rf, ok := ret.Get(0).(func(string, bool) domain.GroupState); ok {
+		r0 = rf(name, enabled)
+	} else {
+		r0 = ret.Get(0).(domain.GroupState)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(name, enabled)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGroupService_SetGroupEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetGroupEnabled'
+type MockGroupService_SetGroupEnabled_Call struct {
+	*mock.Call
+}
+
+// SetGroupEnabled is a helper method to define mock.On call
+//   - name string
+//   - enabled bool
+func (_e *MockGroupService_Expecter) SetGroupEnabled(name interface{}, enabled interface{}) *MockGroupService_SetGroupEnabled_Call {
+	return &MockGroupService_SetGroupEnabled_Call{Call: _e.mock.On("SetGroupEnabled", name, enabled)}
+}
+
+func (_c *MockGroupService_SetGroupEnabled_Call) Run(run func(name string, enabled bool)) *MockGroupService_SetGroupEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *MockGroupService_SetGroupEnabled_Call) Return(_a0 domain.GroupState, _a1 error) *MockGroupService_SetGroupEnabled_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGroupService_SetGroupEnabled_Call) RunAndReturn(run func(string, bool) (domain.GroupState, error)) *MockGroupService_SetGroupEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockGroupService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockGroupService creates a new instance of MockGroupService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockGroupService(t mockConstructorTestingTNewMockGroupService) *MockGroupService {
+	mock := &MockGroupService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
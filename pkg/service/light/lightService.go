@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+//go:generate --name LightService --output mock_lightService.go
+type LightService interface {
+	GetInfo() (domain.LightInfo, error)
+	ReadInfo(ctx context.Context) (domain.LightInfo, error)
+	ToggleEnabled(ctx context.Context) (domain.LightInfo, error)
+	SetBrightness(request domain.LightBrightnessRequest) (domain.LightInfo, error)
+	SetColor(request domain.LightColorRequest) (domain.LightInfo, error)
+	GetCapabilities() domain.LightCapabilities
+	GetLightLogsFromDataServiceLimitN(limit int) ([]domain.LightData, error)
+	QueryLightLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.LightData], error)
+}
+
+// capabilitiesRetryInterval is how long a bulb that could not be asked for its
+// capabilities is assumed to have the configured ones before asking again.
+const capabilitiesRetryInterval = time.Minute
+
+type lightService struct {
+	light                  *domain.Light
+	configuredCapabilities domain.LightCapabilities
+	mu                     sync.Mutex
+	discoveredCapabilities *domain.LightCapabilities
+	discovering            bool
+	retryDiscoveryAt       time.Time
+	now                    func() time.Time
+}
+
+func NewLightService(light *domain.Light, configuredCapabilities domain.LightCapabilities) LightService {
+	return &lightService{light: light, configuredCapabilities: configuredCapabilities, now: time.Now}
+}
+
+func (s *lightService) GetInfo() (domain.LightInfo, error) {
+	address := s.light.Address + controlStationUtils.InfoEndpoint
+	return controlStationUtils.MakeGetRequest(address, s.light.Name, domain.LightInfo{Enabled: false})
+}
+
+func (s *lightService) ReadInfo(ctx context.Context) (domain.LightInfo, error) {
+	address := s.light.Address + controlStationUtils.InfoEndpoint
+	return controlStationUtils.FetchJSONContext[domain.LightInfo](ctx, address, s.light.Name)
+}
+
+func (s *lightService) ToggleEnabled(ctx context.Context) (domain.LightInfo, error) {
+	address := s.light.Address + controlStationUtils.EnabledEndpoint
+	return controlStationUtils.MakePatchRequestContext(ctx, address, s.light.Name, nil, domain.LightInfo{Enabled: false})
+}
+
+func (s *lightService) SetBrightness(request domain.LightBrightnessRequest) (domain.LightInfo, error) {
+	capabilities := s.GetCapabilities()
+	validationErr := &controlStationUtils.ValidationError{}
+	controlStationUtils.ValidateBrightness(validationErr, request, capabilities)
+	if err := validationErr.ErrorOrNil(); err != nil {
+		return domain.LightInfo{Enabled: false}, err
+	}
+
+	address := s.light.Address + controlStationUtils.BrightnessEndpoint
+	return controlStationUtils.MakePatchRequestWithBody(address, s.light.Name, &request, domain.LightInfo{Enabled: false})
+}
+
+func (s *lightService) SetColor(request domain.LightColorRequest) (domain.LightInfo, error) {
+	capabilities := s.GetCapabilities()
+	validationErr := &controlStationUtils.ValidationError{}
+	controlStationUtils.ValidateColor(validationErr, request, capabilities)
+	if err := validationErr.ErrorOrNil(); err != nil {
+		return domain.LightInfo{Enabled: false}, err
+	}
+
+	address := s.light.Address + controlStationUtils.ColorEndpoint
+	return controlStationUtils.MakePatchRequestWithBody(address, s.light.Name, &request, domain.LightInfo{Enabled: false})
+}
+
+// GetCapabilities asks the bulb for its capabilities and remembers the answer.
+// Bulbs that cannot be asked are assumed to have the capabilities configured
+// in the registry until the retry interval has passed. The bulb is asked
+// without holding the lock, so a slow bulb does not block other callers, who
+// get the configured capabilities meanwhile.
+func (s *lightService) GetCapabilities() domain.LightCapabilities {
+	s.mu.Lock()
+	if s.discoveredCapabilities != nil {
+		defer s.mu.Unlock()
+		return *s.discoveredCapabilities
+	}
+	if s.discovering || s.now().Before(s.retryDiscoveryAt) {
+		s.mu.Unlock()
+		return s.configuredCapabilities
+	}
+	s.discovering = true
+	s.mu.Unlock()
+
+	address := s.light.Address + controlStationUtils.CapabilitiesEndpoint
+	capabilities, err := controlStationUtils.FetchJSON[domain.LightCapabilities](address, s.light.Name)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.discovering = false
+	if err != nil {
+		s.retryDiscoveryAt = s.now().Add(capabilitiesRetryInterval)
+		return s.configuredCapabilities
+	}
+	s.discoveredCapabilities = &capabilities
+	return capabilities
+}
+
+func (s *lightService) GetLightLogsFromDataServiceLimitN(limit int) ([]domain.LightData, error) {
+	return controlStationUtils.GetLogsFromDataServiceLimitN[domain.LightData](s.light.Name, limit)
+}
+
+func (s *lightService) QueryLightLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.LightData], error) {
+	return controlStationUtils.QueryLogsFromDataService[domain.LightData](s.light.Name, query)
+}
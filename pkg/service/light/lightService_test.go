@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+var fullCapabilities = domain.LightCapabilities{
+	Dimmable:            true,
+	MinColorTemperature: 2700,
+	MaxColorTemperature: 6500,
+	SupportsRGB:         true,
+	SupportsHSV:         true,
+	MaxTransitionMs:     10000,
+}
+
+func TestNewLightService(t *testing.T) {
+	light := domain.Light{Name: "test", Address: "http://test"}
+	service := NewLightService(&light, domain.DefaultLightCapabilities())
+	assert.NotNil(t, service)
+}
+
+func TestGetInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		ts      *httptest.Server
+		want    domain.LightInfo
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(domain.LightInfo{Enabled: true, Brightness: intPtr(40)})
+			})),
+			want:    domain.LightInfo{Enabled: true, Brightness: intPtr(40)},
+			wantErr: false,
+		},
+		{
+			name: "OnOffBulb",
+			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"enabled": true}`))
+			})),
+			want:    domain.LightInfo{Enabled: true},
+			wantErr: false,
+		},
+		{
+			name: "Failure",
+			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			})),
+			want:    domain.LightInfo{},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer test.ts.Close()
+			service := &lightService{light: &domain.Light{Name: "test", Address: test.ts.URL}}
+			got, err := service.GetInfo()
+
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantErr, err != nil)
+		})
+	}
+}
+
+func TestToggleEnabled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(domain.LightInfo{Enabled: true})
+	}))
+	defer ts.Close()
+
+	service := &lightService{light: &domain.Light{Name: "test", Address: ts.URL}}
+	got, err := service.ToggleEnabled(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.LightInfo{Enabled: true}, got)
+}
+
+func TestSetBrightness(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities domain.LightCapabilities
+		request      domain.LightBrightnessRequest
+		want         domain.LightInfo
+		wantErrors   []controlStationUtils.FieldError
+	}{
+		{
+			name:         "Success",
+			capabilities: fullCapabilities,
+			request:      domain.LightBrightnessRequest{Brightness: 60, TransitionMs: 500},
+			want:         domain.LightInfo{Enabled: true, Brightness: intPtr(60)},
+		},
+		{
+			name:         "NotDimmable",
+			capabilities: domain.DefaultLightCapabilities(),
+			request:      domain.LightBrightnessRequest{Brightness: 60},
+			wantErrors:   []controlStationUtils.FieldError{{Field: "brightness", Message: "is not supported"}},
+		},
+		{
+			name:         "OutOfRange",
+			capabilities: fullCapabilities,
+			request:      domain.LightBrightnessRequest{Brightness: 120, TransitionMs: 20000},
+			wantErrors: []controlStationUtils.FieldError{
+				{Field: "brightness", Message: "must be between 0 and 100"},
+				{Field: "transition_ms", Message: "must be between 0 and 10000"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotRequest domain.LightBrightnessRequest
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case controlStationUtils.CapabilitiesEndpoint:
+					json.NewEncoder(w).Encode(test.capabilities)
+				case controlStationUtils.BrightnessEndpoint:
+					json.NewDecoder(r.Body).Decode(&gotRequest)
+					json.NewEncoder(w).Encode(domain.LightInfo{Enabled: true, Brightness: &gotRequest.Brightness})
+				}
+			}))
+			defer ts.Close()
+			t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+			service := &lightService{light: &domain.Light{Name: "test", Address: ts.URL}, now: time.Now}
+			got, err := service.SetBrightness(test.request)
+
+			if test.wantErrors != nil {
+				var validationErr *controlStationUtils.ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, test.wantErrors, validationErr.Errors)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.request, gotRequest)
+		})
+	}
+}
+
+func TestSetColor(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities domain.LightCapabilities
+		request      domain.LightColorRequest
+		wantErrors   []controlStationUtils.FieldError
+	}{
+		{
+			name:         "ColorTemperature",
+			capabilities: fullCapabilities,
+			request:      domain.LightColorRequest{ColorTemperature: intPtr(3000)},
+		},
+		{
+			name:         "RGB",
+			capabilities: fullCapabilities,
+			request:      domain.LightColorRequest{RGB: &domain.RGBColor{Red: 255, Green: 128, Blue: 0}, TransitionMs: 1000},
+		},
+		{
+			name:         "HSV",
+			capabilities: fullCapabilities,
+			request:      domain.LightColorRequest{HSV: &domain.HSVColor{Hue: 200, Saturation: 50, Value: 80}},
+		},
+		{
+			name:         "NoColor",
+			capabilities: fullCapabilities,
+			request:      domain.LightColorRequest{},
+			wantErrors: []controlStationUtils.FieldError{
+				{Field: "color", Message: "exactly one of color_temperature, rgb or hsv must be set"},
+			},
+		},
+		{
+			name:         "UnsupportedColors",
+			capabilities: domain.LightCapabilities{Dimmable: true, MinColorTemperature: 2700, MaxColorTemperature: 6500},
+			request:      domain.LightColorRequest{RGB: &domain.RGBColor{Red: 300}, TransitionMs: 500},
+			wantErrors: []controlStationUtils.FieldError{
+				{Field: "rgb", Message: "is not supported"},
+				{Field: "transition_ms", Message: "is not supported"},
+			},
+		},
+		{
+			name:         "OutOfRange",
+			capabilities: fullCapabilities,
+			request:      domain.LightColorRequest{ColorTemperature: intPtr(1000)},
+			wantErrors: []controlStationUtils.FieldError{
+				{Field: "color_temperature", Message: "must be between 2700 and 6500"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotRequest domain.LightColorRequest
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == controlStationUtils.ColorEndpoint {
+					json.NewDecoder(r.Body).Decode(&gotRequest)
+					json.NewEncoder(w).Encode(domain.LightInfo{Enabled: true})
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer ts.Close()
+			t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+			service := &lightService{light: &domain.Light{Name: "test", Address: ts.URL}, configuredCapabilities: test.capabilities,
+				now: time.Now}
+			got, err := service.SetColor(test.request)
+
+			if test.wantErrors != nil {
+				var validationErr *controlStationUtils.ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, test.wantErrors, validationErr.Errors)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, domain.LightInfo{Enabled: true}, got)
+			assert.Equal(t, test.request, gotRequest)
+		})
+	}
+}
+
+func TestGetCapabilities(t *testing.T) {
+	t.Run("Discovered", func(t *testing.T) {
+		calls := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			json.NewEncoder(w).Encode(fullCapabilities)
+		}))
+		defer ts.Close()
+
+		service := &lightService{light: &domain.Light{Name: "test", Address: ts.URL}, now: time.Now}
+
+		assert.Equal(t, fullCapabilities, service.GetCapabilities())
+		assert.Equal(t, fullCapabilities, service.GetCapabilities())
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		calls := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer ts.Close()
+		configured := domain.LightCapabilities{Dimmable: true}
+		now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		service := &lightService{light: &domain.Light{Name: "test", Address: ts.URL}, configuredCapabilities: configured,
+			now: func() time.Time { return now }}
+
+		assert.Equal(t, configured, service.GetCapabilities())
+		assert.Equal(t, configured, service.GetCapabilities())
+		assert.Equal(t, 1, calls)
+
+		now = now.Add(capabilitiesRetryInterval)
+		assert.Equal(t, configured, service.GetCapabilities())
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestGetLightLogsFromDataServiceLimitN(t *testing.T) {
+	logs := []domain.LightData{
+		{ID: 1, CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), IsEnabled: true, Brightness: intPtr(50)},
+		{ID: 2, CreatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), IsEnabled: false},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(logs)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := &lightService{light: &domain.Light{Name: "test", Address: ts.URL}}
+	got, err := service.GetLightLogsFromDataServiceLimitN(2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, logs, got)
+}
@@ -0,0 +1,444 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLightService is an autogenerated mock type for the LightService type
+type MockLightService struct {
+	mock.Mock
+}
+
+type MockLightService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLightService) EXPECT() *MockLightService_Expecter {
+	return &MockLightService_Expecter{mock: &_m.Mock}
+}
+
+// GetCapabilities provides a mock function with given fields:
+func (_m *MockLightService) GetCapabilities() domain.LightCapabilities {
+	ret := _m.Called()
+
+	var r0 domain.LightCapabilities
+	if rf, ok := ret.Get(0).(func() domain.LightCapabilities); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.LightCapabilities)
+	}
+
+	return r0
+}
+
+// MockLightService_GetCapabilities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCapabilities'
+type MockLightService_GetCapabilities_Call struct {
+	*mock.Call
+}
+
+// GetCapabilities is a helper method to define mock.On call
+func (_e *MockLightService_Expecter) GetCapabilities() *MockLightService_GetCapabilities_Call {
+	return &MockLightService_GetCapabilities_Call{Call: _e.mock.On("GetCapabilities")}
+}
+
+func (_c *MockLightService_GetCapabilities_Call) Run(run func()) *MockLightService_GetCapabilities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockLightService_GetCapabilities_Call) Return(_a0 domain.LightCapabilities) *MockLightService_GetCapabilities_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLightService_GetCapabilities_Call) RunAndReturn(run func() domain.LightCapabilities) *MockLightService_GetCapabilities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInfo provides a mock function with given fields:
+func (_m *MockLightService) GetInfo() (domain.LightInfo, error) {
+	ret := _m.Called()
+
+	var r0 domain.LightInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (domain.LightInfo, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() domain.LightInfo); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.LightInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLightService_GetInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInfo'
+type MockLightService_GetInfo_Call struct {
+	*mock.Call
+}
+
+// GetInfo is a helper method to define mock.On call
+func (_e *MockLightService_Expecter) GetInfo() *MockLightService_GetInfo_Call {
+	return &MockLightService_GetInfo_Call{Call: _e.mock.On("GetInfo")}
+}
+
+func (_c *MockLightService_GetInfo_Call) Run(run func()) *MockLightService_GetInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockLightService_GetInfo_Call) Return(_a0 domain.LightInfo, _a1 error) *MockLightService_GetInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLightService_GetInfo_Call) RunAndReturn(run func() (domain.LightInfo, error)) *MockLightService_GetInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLightLogsFromDataServiceLimitN provides a mock function with given fields: limit
+func (_m *MockLightService) GetLightLogsFromDataServiceLimitN(limit int) ([]domain.LightData, error) {
+	ret := _m.Called(limit)
+
+	var r0 []domain.LightData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]domain.LightData, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []domain.LightData); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LightData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLightService_GetLightLogsFromDataServiceLimitN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLightLogsFromDataServiceLimitN'
+type MockLightService_GetLightLogsFromDataServiceLimitN_Call struct {
+	*mock.Call
+}
+
+// GetLightLogsFromDataServiceLimitN is a helper method to define mock.On call
+//   - limit int
+func (_e *MockLightService_Expecter) GetLightLogsFromDataServiceLimitN(limit interface{}) *MockLightService_GetLightLogsFromDataServiceLimitN_Call {
+	return &MockLightService_GetLightLogsFromDataServiceLimitN_Call{Call: _e.mock.On("GetLightLogsFromDataServiceLimitN", limit)}
+}
+
+func (_c *MockLightService_GetLightLogsFromDataServiceLimitN_Call) Run(run func(limit int)) *MockLightService_GetLightLogsFromDataServiceLimitN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockLightService_GetLightLogsFromDataServiceLimitN_Call) Return(_a0 []domain.LightData, _a1 error) *MockLightService_GetLightLogsFromDataServiceLimitN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLightService_GetLightLogsFromDataServiceLimitN_Call) RunAndReturn(run func(int) ([]domain.LightData, error)) *MockLightService_GetLightLogsFromDataServiceLimitN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryLightLogsFromDataService provides a mock function with given fields: query
+func (_m *MockLightService) QueryLightLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.LightData], error) {
+	ret := _m.Called(query)
+
+	var r0 domain.LogPage[domain.LightData]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.LogQuery) (domain.LogPage[domain.LightData], error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(domain.LogQuery) domain.LogPage[domain.LightData]); ok {
+		r0 = rf(query)
+	} else {
+		r0 = ret.Get(0).(domain.LogPage[domain.LightData])
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.LogQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLightService_QueryLightLogsFromDataService_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryLightLogsFromDataService'
+type MockLightService_QueryLightLogsFromDataService_Call struct {
+	*mock.Call
+}
+
+// QueryLightLogsFromDataService is a helper method to define mock.On call
+//   - query domain.LogQuery
+func (_e *MockLightService_Expecter) QueryLightLogsFromDataService(query interface{}) *MockLightService_QueryLightLogsFromDataService_Call {
+	return &MockLightService_QueryLightLogsFromDataService_Call{Call: _e.mock.On("QueryLightLogsFromDataService", query)}
+}
+
+func (_c *MockLightService_QueryLightLogsFromDataService_Call) Run(run func(query domain.LogQuery)) *MockLightService_QueryLightLogsFromDataService_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.LogQuery))
+	})
+	return _c
+}
+
+func (_c *MockLightService_QueryLightLogsFromDataService_Call) Return(_a0 domain.LogPage[domain.LightData], _a1 error) *MockLightService_QueryLightLogsFromDataService_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLightService_QueryLightLogsFromDataService_Call) RunAndReturn(run func(domain.LogQuery) (domain.LogPage[domain.LightData], error)) *MockLightService_QueryLightLogsFromDataService_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadInfo provides a mock function with given fields: ctx
+func (_m *MockLightService) ReadInfo(ctx context.Context) (domain.LightInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 domain.LightInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.LightInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.LightInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.LightInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLightService_ReadInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadInfo'
+type MockLightService_ReadInfo_Call struct {
+	*mock.Call
+}
+
+// ReadInfo is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockLightService_Expecter) ReadInfo(ctx interface{}) *MockLightService_ReadInfo_Call {
+	return &MockLightService_ReadInfo_Call{Call: _e.mock.On("ReadInfo", ctx)}
+}
+
+func (_c *MockLightService_ReadInfo_Call) Run(run func(ctx context.Context)) *MockLightService_ReadInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockLightService_ReadInfo_Call) Return(_a0 domain.LightInfo, _a1 error) *MockLightService_ReadInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLightService_ReadInfo_Call) RunAndReturn(run func(context.Context) (domain.LightInfo, error)) *MockLightService_ReadInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetBrightness provides a mock function with given fields: request
+func (_m *MockLightService) SetBrightness(request domain.LightBrightnessRequest) (domain.LightInfo, error) {
+	ret := _m.Called(request)
+
+	var r0 domain.LightInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.LightBrightnessRequest) (domain.LightInfo, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(domain.LightBrightnessRequest) domain.LightInfo); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(domain.LightInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.LightBrightnessRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLightService_SetBrightness_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBrightness'
+type MockLightService_SetBrightness_Call struct {
+	*mock.Call
+}
+
+// SetBrightness is a helper method to define mock.On call
+//   - request domain.LightBrightnessRequest
+func (_e *MockLightService_Expecter) SetBrightness(request interface{}) *MockLightService_SetBrightness_Call {
+	return &MockLightService_SetBrightness_Call{Call: _e.mock.On("SetBrightness", request)}
+}
+
+func (_c *MockLightService_SetBrightness_Call) Run(run func(request domain.LightBrightnessRequest)) *MockLightService_SetBrightness_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.LightBrightnessRequest))
+	})
+	return _c
+}
+
+func (_c *MockLightService_SetBrightness_Call) Return(_a0 domain.LightInfo, _a1 error) *MockLightService_SetBrightness_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLightService_SetBrightness_Call) RunAndReturn(run func(domain.LightBrightnessRequest) (domain.LightInfo, error)) *MockLightService_SetBrightness_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetColor provides a mock function with given fields: request
+func (_m *MockLightService) SetColor(request domain.LightColorRequest) (domain.LightInfo, error) {
+	ret := _m.Called(request)
+
+	var r0 domain.LightInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.LightColorRequest) (domain.LightInfo, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(domain.LightColorRequest) domain.LightInfo); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(domain.LightInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.LightColorRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLightService_SetColor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetColor'
+type MockLightService_SetColor_Call struct {
+	*mock.Call
+}
+
+// SetColor is a helper method to define mock.On call
+//   - request domain.LightColorRequest
+func (_e *MockLightService_Expecter) SetColor(request interface{}) *MockLightService_SetColor_Call {
+	return &MockLightService_SetColor_Call{Call: _e.mock.On("SetColor", request)}
+}
+
+func (_c *MockLightService_SetColor_Call) Run(run func(request domain.LightColorRequest)) *MockLightService_SetColor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.LightColorRequest))
+	})
+	return _c
+}
+
+func (_c *MockLightService_SetColor_Call) Return(_a0 domain.LightInfo, _a1 error) *MockLightService_SetColor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLightService_SetColor_Call) RunAndReturn(run func(domain.LightColorRequest) (domain.LightInfo, error)) *MockLightService_SetColor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ToggleEnabled provides a mock function with given fields: ctx
+func (_m *MockLightService) ToggleEnabled(ctx context.Context) (domain.LightInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 domain.LightInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.LightInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.LightInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.LightInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLightService_ToggleEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ToggleEnabled'
+type MockLightService_ToggleEnabled_Call struct {
+	*mock.Call
+}
+
+// ToggleEnabled is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockLightService_Expecter) ToggleEnabled(ctx interface{}) *MockLightService_ToggleEnabled_Call {
+	return &MockLightService_ToggleEnabled_Call{Call: _e.mock.On("ToggleEnabled", ctx)}
+}
+
+func (_c *MockLightService_ToggleEnabled_Call) Run(run func(ctx context.Context)) *MockLightService_ToggleEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockLightService_ToggleEnabled_Call) Return(_a0 domain.LightInfo, _a1 error) *MockLightService_ToggleEnabled_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLightService_ToggleEnabled_Call) RunAndReturn(run func(context.Context) (domain.LightInfo, error)) *MockLightService_ToggleEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockLightService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockLightService creates a new instance of MockLightService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockLightService(t mockConstructorTestingTNewMockLightService) *MockLightService {
+	mock := &MockLightService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
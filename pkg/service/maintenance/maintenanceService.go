@@ -0,0 +1,207 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+var ErrWindowNotFound = errors.New("Maintenance window not found")
+
+const maintenanceLogName = "maintenance"
+
+// MaintenanceTag is added to the data service logs of a device in maintenance.
+const MaintenanceTag = "maintenance"
+
+//go:generate --name MaintenanceService --output mock_maintenanceService.go
+type MaintenanceService interface {
+	ListWindows() []domain.MaintenanceWindow
+	Schedule(request domain.MaintenanceRequest, source string) (domain.MaintenanceWindow, error)
+	End(id string, source string) (domain.MaintenanceWindow, error)
+	InMaintenance(device string) bool
+}
+
+type maintenanceService struct {
+	registry registry.Registry
+	now      func() time.Time
+
+	mu      sync.Mutex
+	windows []domain.MaintenanceWindow
+}
+
+// NewMaintenanceService restores the scheduled windows from the data service.
+func NewMaintenanceService(registry registry.Registry) MaintenanceService {
+	s := &maintenanceService{registry: registry, now: time.Now}
+	s.restore()
+	return s
+}
+
+// ListWindows returns the active and upcoming windows ordered by their start.
+func (s *maintenanceService) ListWindows() []domain.MaintenanceWindow {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+	windows := make([]domain.MaintenanceWindow, len(s.windows))
+	copy(windows, s.windows)
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].Start.Before(windows[j].Start)
+	})
+	return windows
+}
+
+// Schedule adds a window for a registered device. A window without start begins
+// immediately, one without end lasts until it is ended.
+func (s *maintenanceService) Schedule(request domain.MaintenanceRequest, source string) (domain.MaintenanceWindow, error) {
+	if _, err := s.registry.Get(request.Device); err != nil {
+		return domain.MaintenanceWindow{}, err
+	}
+
+	now := s.now().UTC()
+	window := domain.MaintenanceWindow{Device: request.Device, Start: now, Reason: request.Reason, Source: source}
+	if request.Start != nil {
+		window.Start = request.Start.UTC()
+	}
+	if request.End != nil {
+		end := request.End.UTC()
+		window.End = &end
+	}
+
+	validationErr := &controlStationUtils.ValidationError{}
+	if window.End != nil && !window.End.After(window.Start) {
+		validationErr.Add("end", "must be after start")
+	} else if window.End != nil && !window.End.After(now) {
+		validationErr.Add("end", "must not be in the past")
+	}
+	if err := validationErr.ErrorOrNil(); err != nil {
+		return domain.MaintenanceWindow{}, err
+	}
+
+	id, err := newWindowID()
+	if err != nil {
+		return domain.MaintenanceWindow{}, err
+	}
+	window.ID = id
+
+	s.mu.Lock()
+	s.prune()
+	s.windows = append(s.windows, window)
+	record := s.snapshot(source)
+	s.mu.Unlock()
+
+	log.Printf("Maintenance of '%s' scheduled from %s by %s\n", window.Device, window.Start.Format(time.RFC3339), source)
+	s.record(record)
+	return window, nil
+}
+
+// End ends an active window now. A window that has not started yet is
+// cancelled.
+func (s *maintenanceService) End(id string, source string) (domain.MaintenanceWindow, error) {
+	s.mu.Lock()
+	s.prune()
+	now := s.now().UTC()
+	for i, window := range s.windows {
+		if window.ID != id {
+			continue
+		}
+
+		if now.Before(window.Start) {
+			window.Start = now
+		}
+		window.End = &now
+		s.windows = append(s.windows[:i], s.windows[i+1:]...)
+		record := s.snapshot(source)
+		s.mu.Unlock()
+
+		log.Printf("Maintenance of '%s' ended by %s\n", window.Device, source)
+		s.record(record)
+		return window, nil
+	}
+	s.mu.Unlock()
+
+	return domain.MaintenanceWindow{}, ErrWindowNotFound
+}
+
+func (s *maintenanceService) InMaintenance(device string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for _, window := range s.windows {
+		if window.Device == device && window.ActiveAt(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Tags returns the log tags of the device, to be set as the log tagger of the
+// data service logs.
+func Tags(service MaintenanceService) func(device string) []string {
+	return func(device string) []string {
+		if service.InMaintenance(device) {
+			return []string{MaintenanceTag}
+		}
+		return nil
+	}
+}
+
+// prune drops the windows that are over. It must be called with the mutex
+// held.
+func (s *maintenanceService) prune() {
+	now := s.now()
+	windows := s.windows[:0]
+	for _, window := range s.windows {
+		if window.End == nil || now.Before(*window.End) {
+			windows = append(windows, window)
+		}
+	}
+	s.windows = windows
+}
+
+// snapshot must be called with the mutex held.
+func (s *maintenanceService) snapshot(source string) domain.MaintenanceRecord {
+	windows := make([]domain.MaintenanceWindow, len(s.windows))
+	copy(windows, s.windows)
+	return domain.MaintenanceRecord{Windows: windows, Source: source, Time: s.now().UTC()}
+}
+
+func (s *maintenanceService) record(record domain.MaintenanceRecord) {
+	err := controlStationUtils.SendLogsToDataService(maintenanceLogName, record)
+	if err != nil {
+		log.Printf("Failed to send '%s' logs to data service: %s\n", maintenanceLogName, err)
+	}
+}
+
+func (s *maintenanceService) restore() {
+	records, err := controlStationUtils.GetLogsFromDataServiceLimitN[domain.MaintenanceRecord](maintenanceLogName, 1)
+	if err != nil {
+		log.Printf("Failed to restore the maintenance windows: %s\n", err)
+		return
+	}
+	if len(records) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.windows = records[0].Windows
+	s.prune()
+}
+
+func newWindowID() (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
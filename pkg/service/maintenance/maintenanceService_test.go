@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	alertService "github.com/pklimuk-eng-thesis/control-station/pkg/service/alert"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	lightService "github.com/pklimuk-eng-thesis/control-station/pkg/service/light"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeDataService serves the persisted records and collects the sent ones.
+type fakeDataService struct {
+	mu   sync.Mutex
+	sent []domain.MaintenanceRecord
+}
+
+func newDataService(t *testing.T, persisted []domain.MaintenanceRecord) *fakeDataService {
+	dataService := &fakeDataService{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/maintenance/latest":
+			json.NewEncoder(w).Encode(persisted)
+		case "/maintenance/add":
+			var record domain.MaintenanceRecord
+			json.NewDecoder(r.Body).Decode(&record)
+			dataService.mu.Lock()
+			dataService.sent = append(dataService.sent, record)
+			dataService.mu.Unlock()
+		}
+	}))
+	t.Cleanup(ts.Close)
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+	return dataService
+}
+
+func (f *fakeDataService) records() []domain.MaintenanceRecord {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sent
+}
+
+func newTestService(t *testing.T, persisted []domain.MaintenanceRecord) (*maintenanceService, *fakeDataService) {
+	dataService := newDataService(t, persisted)
+	deviceRegistry := registry.NewRegistry()
+	deviceRegistry.Register(domain.RegisteredDevice{Name: "smartPlug", Kind: domain.KindPlug, Route: "/smartPlug"})
+	service := &maintenanceService{registry: deviceRegistry, now: func() time.Time { return testNow }}
+	service.restore()
+	return service, dataService
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestSchedule(t *testing.T) {
+	service, dataService := newTestService(t, nil)
+
+	window, err := service.Schedule(domain.MaintenanceRequest{Device: "smartPlug", Reason: "firmware update"}, "tester")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, window.ID)
+	assert.Equal(t, testNow, window.Start)
+	assert.Nil(t, window.End)
+	assert.True(t, service.InMaintenance("smartPlug"))
+	assert.Equal(t, []domain.MaintenanceWindow{window}, service.ListWindows())
+	records := dataService.records()
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, []domain.MaintenanceWindow{window}, records[0].Windows)
+		assert.Equal(t, "tester", records[0].Source)
+	}
+}
+
+func TestSchedule_Upcoming(t *testing.T) {
+	service, _ := newTestService(t, nil)
+
+	_, err := service.Schedule(domain.MaintenanceRequest{Device: "smartPlug", Start: timePtr(testNow.Add(time.Hour)),
+		End: timePtr(testNow.Add(2 * time.Hour))}, "tester")
+
+	assert.NoError(t, err)
+	assert.False(t, service.InMaintenance("smartPlug"))
+
+	service.now = func() time.Time { return testNow.Add(90 * time.Minute) }
+	assert.True(t, service.InMaintenance("smartPlug"))
+
+	service.now = func() time.Time { return testNow.Add(2 * time.Hour) }
+	assert.False(t, service.InMaintenance("smartPlug"))
+	assert.Empty(t, service.ListWindows())
+}
+
+func TestSchedule_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domain.MaintenanceRequest
+		wantErr error
+	}{
+		{name: "UnknownDevice", request: domain.MaintenanceRequest{Device: "unknown"},
+			wantErr: registry.ErrDeviceNotRegistered},
+		{name: "EndBeforeStart", request: domain.MaintenanceRequest{Device: "smartPlug",
+			Start: timePtr(testNow.Add(time.Hour)), End: timePtr(testNow.Add(time.Minute))}},
+		{name: "EndInPast", request: domain.MaintenanceRequest{Device: "smartPlug",
+			Start: timePtr(testNow.Add(-time.Hour)), End: timePtr(testNow.Add(-time.Minute))}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, dataService := newTestService(t, nil)
+
+			_, err := service.Schedule(test.request, "tester")
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				var validationErr *controlStationUtils.ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+			}
+			assert.Empty(t, service.ListWindows())
+			assert.Empty(t, dataService.records())
+		})
+	}
+}
+
+func TestEnd(t *testing.T) {
+	service, dataService := newTestService(t, nil)
+	window, _ := service.Schedule(domain.MaintenanceRequest{Device: "smartPlug"}, "tester")
+
+	ended, err := service.End(window.ID, "tester")
+
+	assert.NoError(t, err)
+	assert.Equal(t, timePtr(testNow), ended.End)
+	assert.False(t, service.InMaintenance("smartPlug"))
+	records := dataService.records()
+	if assert.Len(t, records, 2) {
+		assert.Empty(t, records[1].Windows)
+	}
+
+	_, err = service.End(window.ID, "tester")
+	assert.ErrorIs(t, err, ErrWindowNotFound)
+}
+
+func TestRestore(t *testing.T) {
+	active := domain.MaintenanceWindow{ID: "a", Device: "smartPlug", Start: testNow.Add(-time.Hour)}
+	ended := domain.MaintenanceWindow{ID: "b", Device: "ac", Start: testNow.Add(-2 * time.Hour),
+		End: timePtr(testNow.Add(-time.Hour))}
+	service, _ := newTestService(t, []domain.MaintenanceRecord{{Windows: []domain.MaintenanceWindow{active, ended}}})
+
+	assert.Equal(t, []domain.MaintenanceWindow{active}, service.ListWindows())
+	assert.True(t, service.InMaintenance("smartPlug"))
+	assert.False(t, service.InMaintenance("ac"))
+}
+
+func TestTags(t *testing.T) {
+	maintenance := new(MockMaintenanceService)
+	maintenance.EXPECT().InMaintenance("smartPlug").Return(true)
+	maintenance.EXPECT().InMaintenance("ac").Return(false)
+
+	tags := Tags(maintenance)
+
+	assert.Equal(t, []string{MaintenanceTag}, tags("smartPlug"))
+	assert.Nil(t, tags("ac"))
+}
+
+func TestSkipSwitches(t *testing.T) {
+	maintenance := new(MockMaintenanceService)
+	maintenance.EXPECT().InMaintenance("smartPlug").Return(true)
+	maintenance.EXPECT().InMaintenance("smartBulb").Return(false)
+	toggles := 0
+	toggle := func(ctx context.Context) (domain.Switchable, error) {
+		toggles++
+		return domain.PlugInfo{Enabled: true}, nil
+	}
+	switches := SkipSwitches(map[string]groupService.Switch{
+		"smartPlug": {ToggleEnabled: toggle},
+		"smartBulb": {ToggleEnabled: toggle},
+	}, maintenance)
+
+	_, err := switches["smartPlug"].ToggleEnabled(context.Background())
+	assert.ErrorIs(t, err, controlStationUtils.ErrInMaintenance)
+	_, err = switches["smartBulb"].ToggleEnabled(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, toggles)
+}
+
+func TestSkipLight(t *testing.T) {
+	maintenance := new(MockMaintenanceService)
+	maintenance.EXPECT().InMaintenance("smartBulb").Return(true)
+	light := new(lightService.MockLightService)
+
+	_, err := SkipLight(light, "smartBulb", maintenance).SetBrightness(domain.LightBrightnessRequest{Brightness: 40})
+
+	assert.ErrorIs(t, err, controlStationUtils.ErrInMaintenance)
+	light.AssertNotCalled(t, "SetBrightness", mock.Anything)
+}
+
+func TestMuteAlerts(t *testing.T) {
+	maintenance := new(MockMaintenanceService)
+	maintenance.EXPECT().InMaintenance("gasSensor").Return(true).Once()
+	maintenance.EXPECT().InMaintenance("gasSensor").Return(false).Once()
+	alerts := new(alertService.MockAlertService)
+	alerts.EXPECT().Raise(mock.Anything).Return(errors.New("failure")).Once()
+	muting := MuteAlerts(alerts, maintenance)
+	alert := domain.Alert{Severity: domain.AlertCritical, Source: "gasSensor", Message: "Gas detected"}
+
+	assert.NoError(t, muting.Raise(alert))
+	assert.Error(t, muting.Raise(alert))
+	alerts.AssertNumberOfCalls(t, "Raise", 1)
+}
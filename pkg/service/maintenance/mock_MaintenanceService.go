@@ -0,0 +1,227 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockMaintenanceService is an autogenerated mock type for the MaintenanceService type
+type MockMaintenanceService struct {
+	mock.Mock
+}
+
+type MockMaintenanceService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMaintenanceService) EXPECT() *MockMaintenanceService_Expecter {
+	return &MockMaintenanceService_Expecter{mock: &_m.Mock}
+}
+
+// End provides a mock function with given fields: id, source
+func (_m *MockMaintenanceService) End(id string, source string) (domain.MaintenanceWindow, error) {
+	ret := _m.Called(id, source)
+
+	var r0 domain.MaintenanceWindow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (domain.MaintenanceWindow, error)); ok {
+		return rf(id, source)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) domain.MaintenanceWindow); ok {
+		r0 = rf(id, source)
+	} else {
+		r0 = ret.Get(0).(domain.MaintenanceWindow)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(id, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMaintenanceService_End_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'End'
+type MockMaintenanceService_End_Call struct {
+	*mock.Call
+}
+
+// End is a helper method to define mock.On call
+//   - id string
+//   - source string
+func (_e *MockMaintenanceService_Expecter) End(id interface{}, source interface{}) *MockMaintenanceService_End_Call {
+	return &MockMaintenanceService_End_Call{Call: _e.mock.On("End", id, source)}
+}
+
+func (_c *MockMaintenanceService_End_Call) Run(run func(id string, source string)) *MockMaintenanceService_End_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMaintenanceService_End_Call) Return(_a0 domain.MaintenanceWindow, _a1 error) *MockMaintenanceService_End_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMaintenanceService_End_Call) RunAndReturn(run func(string, string) (domain.MaintenanceWindow, error)) *MockMaintenanceService_End_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InMaintenance provides a mock function with given fields: device
+func (_m *MockMaintenanceService) InMaintenance(device string) bool {
+	ret := _m.Called(device)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(device)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockMaintenanceService_InMaintenance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InMaintenance'
+type MockMaintenanceService_InMaintenance_Call struct {
+	*mock.Call
+}
+
+// InMaintenance is a helper method to define mock.On call
+//   - device string
+func (_e *MockMaintenanceService_Expecter) InMaintenance(device interface{}) *MockMaintenanceService_InMaintenance_Call {
+	return &MockMaintenanceService_InMaintenance_Call{Call: _e.mock.On("InMaintenance", device)}
+}
+
+func (_c *MockMaintenanceService_InMaintenance_Call) Run(run func(device string)) *MockMaintenanceService_InMaintenance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockMaintenanceService_InMaintenance_Call) Return(_a0 bool) *MockMaintenanceService_InMaintenance_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMaintenanceService_InMaintenance_Call) RunAndReturn(run func(string) bool) *MockMaintenanceService_InMaintenance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWindows provides a mock function with given fields:
+func (_m *MockMaintenanceService) ListWindows() []domain.MaintenanceWindow {
+	ret := _m.Called()
+
+	var r0 []domain.MaintenanceWindow
+	if rf, ok := ret.Get(0).(func() []domain.MaintenanceWindow); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.MaintenanceWindow)
+		}
+	}
+
+	return r0
+}
+
+// MockMaintenanceService_ListWindows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWindows'
+type MockMaintenanceService_ListWindows_Call struct {
+	*mock.Call
+}
+
+// ListWindows is a helper method to define mock.On call
+func (_e *MockMaintenanceService_Expecter) ListWindows() *MockMaintenanceService_ListWindows_Call {
+	return &MockMaintenanceService_ListWindows_Call{Call: _e.mock.On("ListWindows")}
+}
+
+func (_c *MockMaintenanceService_ListWindows_Call) Run(run func()) *MockMaintenanceService_ListWindows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMaintenanceService_ListWindows_Call) Return(_a0 []domain.MaintenanceWindow) *MockMaintenanceService_ListWindows_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMaintenanceService_ListWindows_Call) RunAndReturn(run func() []domain.MaintenanceWindow) *MockMaintenanceService_ListWindows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Schedule provides a mock function with given fields: request, source
+func (_m *MockMaintenanceService) Schedule(request domain.MaintenanceRequest, source string) (domain.MaintenanceWindow, error) {
+	ret := _m.Called(request, source)
+
+	var r0 domain.MaintenanceWindow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.MaintenanceRequest, string) (domain.MaintenanceWindow, error)); ok {
+		return rf(request, source)
+	}
+	if rf, ok := ret.Get(0).(func(domain.MaintenanceRequest, string) domain.MaintenanceWindow); ok {
+		r0 = rf(request, source)
+	} else {
+		r0 = ret.Get(0).(domain.MaintenanceWindow)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.MaintenanceRequest, string) error); ok {
+		r1 = rf(request, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMaintenanceService_Schedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Schedule'
+type MockMaintenanceService_Schedule_Call struct {
+	*mock.Call
+}
+
+// Schedule is a helper method to define mock.On call
+//   - request domain.MaintenanceRequest
+//   - source string
+func (_e *MockMaintenanceService_Expecter) Schedule(request interface{}, source interface{}) *MockMaintenanceService_Schedule_Call {
+	return &MockMaintenanceService_Schedule_Call{Call: _e.mock.On("Schedule", request, source)}
+}
+
+func (_c *MockMaintenanceService_Schedule_Call) Run(run func(request domain.MaintenanceRequest, source string)) *MockMaintenanceService_Schedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.MaintenanceRequest), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMaintenanceService_Schedule_Call) Return(_a0 domain.MaintenanceWindow, _a1 error) *MockMaintenanceService_Schedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMaintenanceService_Schedule_Call) RunAndReturn(run func(domain.MaintenanceRequest, string) (domain.MaintenanceWindow, error)) *MockMaintenanceService_Schedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockMaintenanceService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockMaintenanceService creates a new instance of MockMaintenanceService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockMaintenanceService(t mockConstructorTestingTNewMockMaintenanceService) *MockMaintenanceService {
+	mock := &MockMaintenanceService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	acService "github.com/pklimuk-eng-thesis/control-station/pkg/service/ac"
+	alertService "github.com/pklimuk-eng-thesis/control-station/pkg/service/alert"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	lightService "github.com/pklimuk-eng-thesis/control-station/pkg/service/light"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+func errInMaintenance(device string) error {
+	return fmt.Errorf("%w: %s", controlStationUtils.ErrInMaintenance, device)
+}
+
+// SkipSwitches returns the switches that refuse to toggle the devices in
+// maintenance, for automation and bulk commands to leave them alone. Reading
+// the state is still allowed.
+func SkipSwitches(switches map[string]groupService.Switch, service MaintenanceService) map[string]groupService.Switch {
+	skipping := make(map[string]groupService.Switch, len(switches))
+	for name, sw := range switches {
+		name, toggleEnabled := name, sw.ToggleEnabled
+		skipping[name] = groupService.Switch{
+			GetInfo: sw.GetInfo,
+			ToggleEnabled: func(ctx context.Context) (domain.Switchable, error) {
+				if service.InMaintenance(name) {
+					return nil, errInMaintenance(name)
+				}
+				return toggleEnabled(ctx)
+			},
+		}
+	}
+	return skipping
+}
+
+type skippingACService struct {
+	acService.ACService
+	name    string
+	service MaintenanceService
+}
+
+// SkipAC returns the AC service that refuses to change the AC while it is in
+// maintenance.
+func SkipAC(ac acService.ACService, name string, service MaintenanceService) acService.ACService {
+	return &skippingACService{ACService: ac, name: name, service: service}
+}
+
+func (s *skippingACService) ToggleEnabled(ctx context.Context) (domain.ACInfo, error) {
+	if s.service.InMaintenance(s.name) {
+		return domain.ACInfo{}, errInMaintenance(s.name)
+	}
+	return s.ACService.ToggleEnabled(ctx)
+}
+
+func (s *skippingACService) UpdateACSettings(desiredSettings domain.ACInfo) (domain.ACInfo, error) {
+	if s.service.InMaintenance(s.name) {
+		return domain.ACInfo{}, errInMaintenance(s.name)
+	}
+	return s.ACService.UpdateACSettings(desiredSettings)
+}
+
+type skippingLightService struct {
+	lightService.LightService
+	name    string
+	service MaintenanceService
+}
+
+// SkipLight returns the light service that refuses to change the light while
+// it is in maintenance.
+func SkipLight(light lightService.LightService, name string, service MaintenanceService) lightService.LightService {
+	return &skippingLightService{LightService: light, name: name, service: service}
+}
+
+func (s *skippingLightService) ToggleEnabled(ctx context.Context) (domain.LightInfo, error) {
+	if s.service.InMaintenance(s.name) {
+		return domain.LightInfo{}, errInMaintenance(s.name)
+	}
+	return s.LightService.ToggleEnabled(ctx)
+}
+
+func (s *skippingLightService) SetBrightness(request domain.LightBrightnessRequest) (domain.LightInfo, error) {
+	if s.service.InMaintenance(s.name) {
+		return domain.LightInfo{}, errInMaintenance(s.name)
+	}
+	return s.LightService.SetBrightness(request)
+}
+
+func (s *skippingLightService) SetColor(request domain.LightColorRequest) (domain.LightInfo, error) {
+	if s.service.InMaintenance(s.name) {
+		return domain.LightInfo{}, errInMaintenance(s.name)
+	}
+	return s.LightService.SetColor(request)
+}
+
+type mutingAlertService struct {
+	alertService.AlertService
+	service MaintenanceService
+}
+
+// MuteAlerts returns the alert service that drops the alerts raised for devices
+// in maintenance.
+func MuteAlerts(alerts alertService.AlertService, service MaintenanceService) alertService.AlertService {
+	return &mutingAlertService{AlertService: alerts, service: service}
+}
+
+func (s *mutingAlertService) Raise(alert domain.Alert) error {
+	if s.service.InMaintenance(alert.Source) {
+		log.Printf("ALERT [%s] %s muted, the device is in maintenance: %s\n", alert.Severity, alert.Source, alert.Message)
+		return nil
+	}
+	return s.AlertService.Raise(alert)
+}
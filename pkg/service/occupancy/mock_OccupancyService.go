@@ -0,0 +1,186 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	time "time"
+
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOccupancyService is an autogenerated mock type for the OccupancyService type
+type MockOccupancyService struct {
+	mock.Mock
+}
+
+type MockOccupancyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOccupancyService) EXPECT() *MockOccupancyService_Expecter {
+	return &MockOccupancyService_Expecter{mock: &_m.Mock}
+}
+
+// GetOccupancy provides a mock function with given fields: roomID
+func (_m *MockOccupancyService) GetOccupancy(roomID string) (domain.RoomOccupancy, error) {
+	ret := _m.Called(roomID)
+
+	var r0 domain.RoomOccupancy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (domain.RoomOccupancy, error)); ok {
+		return rf(roomID)
+	}
+	if rf, ok := ret.Get(0).(func(string) domain.RoomOccupancy); ok {
+		r0 = rf(roomID)
+	} else {
+		r0 = ret.Get(0).(domain.RoomOccupancy)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(roomID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOccupancyService_GetOccupancy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOccupancy'
+type MockOccupancyService_GetOccupancy_Call struct {
+	*mock.Call
+}
+
+// GetOccupancy is a helper method to define mock.On call
+//   - roomID string
+func (_e *MockOccupancyService_Expecter) GetOccupancy(roomID interface{}) *MockOccupancyService_GetOccupancy_Call {
+	return &MockOccupancyService_GetOccupancy_Call{Call: _e.mock.On("GetOccupancy", roomID)}
+}
+
+func (_c *MockOccupancyService_GetOccupancy_Call) Run(run func(roomID string)) *MockOccupancyService_GetOccupancy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockOccupancyService_GetOccupancy_Call) Return(_a0 domain.RoomOccupancy, _a1 error) *MockOccupancyService_GetOccupancy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOccupancyService_GetOccupancy_Call) RunAndReturn(run func(string) (domain.RoomOccupancy, error)) *MockOccupancyService_GetOccupancy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOccupancy provides a mock function with given fields:
+func (_m *MockOccupancyService) ListOccupancy() []domain.RoomOccupancy {
+	ret := _m.Called()
+
+	var r0 []domain.RoomOccupancy
+	if rf, ok := ret.Get(0).(func() []domain.RoomOccupancy); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RoomOccupancy)
+		}
+	}
+
+	return r0
+}
+
+// MockOccupancyService_ListOccupancy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOccupancy'
+type MockOccupancyService_ListOccupancy_Call struct {
+	*mock.Call
+}
+
+// ListOccupancy is a helper method to define mock.On call
+func (_e *MockOccupancyService_Expecter) ListOccupancy() *MockOccupancyService_ListOccupancy_Call {
+	return &MockOccupancyService_ListOccupancy_Call{Call: _e.mock.On("ListOccupancy")}
+}
+
+func (_c *MockOccupancyService_ListOccupancy_Call) Run(run func()) *MockOccupancyService_ListOccupancy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockOccupancyService_ListOccupancy_Call) Return(_a0 []domain.RoomOccupancy) *MockOccupancyService_ListOccupancy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOccupancyService_ListOccupancy_Call) RunAndReturn(run func() []domain.RoomOccupancy) *MockOccupancyService_ListOccupancy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetOverride provides a mock function with given fields: roomID, window
+func (_m *MockOccupancyService) SetOverride(roomID string, window time.Duration) (domain.RoomOccupancy, error) {
+	ret := _m.Called(roomID, window)
+
+	var r0 domain.RoomOccupancy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration) (domain.RoomOccupancy, error)); ok {
+		return rf(roomID, window)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Duration) domain.RoomOccupancy); ok {
+		r0 = rf(roomID, window)
+	} else {
+		r0 = ret.Get(0).(domain.RoomOccupancy)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(roomID, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOccupancyService_SetOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOverride'
+type MockOccupancyService_SetOverride_Call struct {
+	*mock.Call
+}
+
+// SetOverride is a helper method to define mock.On call
+//   - roomID string
+//   - window time.Duration
+func (_e *MockOccupancyService_Expecter) SetOverride(roomID interface{}, window interface{}) *MockOccupancyService_SetOverride_Call {
+	return &MockOccupancyService_SetOverride_Call{Call: _e.mock.On("SetOverride", roomID, window)}
+}
+
+func (_c *MockOccupancyService_SetOverride_Call) Run(run func(roomID string, window time.Duration)) *MockOccupancyService_SetOverride_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockOccupancyService_SetOverride_Call) Return(_a0 domain.RoomOccupancy, _a1 error) *MockOccupancyService_SetOverride_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOccupancyService_SetOverride_Call) RunAndReturn(run func(string, time.Duration) (domain.RoomOccupancy, error)) *MockOccupancyService_SetOverride_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockOccupancyService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockOccupancyService creates a new instance of MockOccupancyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockOccupancyService(t mockConstructorTestingTNewMockOccupancyService) *MockOccupancyService {
+	mock := &MockOccupancyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/event"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	arbitrationService "github.com/pklimuk-eng-thesis/control-station/pkg/service/arbitration"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+//go:generate --name OccupancyService --output mock_occupancyService.go
+type OccupancyService interface {
+	ListOccupancy() []domain.RoomOccupancy
+	GetOccupancy(roomID string) (domain.RoomOccupancy, error)
+	SetOverride(roomID string, window time.Duration) (domain.RoomOccupancy, error)
+}
+
+type roomState struct {
+	occupancy domain.RoomOccupancy
+	presence  map[string]bool
+	timer     *time.Timer
+	// timers counts the started timers of the room, so that a timer that fired
+	// after being replaced or cancelled can tell it is stale.
+	timers int
+}
+
+type occupancyService struct {
+	config      domain.OccupancyConfig
+	registry    registry.Registry
+	switches    map[string]groupService.Switch
+	arbitration arbitrationService.ArbitrationService
+	now         func() time.Time
+
+	mu    sync.Mutex
+	rooms map[string]*roomState
+	// actionMu serializes switching the devices, so that a quick succession of
+	// transitions cannot toggle a device twice.
+	actionMu sync.Mutex
+	// switchedOff holds the ACs switched off because their room became vacant,
+	// which are switched back on once it is occupied again.
+	switchedOff map[string]bool
+}
+
+// NewOccupancyService starts tracking the occupancy of every registered room.
+// All rooms start vacant and the devices are left as they are until the first
+// transition. The switches are expected to be refused by the arbitration
+// service while a device is overridden.
+func NewOccupancyService(config domain.OccupancyConfig, registry registry.Registry,
+	switches map[string]groupService.Switch, arbitration arbitrationService.ArbitrationService,
+	eventBus event.Bus) OccupancyService {
+	s := &occupancyService{config: config, registry: registry, switches: switches, arbitration: arbitration,
+		now: time.Now, rooms: map[string]*roomState{}, switchedOff: map[string]bool{}}
+	now := s.now()
+	for _, room := range registry.ListRooms() {
+		s.rooms[room.ID] = &roomState{
+			occupancy: domain.RoomOccupancy{Room: room.ID, State: domain.RoomVacant, Since: now},
+			presence:  map[string]bool{},
+		}
+	}
+	eventBus.Subscribe(s.handleEvent)
+	return s
+}
+
+func (s *occupancyService) ListOccupancy() []domain.RoomOccupancy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	occupancy := make([]domain.RoomOccupancy, 0, len(s.rooms))
+	for roomID, room := range s.rooms {
+		roomOccupancy := room.occupancy
+		roomOccupancy.OverrideUntil = s.overrideUntil(roomID)
+		occupancy = append(occupancy, roomOccupancy)
+	}
+	sort.Slice(occupancy, func(i, j int) bool {
+		return occupancy[i].Room < occupancy[j].Room
+	})
+	return occupancy
+}
+
+func (s *occupancyService) GetOccupancy(roomID string) (domain.RoomOccupancy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, ok := s.rooms[roomID]
+	if !ok {
+		return domain.RoomOccupancy{}, registry.ErrRoomNotRegistered
+	}
+	occupancy := room.occupancy
+	occupancy.OverrideUntil = s.overrideUntil(roomID)
+	return occupancy, nil
+}
+
+// SetOverride overrides every device of the room the automation switches in the
+// arbitration service for the window, e.g. after the lights were switched by
+// hand. A zero window ends the override.
+func (s *occupancyService) SetOverride(roomID string, window time.Duration) (domain.RoomOccupancy, error) {
+	if window < 0 {
+		validationErr := &controlStationUtils.ValidationError{}
+		validationErr.Add("minutes", "must not be negative")
+		return domain.RoomOccupancy{}, validationErr
+	}
+	if _, err := s.GetOccupancy(roomID); err != nil {
+		return domain.RoomOccupancy{}, err
+	}
+
+	for _, device := range s.automated(roomID) {
+		if _, err := s.arbitration.Override(device.Name, window); err != nil {
+			return domain.RoomOccupancy{}, err
+		}
+	}
+	if window == 0 {
+		s.reconcile(roomID)
+	} else {
+		time.AfterFunc(window, func() {
+			s.reconcile(roomID)
+		})
+	}
+	return s.GetOccupancy(roomID)
+}
+
+func (s *occupancyService) handleEvent(e domain.DeviceEvent) {
+	isPresence := contains(s.config.PresenceSensors, e.Device)
+	if !isPresence && !contains(s.config.DoorSensors, e.Device) {
+		return
+	}
+	device, err := s.registry.Get(e.Device)
+	if err != nil || device.Room == "" {
+		return
+	}
+
+	s.mu.Lock()
+	room, ok := s.rooms[device.Room]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+
+	now := s.now()
+	if isPresence {
+		room.presence[e.Device] = e.Type == domain.EventDetected
+	}
+	if e.Type == domain.EventDetected {
+		room.occupancy.LastActivity = &now
+	}
+
+	changed := false
+	if e.Type == domain.EventDetected && room.occupancy.State == domain.RoomVacant {
+		room.occupancy.State = domain.RoomOccupied
+		room.occupancy.Since = now
+		room.occupancy.TriggeredBy = e.Device
+		changed = true
+	}
+
+	// Every activity extends the occupancy. While someone is present the room
+	// stays occupied without a deadline.
+	switch {
+	case room.occupancy.State != domain.RoomOccupied:
+	case anyPresent(room.presence):
+		s.stopTimer(room)
+	case e.Type == domain.EventDetected || isPresence:
+		s.startTimer(device.Room, room, now)
+	}
+	s.mu.Unlock()
+
+	if changed {
+		go s.act(device.Room, domain.RoomOccupied)
+	}
+}
+
+func (s *occupancyService) startTimer(roomID string, room *roomState, now time.Time) {
+	s.stopTimer(room)
+	timeout := s.timeout(roomID)
+	vacantAt := now.Add(timeout)
+	room.occupancy.VacantAt = &vacantAt
+	room.timers++
+	timerID := room.timers
+	room.timer = time.AfterFunc(timeout, func() {
+		s.vacate(roomID, timerID)
+	})
+}
+
+func (s *occupancyService) stopTimer(room *roomState) {
+	if room.timer != nil {
+		room.timer.Stop()
+		room.timer = nil
+	}
+	room.occupancy.VacantAt = nil
+}
+
+func (s *occupancyService) vacate(roomID string, timerID int) {
+	s.mu.Lock()
+	room := s.rooms[roomID]
+	if room.timer == nil || room.timers != timerID {
+		s.mu.Unlock()
+		return
+	}
+	room.timer = nil
+	room.occupancy.State = domain.RoomVacant
+	room.occupancy.Since = s.now()
+	room.occupancy.TriggeredBy = ""
+	room.occupancy.VacantAt = nil
+	s.mu.Unlock()
+
+	s.act(roomID, domain.RoomVacant)
+}
+
+// reconcile switches off the devices of a room that became vacant during an
+// override. An occupied room is left as it is, since the override usually means
+// someone in it wanted the devices the way they are. Devices still overridden
+// are refused by the arbitration service.
+func (s *occupancyService) reconcile(roomID string) {
+	occupancy, err := s.GetOccupancy(roomID)
+	if err == nil && occupancy.State == domain.RoomVacant {
+		s.act(roomID, domain.RoomVacant)
+	}
+}
+
+// act switches the lights of the room on or off with the occupancy. The ACs are
+// switched off when the room becomes vacant and only those are switched back
+// on when it is occupied again.
+func (s *occupancyService) act(roomID string, state domain.OccupancyState) {
+	s.actionMu.Lock()
+	defer s.actionMu.Unlock()
+
+	s.mu.Lock()
+	current := s.rooms[roomID].occupancy.State
+	s.mu.Unlock()
+	if current != state {
+		return
+	}
+
+	occupied := state == domain.RoomOccupied
+	for _, device := range s.automated(roomID) {
+		deviceSwitch := s.switches[device.Name]
+		switch device.Kind {
+		case domain.KindLight:
+			s.ensure(device.Name, deviceSwitch, occupied)
+		case domain.KindAC:
+			if !occupied {
+				if s.ensure(device.Name, deviceSwitch, false) {
+					s.switchedOff[device.Name] = true
+				}
+			} else if s.switchedOff[device.Name] {
+				delete(s.switchedOff, device.Name)
+				s.ensure(device.Name, deviceSwitch, true)
+			}
+		}
+	}
+}
+
+// ensure switches the device to the given state and reports whether it had to
+// be switched.
+func (s *occupancyService) ensure(name string, deviceSwitch groupService.Switch, enabled bool) bool {
+	info, err := deviceSwitch.GetInfo(context.Background())
+	if err != nil {
+		log.Printf("Failed to read '%s' for occupancy automation: %s\n", name, err)
+		return false
+	}
+	if info.IsEnabled() == enabled {
+		return false
+	}
+
+	if _, err := deviceSwitch.ToggleEnabled(context.Background()); err != nil {
+		if !errors.Is(err, controlStationUtils.ErrOverridden) {
+			log.Printf("Failed to switch '%s' for occupancy automation: %s\n", name, err)
+		}
+		return false
+	}
+	return true
+}
+
+// automated returns the lights and ACs of the room that can be switched.
+func (s *occupancyService) automated(roomID string) []domain.RegisteredDevice {
+	var devices []domain.RegisteredDevice
+	for _, device := range s.registry.ListByRooms(roomID) {
+		if _, ok := s.switches[device.Name]; ok && (device.Kind == domain.KindLight || device.Kind == domain.KindAC) {
+			devices = append(devices, device)
+		}
+	}
+	return devices
+}
+
+// overrideUntil returns the latest end of the overrides of the automated
+// devices of the room, if any of them is overridden.
+func (s *occupancyService) overrideUntil(roomID string) *time.Time {
+	var overrideUntil *time.Time
+	for _, device := range s.automated(roomID) {
+		control, err := s.arbitration.GetControl(device.Name)
+		if err != nil || control.OverrideUntil == nil {
+			continue
+		}
+		if overrideUntil == nil || control.OverrideUntil.After(*overrideUntil) {
+			overrideUntil = control.OverrideUntil
+		}
+	}
+	return overrideUntil
+}
+
+func (s *occupancyService) timeout(roomID string) time.Duration {
+	if timeout, ok := s.config.RoomTimeouts[roomID]; ok {
+		return timeout
+	}
+	return s.config.VacancyTimeout
+}
+
+func anyPresent(presence map[string]bool) bool {
+	for _, present := range presence {
+		if present {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
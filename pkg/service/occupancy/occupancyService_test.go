@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/event"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	arbitrationService "github.com/pklimuk-eng-thesis/control-station/pkg/service/arbitration"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTimeout = 30 * time.Millisecond
+
+// fakeSwitch is a switchable device that records how often it was toggled.
+type fakeSwitch struct {
+	mu      sync.Mutex
+	enabled bool
+	toggles int
+}
+
+func (f *fakeSwitch) toSwitch() groupService.Switch {
+	return groupService.Switch{
+		GetInfo: func(ctx context.Context) (domain.Switchable, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return domain.LightInfo{Enabled: f.enabled}, nil
+		},
+		ToggleEnabled: func(ctx context.Context) (domain.Switchable, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.toggles++
+			f.enabled = !f.enabled
+			return domain.LightInfo{Enabled: f.enabled}, nil
+		},
+	}
+}
+
+func (f *fakeSwitch) isEnabled() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.enabled
+}
+
+type testHome struct {
+	service  *occupancyService
+	eventBus event.Bus
+	light    *fakeSwitch
+	ac       *fakeSwitch
+}
+
+func newTestHome(t *testing.T, acEnabled bool) testHome {
+	deviceRegistry := registry.NewRegistry()
+	deviceRegistry.RegisterRoom(domain.Room{ID: "livingRoom"})
+	deviceRegistry.RegisterRoom(domain.Room{ID: "bedroom"})
+	for _, device := range []domain.RegisteredDevice{
+		{Name: "presenceSensor", Kind: domain.KindSensor, Room: "livingRoom"},
+		{Name: "doorsSensor", Kind: domain.KindSensor, Room: "livingRoom"},
+		{Name: "gasSensor", Kind: domain.KindSensor, Room: "livingRoom"},
+		{Name: "smartBulb", Kind: domain.KindLight, Room: "livingRoom"},
+		{Name: "ac", Kind: domain.KindAC, Room: "livingRoom"},
+		{Name: "smartPlug", Kind: domain.KindPlug, Room: "livingRoom"},
+	} {
+		assert.NoError(t, deviceRegistry.Register(device))
+	}
+
+	home := testHome{eventBus: event.NewBus(), light: &fakeSwitch{}, ac: &fakeSwitch{enabled: acEnabled}}
+	plug := &fakeSwitch{enabled: true}
+	switches := map[string]groupService.Switch{"smartBulb": home.light.toSwitch(), "ac": home.ac.toSwitch(),
+		"smartPlug": plug.toSwitch()}
+	config := domain.OccupancyConfig{PresenceSensors: []string{"presenceSensor"}, DoorSensors: []string{"doorsSensor"},
+		VacancyTimeout: time.Hour, RoomTimeouts: map[string]time.Duration{"livingRoom": testTimeout}}
+	arbitration := arbitrationService.NewArbitrationService(deviceRegistry, time.Hour)
+	home.service = NewOccupancyService(config, deviceRegistry,
+		arbitrationService.Switches(switches, arbitration, domain.SourceRule), arbitration, home.eventBus).(*occupancyService)
+	t.Cleanup(func() {
+		assert.True(t, plug.isEnabled(), "devices other than lights and ACs must not be switched")
+	})
+	return home
+}
+
+func (h testHome) publish(device string, eventType domain.EventType) {
+	h.eventBus.Publish(domain.DeviceEvent{Device: device, Type: eventType, Time: time.Now()})
+}
+
+func (h testHome) state(t *testing.T) domain.OccupancyState {
+	occupancy, err := h.service.GetOccupancy("livingRoom")
+	assert.NoError(t, err)
+	return occupancy.State
+}
+
+func TestListOccupancy(t *testing.T) {
+	home := newTestHome(t, false)
+
+	got := home.service.ListOccupancy()
+
+	assert.Len(t, got, 2)
+	assert.Equal(t, "bedroom", got[0].Room)
+	assert.Equal(t, domain.RoomVacant, got[0].State)
+	assert.Equal(t, "livingRoom", got[1].Room)
+}
+
+func TestGetOccupancy_UnknownRoom(t *testing.T) {
+	home := newTestHome(t, false)
+
+	_, err := home.service.GetOccupancy("kitchen")
+
+	assert.ErrorIs(t, err, registry.ErrRoomNotRegistered)
+}
+
+func TestPresence_OccupiesUntilClearedAndTimedOut(t *testing.T) {
+	home := newTestHome(t, true)
+
+	home.publish("presenceSensor", domain.EventDetected)
+	assert.Equal(t, domain.RoomOccupied, home.state(t))
+	assert.Eventually(t, home.light.isEnabled, time.Second, time.Millisecond)
+
+	time.Sleep(2 * testTimeout)
+	assert.Equal(t, domain.RoomOccupied, home.state(t), "presence must hold the room occupied")
+
+	home.publish("presenceSensor", domain.EventCleared)
+	occupancy, _ := home.service.GetOccupancy("livingRoom")
+	assert.NotNil(t, occupancy.VacantAt)
+
+	assert.Eventually(t, func() bool { return !home.light.isEnabled() && !home.ac.isEnabled() },
+		time.Second, time.Millisecond)
+	assert.Equal(t, domain.RoomVacant, home.state(t))
+
+	home.publish("doorsSensor", domain.EventDetected)
+	assert.Eventually(t, func() bool { return home.light.isEnabled() && home.ac.isEnabled() },
+		time.Second, time.Millisecond, "the AC switched off on vacancy must be switched back on")
+}
+
+func TestDoor_RetriggerExtendsOccupancy(t *testing.T) {
+	home := newTestHome(t, false)
+
+	home.publish("doorsSensor", domain.EventDetected)
+	for i := 0; i < 4; i++ {
+		time.Sleep(testTimeout / 2)
+		home.publish("doorsSensor", domain.EventDetected)
+	}
+	assert.Equal(t, domain.RoomOccupied, home.state(t))
+
+	assert.Eventually(t, func() bool { return home.state(t) == domain.RoomVacant }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return !home.light.isEnabled() }, time.Second, time.Millisecond)
+	assert.False(t, home.ac.isEnabled(), "an AC that was off must stay off")
+}
+
+func TestIgnoredEvents(t *testing.T) {
+	home := newTestHome(t, false)
+
+	home.publish("gasSensor", domain.EventDetected)
+	home.publish("doorsSensor", domain.EventCleared)
+
+	assert.Equal(t, domain.RoomVacant, home.state(t))
+}
+
+func TestSetOverride(t *testing.T) {
+	home := newTestHome(t, false)
+	home.publish("doorsSensor", domain.EventDetected)
+	assert.Eventually(t, home.light.isEnabled, time.Second, time.Millisecond)
+
+	got, err := home.service.SetOverride("livingRoom", time.Hour)
+	assert.NoError(t, err)
+	assert.NotNil(t, got.OverrideUntil)
+
+	assert.Eventually(t, func() bool { return home.state(t) == domain.RoomVacant }, time.Second, time.Millisecond)
+	assert.True(t, home.light.isEnabled(), "the override must keep the light on")
+
+	got, err = home.service.SetOverride("livingRoom", 0)
+	assert.NoError(t, err)
+	assert.Nil(t, got.OverrideUntil)
+	assert.False(t, home.light.isEnabled(), "a vacant room must be switched off when the override ends")
+}
+
+func TestSetOverride_Expires(t *testing.T) {
+	home := newTestHome(t, false)
+	home.publish("doorsSensor", domain.EventDetected)
+	assert.Eventually(t, home.light.isEnabled, time.Second, time.Millisecond)
+
+	_, err := home.service.SetOverride("livingRoom", 3*testTimeout)
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return !home.light.isEnabled() }, time.Second, time.Millisecond)
+	occupancy, _ := home.service.GetOccupancy("livingRoom")
+	assert.Nil(t, occupancy.OverrideUntil)
+}
+
+func TestSetOverride_Invalid(t *testing.T) {
+	home := newTestHome(t, false)
+
+	_, err := home.service.SetOverride("kitchen", time.Hour)
+	assert.ErrorIs(t, err, registry.ErrRoomNotRegistered)
+
+	_, err = home.service.SetOverride("livingRoom", -time.Minute)
+	var validationErr *controlStationUtils.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
@@ -0,0 +1,395 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+	time "time"
+
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPlugService is an autogenerated mock type for the PlugService type
+type MockPlugService struct {
+	mock.Mock
+}
+
+type MockPlugService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPlugService) EXPECT() *MockPlugService_Expecter {
+	return &MockPlugService_Expecter{mock: &_m.Mock}
+}
+
+// GetEnergyTotals provides a mock function with given fields: period, from, to
+func (_m *MockPlugService) GetEnergyTotals(period domain.EnergyPeriod, from time.Time, to time.Time) (domain.EnergyTotals, error) {
+	ret := _m.Called(period, from, to)
+
+	var r0 domain.EnergyTotals
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.EnergyPeriod, time.Time, time.Time) (domain.EnergyTotals, error)); ok {
+		return rf(period, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(domain.EnergyPeriod, time.Time, time.Time) domain.EnergyTotals); ok {
+		r0 = rf(period, from, to)
+	} else {
+		r0 = ret.Get(0).(domain.EnergyTotals)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.EnergyPeriod, time.Time, time.Time) error); ok {
+		r1 = rf(period, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPlugService_GetEnergyTotals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEnergyTotals'
+type MockPlugService_GetEnergyTotals_Call struct {
+	*mock.Call
+}
+
+// GetEnergyTotals is a helper method to define mock.On call
+//   - period domain.EnergyPeriod
+//   - from time.Time
+//   - to time.Time
+func (_e *MockPlugService_Expecter) GetEnergyTotals(period interface{}, from interface{}, to interface{}) *MockPlugService_GetEnergyTotals_Call {
+	return &MockPlugService_GetEnergyTotals_Call{Call: _e.mock.On("GetEnergyTotals", period, from, to)}
+}
+
+func (_c *MockPlugService_GetEnergyTotals_Call) Run(run func(period domain.EnergyPeriod, from time.Time, to time.Time)) *MockPlugService_GetEnergyTotals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.EnergyPeriod), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockPlugService_GetEnergyTotals_Call) Return(_a0 domain.EnergyTotals, _a1 error) *MockPlugService_GetEnergyTotals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPlugService_GetEnergyTotals_Call) RunAndReturn(run func(domain.EnergyPeriod, time.Time, time.Time) (domain.EnergyTotals, error)) *MockPlugService_GetEnergyTotals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInfo provides a mock function with given fields:
+func (_m *MockPlugService) GetInfo() (domain.PlugInfo, error) {
+	ret := _m.Called()
+
+	var r0 domain.PlugInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (domain.PlugInfo, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() domain.PlugInfo); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.PlugInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPlugService_GetInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInfo'
+type MockPlugService_GetInfo_Call struct {
+	*mock.Call
+}
+
+// GetInfo is a helper method to define mock.On call
+func (_e *MockPlugService_Expecter) GetInfo() *MockPlugService_GetInfo_Call {
+	return &MockPlugService_GetInfo_Call{Call: _e.mock.On("GetInfo")}
+}
+
+func (_c *MockPlugService_GetInfo_Call) Run(run func()) *MockPlugService_GetInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPlugService_GetInfo_Call) Return(_a0 domain.PlugInfo, _a1 error) *MockPlugService_GetInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPlugService_GetInfo_Call) RunAndReturn(run func() (domain.PlugInfo, error)) *MockPlugService_GetInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPlugLogsFromDataServiceLimitN provides a mock function with given fields: limit
+func (_m *MockPlugService) GetPlugLogsFromDataServiceLimitN(limit int) ([]domain.PlugData, error) {
+	ret := _m.Called(limit)
+
+	var r0 []domain.PlugData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]domain.PlugData, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []domain.PlugData); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PlugData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPlugService_GetPlugLogsFromDataServiceLimitN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPlugLogsFromDataServiceLimitN'
+type MockPlugService_GetPlugLogsFromDataServiceLimitN_Call struct {
+	*mock.Call
+}
+
+// GetPlugLogsFromDataServiceLimitN is a helper method to define mock.On call
+//   - limit int
+func (_e *MockPlugService_Expecter) GetPlugLogsFromDataServiceLimitN(limit interface{}) *MockPlugService_GetPlugLogsFromDataServiceLimitN_Call {
+	return &MockPlugService_GetPlugLogsFromDataServiceLimitN_Call{Call: _e.mock.On("GetPlugLogsFromDataServiceLimitN", limit)}
+}
+
+func (_c *MockPlugService_GetPlugLogsFromDataServiceLimitN_Call) Run(run func(limit int)) *MockPlugService_GetPlugLogsFromDataServiceLimitN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockPlugService_GetPlugLogsFromDataServiceLimitN_Call) Return(_a0 []domain.PlugData, _a1 error) *MockPlugService_GetPlugLogsFromDataServiceLimitN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPlugService_GetPlugLogsFromDataServiceLimitN_Call) RunAndReturn(run func(int) ([]domain.PlugData, error)) *MockPlugService_GetPlugLogsFromDataServiceLimitN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTariff provides a mock function with given fields:
+func (_m *MockPlugService) GetTariff() domain.Tariff {
+	ret := _m.Called()
+
+	var r0 domain.Tariff
+	if rf, ok := ret.Get(0).(func() domain.Tariff); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.Tariff)
+	}
+
+	return r0
+}
+
+// MockPlugService_GetTariff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTariff'
+type MockPlugService_GetTariff_Call struct {
+	*mock.Call
+}
+
+// GetTariff is a helper method to define mock.On call
+func (_e *MockPlugService_Expecter) GetTariff() *MockPlugService_GetTariff_Call {
+	return &MockPlugService_GetTariff_Call{Call: _e.mock.On("GetTariff")}
+}
+
+func (_c *MockPlugService_GetTariff_Call) Run(run func()) *MockPlugService_GetTariff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPlugService_GetTariff_Call) Return(_a0 domain.Tariff) *MockPlugService_GetTariff_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPlugService_GetTariff_Call) RunAndReturn(run func() domain.Tariff) *MockPlugService_GetTariff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryPlugLogsFromDataService provides a mock function with given fields: query
+func (_m *MockPlugService) QueryPlugLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.PlugData], error) {
+	ret := _m.Called(query)
+
+	var r0 domain.LogPage[domain.PlugData]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.LogQuery) (domain.LogPage[domain.PlugData], error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(domain.LogQuery) domain.LogPage[domain.PlugData]); ok {
+		r0 = rf(query)
+	} else {
+		r0 = ret.Get(0).(domain.LogPage[domain.PlugData])
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.LogQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPlugService_QueryPlugLogsFromDataService_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryPlugLogsFromDataService'
+type MockPlugService_QueryPlugLogsFromDataService_Call struct {
+	*mock.Call
+}
+
+// QueryPlugLogsFromDataService is a helper method to define mock.On call
+//   - query domain.LogQuery
+func (_e *MockPlugService_Expecter) QueryPlugLogsFromDataService(query interface{}) *MockPlugService_QueryPlugLogsFromDataService_Call {
+	return &MockPlugService_QueryPlugLogsFromDataService_Call{Call: _e.mock.On("QueryPlugLogsFromDataService", query)}
+}
+
+func (_c *MockPlugService_QueryPlugLogsFromDataService_Call) Run(run func(query domain.LogQuery)) *MockPlugService_QueryPlugLogsFromDataService_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.LogQuery))
+	})
+	return _c
+}
+
+func (_c *MockPlugService_QueryPlugLogsFromDataService_Call) Return(_a0 domain.LogPage[domain.PlugData], _a1 error) *MockPlugService_QueryPlugLogsFromDataService_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPlugService_QueryPlugLogsFromDataService_Call) RunAndReturn(run func(domain.LogQuery) (domain.LogPage[domain.PlugData], error)) *MockPlugService_QueryPlugLogsFromDataService_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadInfo provides a mock function with given fields: ctx
+func (_m *MockPlugService) ReadInfo(ctx context.Context) (domain.PlugInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 domain.PlugInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.PlugInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.PlugInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.PlugInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPlugService_ReadInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadInfo'
+type MockPlugService_ReadInfo_Call struct {
+	*mock.Call
+}
+
+// ReadInfo is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPlugService_Expecter) ReadInfo(ctx interface{}) *MockPlugService_ReadInfo_Call {
+	return &MockPlugService_ReadInfo_Call{Call: _e.mock.On("ReadInfo", ctx)}
+}
+
+func (_c *MockPlugService_ReadInfo_Call) Run(run func(ctx context.Context)) *MockPlugService_ReadInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockPlugService_ReadInfo_Call) Return(_a0 domain.PlugInfo, _a1 error) *MockPlugService_ReadInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPlugService_ReadInfo_Call) RunAndReturn(run func(context.Context) (domain.PlugInfo, error)) *MockPlugService_ReadInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ToggleEnabled provides a mock function with given fields: ctx
+func (_m *MockPlugService) ToggleEnabled(ctx context.Context) (domain.PlugInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 domain.PlugInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.PlugInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.PlugInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.PlugInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPlugService_ToggleEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ToggleEnabled'
+type MockPlugService_ToggleEnabled_Call struct {
+	*mock.Call
+}
+
+// ToggleEnabled is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPlugService_Expecter) ToggleEnabled(ctx interface{}) *MockPlugService_ToggleEnabled_Call {
+	return &MockPlugService_ToggleEnabled_Call{Call: _e.mock.On("ToggleEnabled", ctx)}
+}
+
+func (_c *MockPlugService_ToggleEnabled_Call) Run(run func(ctx context.Context)) *MockPlugService_ToggleEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockPlugService_ToggleEnabled_Call) Return(_a0 domain.PlugInfo, _a1 error) *MockPlugService_ToggleEnabled_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPlugService_ToggleEnabled_Call) RunAndReturn(run func(context.Context) (domain.PlugInfo, error)) *MockPlugService_ToggleEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockPlugService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockPlugService creates a new instance of MockPlugService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockPlugService(t mockConstructorTestingTNewMockPlugService) *MockPlugService {
+	mock := &MockPlugService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+//go:generate --name PlugService --output mock_plugService.go
+type PlugService interface {
+	GetInfo() (domain.PlugInfo, error)
+	ReadInfo(ctx context.Context) (domain.PlugInfo, error)
+	ToggleEnabled(ctx context.Context) (domain.PlugInfo, error)
+	GetPlugLogsFromDataServiceLimitN(limit int) ([]domain.PlugData, error)
+	QueryPlugLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.PlugData], error)
+	GetEnergyTotals(period domain.EnergyPeriod, from time.Time, to time.Time) (domain.EnergyTotals, error)
+	GetTariff() domain.Tariff
+}
+
+type plugService struct {
+	plug     *domain.Plug
+	tariff   domain.Tariff
+	location *time.Location
+}
+
+func NewPlugService(plug *domain.Plug, tariff domain.Tariff) PlugService {
+	return &plugService{plug: plug, tariff: tariff, location: time.Local}
+}
+
+func (s *plugService) GetInfo() (domain.PlugInfo, error) {
+	address := s.plug.Address + controlStationUtils.InfoEndpoint
+	return controlStationUtils.MakeGetRequest(address, s.plug.Name, domain.PlugInfo{Enabled: false})
+}
+
+func (s *plugService) ReadInfo(ctx context.Context) (domain.PlugInfo, error) {
+	address := s.plug.Address + controlStationUtils.InfoEndpoint
+	return controlStationUtils.FetchJSONContext[domain.PlugInfo](ctx, address, s.plug.Name)
+}
+
+func (s *plugService) ToggleEnabled(ctx context.Context) (domain.PlugInfo, error) {
+	address := s.plug.Address + controlStationUtils.EnabledEndpoint
+	return controlStationUtils.MakePatchRequestContext(ctx, address, s.plug.Name, nil, domain.PlugInfo{Enabled: false})
+}
+
+func (s *plugService) GetPlugLogsFromDataServiceLimitN(limit int) ([]domain.PlugData, error) {
+	return controlStationUtils.GetLogsFromDataServiceLimitN[domain.PlugData](s.plug.Name, limit)
+}
+
+func (s *plugService) QueryPlugLogsFromDataService(query domain.LogQuery) (domain.LogPage[domain.PlugData], error) {
+	return controlStationUtils.QueryLogsFromDataService[domain.PlugData](s.plug.Name, query)
+}
+
+// GetEnergyTotals sums up the consumption between consecutive cumulative
+// energy readings logged from from until to. The consumption is assigned to
+// the period of the later reading of each pair. A period is partial unless
+// there are readings at or before its start and at or after its end. The cost
+// is left out when no energy price is configured. The totals are truncated
+// when the readings could not be read for the whole range.
+func (s *plugService) GetEnergyTotals(period domain.EnergyPeriod, from time.Time,
+	to time.Time) (domain.EnergyTotals, error) {
+	fetched, err := s.energyReadings(from, to)
+	if err != nil {
+		return domain.EnergyTotals{}, err
+	}
+	readings := fetched.readings
+
+	totals := []domain.EnergyTotal{}
+	for i := 1; i < len(readings); i++ {
+		consumed := float64(*readings[i].Energy - *readings[i-1].Energy)
+		if consumed < 0 {
+			// The meter was reset, so the reading is the consumption since the reset.
+			consumed = float64(*readings[i].Energy)
+		}
+
+		periodStart := s.periodStart(readings[i].CreatedAt, period)
+		if len(totals) == 0 || !totals[len(totals)-1].PeriodStart.Equal(periodStart) {
+			totals = append(totals, domain.EnergyTotal{PeriodStart: periodStart})
+		}
+		totals[len(totals)-1].Energy += consumed
+	}
+
+	for i := range totals {
+		periodEnd := s.periodEnd(totals[i].PeriodStart, period)
+		totals[i].Partial = readings[0].CreatedAt.After(totals[i].PeriodStart) ||
+			readings[len(readings)-1].CreatedAt.Before(periodEnd)
+		if s.tariff.PricePerKWh > 0 {
+			cost := totals[i].Energy * s.tariff.PricePerKWh
+			totals[i].Cost = &cost
+			totals[i].Currency = s.tariff.Currency
+		}
+	}
+	return domain.EnergyTotals{
+		Totals:      totals,
+		Truncated:   fetched.scannedFrom != nil || fetched.cutOff,
+		ScannedFrom: fetched.scannedFrom,
+	}, nil
+}
+
+// maxEnergyPages bounds the log pages read for the energy totals, so that a
+// long range can not make a single request read the whole log history.
+const maxEnergyPages = 20
+
+// fetchedReadings are the energy readings of a range. scannedFrom is set when
+// the logs could only be searched since then, and cutOff when the readings end
+// early because the page limit was reached.
+type fetchedReadings struct {
+	readings    []domain.PlugData
+	scannedFrom *time.Time
+	cutOff      bool
+}
+
+// energyReadings returns the logs with an energy reading from from until to,
+// oldest first, reading at most maxEnergyPages pages.
+func (s *plugService) energyReadings(from time.Time, to time.Time) (fetchedReadings, error) {
+	var fetched fetchedReadings
+	query := domain.LogQuery{From: &from, To: &to, Order: domain.SortAsc, Limit: controlStationUtils.MaxLogQueryLimit()}
+	for pages := 1; ; pages++ {
+		page, err := s.QueryPlugLogsFromDataService(query)
+		if err != nil {
+			return fetchedReadings{}, err
+		}
+		if page.Truncated && page.ScannedFrom != nil {
+			fetched.scannedFrom = page.ScannedFrom
+		}
+		for _, record := range page.Logs {
+			if record.Energy != nil {
+				fetched.readings = append(fetched.readings, record)
+			}
+		}
+		if page.NextCursor == "" {
+			return fetched, nil
+		}
+		if pages == maxEnergyPages {
+			log.Printf("Energy totals of '%s' cut off after %d pages of logs\n", s.plug.Name, maxEnergyPages)
+			fetched.cutOff = true
+			return fetched, nil
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+func (s *plugService) GetTariff() domain.Tariff {
+	return s.tariff
+}
+
+func (s *plugService) periodStart(t time.Time, period domain.EnergyPeriod) time.Time {
+	t = t.In(s.location)
+	if period == domain.EnergyPeriodMonthly {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, s.location)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.location)
+}
+
+func (s *plugService) periodEnd(periodStart time.Time, period domain.EnergyPeriod) time.Time {
+	if period == domain.EnergyPeriodMonthly {
+		return periodStart.AddDate(0, 1, 0)
+	}
+	return periodStart.AddDate(0, 0, 1)
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func floatPtr(v float32) *float32 {
+	return &v
+}
+
+func TestNewPlugService(t *testing.T) {
+	plug := domain.Plug{Name: "test", Address: "http://test"}
+	service := NewPlugService(&plug, domain.Tariff{PricePerKWh: 0.5, Currency: "PLN"})
+	assert.NotNil(t, service)
+}
+
+func TestGetInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		ts      *httptest.Server
+		want    domain.PlugInfo
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"enabled": true, "power": 60.5, "voltage": 230, "current": 0.26, "energy": 12.5}`))
+			})),
+			want: domain.PlugInfo{Enabled: true, Power: floatPtr(60.5), Voltage: floatPtr(230),
+				Current: floatPtr(0.26), Energy: floatPtr(12.5)},
+			wantErr: false,
+		},
+		{
+			name: "WithoutMeter",
+			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"enabled": false}`))
+			})),
+			want:    domain.PlugInfo{Enabled: false},
+			wantErr: false,
+		},
+		{
+			name: "Failure",
+			ts: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			})),
+			want:    domain.PlugInfo{},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer test.ts.Close()
+			service := &plugService{plug: &domain.Plug{Name: "test", Address: test.ts.URL}}
+			got, err := service.GetInfo()
+
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantErr, err != nil)
+		})
+	}
+}
+
+func TestToggleEnabled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(domain.PlugInfo{Enabled: true})
+	}))
+	defer ts.Close()
+
+	service := &plugService{plug: &domain.Plug{Name: "test", Address: ts.URL}}
+	got, err := service.ToggleEnabled(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PlugInfo{Enabled: true}, got)
+}
+
+func TestToggleEnabled_ContextDone(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	service := &plugService{plug: &domain.Plug{Name: "test", Address: ts.URL}}
+	_, err := service.ToggleEnabled(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func floatPtr64(v float64) *float64 {
+	return &v
+}
+
+func energyTestServer(t *testing.T, logs []domain.PlugData) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/test/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(logs)
+	}))
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+	return ts
+}
+
+func TestGetEnergyTotals(t *testing.T) {
+	logs := []domain.PlugData{
+		{ID: 1, CreatedAt: time.Date(2023, 1, 30, 8, 0, 0, 0, time.UTC), Energy: floatPtr(10)},
+		{ID: 2, CreatedAt: time.Date(2023, 1, 30, 18, 0, 0, 0, time.UTC)},
+		{ID: 3, CreatedAt: time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), Energy: floatPtr(12)},
+		{ID: 4, CreatedAt: time.Date(2023, 1, 31, 20, 0, 0, 0, time.UTC), Energy: floatPtr(13)},
+		{ID: 5, CreatedAt: time.Date(2023, 1, 31, 22, 0, 0, 0, time.UTC), Energy: floatPtr(1)},
+		{ID: 6, CreatedAt: time.Date(2023, 2, 1, 8, 0, 0, 0, time.UTC), Energy: floatPtr(1.5)},
+	}
+	from, to := time.Date(2023, 1, 30, 0, 0, 0, 0, time.UTC), time.Date(2023, 2, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		period domain.EnergyPeriod
+		tariff domain.Tariff
+		want   []domain.EnergyTotal
+	}{
+		{
+			name:   "Daily",
+			period: domain.EnergyPeriodDaily,
+			tariff: domain.Tariff{PricePerKWh: 0.5, Currency: "PLN"},
+			want: []domain.EnergyTotal{
+				{PeriodStart: time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), Energy: 4, Cost: floatPtr64(2), Currency: "PLN"},
+				{PeriodStart: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), Energy: 0.5, Partial: true, Cost: floatPtr64(0.25),
+					Currency: "PLN"},
+			},
+		},
+		{
+			name:   "Monthly",
+			period: domain.EnergyPeriodMonthly,
+			tariff: domain.Tariff{PricePerKWh: 0.5, Currency: "PLN"},
+			want: []domain.EnergyTotal{
+				{PeriodStart: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Energy: 4, Partial: true, Cost: floatPtr64(2),
+					Currency: "PLN"},
+				{PeriodStart: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), Energy: 0.5, Partial: true, Cost: floatPtr64(0.25),
+					Currency: "PLN"},
+			},
+		},
+		{
+			name:   "NoPrice",
+			period: domain.EnergyPeriodMonthly,
+			tariff: domain.Tariff{Currency: "PLN"},
+			want: []domain.EnergyTotal{
+				{PeriodStart: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Energy: 4, Partial: true},
+				{PeriodStart: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), Energy: 0.5, Partial: true},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := energyTestServer(t, logs)
+			defer ts.Close()
+
+			service := &plugService{
+				plug:     &domain.Plug{Name: "test", Address: ts.URL},
+				tariff:   test.tariff,
+				location: time.UTC,
+			}
+			got, err := service.GetEnergyTotals(test.period, from, to)
+
+			assert.NoError(t, err)
+			assert.Equal(t, domain.EnergyTotals{Totals: test.want}, got)
+		})
+	}
+}
+
+func TestGetEnergyTotals_QueriesRange(t *testing.T) {
+	var query url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("[]"))
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := &plugService{plug: &domain.Plug{Name: "test", Address: ts.URL}, location: time.UTC}
+	got, err := service.GetEnergyTotals(domain.EnergyPeriodDaily, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.EnergyTotals{Totals: []domain.EnergyTotal{}}, got)
+	assert.Equal(t, "2023-01-01T00:00:00Z", query.Get("from"))
+	assert.Equal(t, "2023-02-01T00:00:00Z", query.Get("to"))
+	assert.Equal(t, "asc", query.Get("order"))
+}
+
+func TestGetEnergyTotals_Failure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := &plugService{plug: &domain.Plug{Name: "test", Address: ts.URL}, location: time.UTC}
+	got, err := service.GetEnergyTotals(domain.EnergyPeriodDaily, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.Error(t, err)
+	assert.Equal(t, domain.EnergyTotals{}, got)
+}
+
+func TestGetEnergyTotals_PageLimit(t *testing.T) {
+	controlStationUtils.SetLogQueryLimits(1, controlStationUtils.DefaultLogQueryScanLimit)
+	t.Cleanup(func() {
+		controlStationUtils.SetLogQueryLimits(controlStationUtils.DefaultMaxLogQueryLimit,
+			controlStationUtils.DefaultLogQueryScanLimit)
+	})
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var logs []domain.PlugData
+	for i := 0; i < 2*maxEnergyPages; i++ {
+		logs = append(logs, domain.PlugData{ID: i + 1, CreatedAt: start.Add(time.Duration(i) * time.Hour),
+			Energy: floatPtr(float32(i))})
+	}
+	queries := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries++
+		afterID, _ := strconv.Atoi(r.URL.Query().Get("after_id"))
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(logs[afterID : afterID+2])
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := &plugService{plug: &domain.Plug{Name: "test", Address: ts.URL}, location: time.UTC}
+	got, err := service.GetEnergyTotals(domain.EnergyPeriodMonthly, start, start.AddDate(0, 1, 0))
+
+	assert.NoError(t, err)
+	assert.Equal(t, maxEnergyPages, queries)
+	assert.Equal(t, domain.EnergyTotals{
+		Totals:    []domain.EnergyTotal{{PeriodStart: start, Energy: maxEnergyPages - 1, Partial: true}},
+		Truncated: true,
+	}, got)
+}
+
+func TestGetEnergyTotals_TruncatedScan(t *testing.T) {
+	controlStationUtils.SetLogQueryLimits(controlStationUtils.DefaultMaxLogQueryLimit, 2)
+	t.Cleanup(func() {
+		controlStationUtils.SetLogQueryLimits(controlStationUtils.DefaultMaxLogQueryLimit,
+			controlStationUtils.DefaultLogQueryScanLimit)
+	})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/truncated/latest" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode([]domain.PlugData{
+			{ID: 3, CreatedAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC), Energy: floatPtr(3)},
+			{ID: 2, CreatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), Energy: floatPtr(2)},
+		})
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	service := &plugService{plug: &domain.Plug{Name: "truncated", Address: ts.URL}, location: time.UTC}
+	got, err := service.GetEnergyTotals(domain.EnergyPeriodDaily, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	scannedFrom := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, domain.EnergyTotals{
+		Totals:      []domain.EnergyTotal{{PeriodStart: scannedFrom, Energy: 1, Partial: true}},
+		Truncated:   true,
+		ScannedFrom: &scannedFrom,
+	}, got)
+}
+
+func TestGetTariff(t *testing.T) {
+	tariff := domain.Tariff{PricePerKWh: 0.8, Currency: "EUR"}
+	service := NewPlugService(&domain.Plug{Name: "test", Address: "http://test"}, tariff)
+
+	assert.Equal(t, tariff, service.GetTariff())
+}
@@ -0,0 +1,293 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRoomService is an autogenerated mock type for the RoomService type
+type MockRoomService struct {
+	mock.Mock
+}
+
+type MockRoomService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRoomService) EXPECT() *MockRoomService_Expecter {
+	return &MockRoomService_Expecter{mock: &_m.Mock}
+}
+
+// GetRoomStatus provides a mock function with given fields: id
+func (_m *MockRoomService) GetRoomStatus(id string) (domain.RoomStatus, error) {
+	ret := _m.Called(id)
+
+	var r0 domain.RoomStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (domain.RoomStatus, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) domain.RoomStatus); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(domain.RoomStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoomService_GetRoomStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoomStatus'
+type MockRoomService_GetRoomStatus_Call struct {
+	*mock.Call
+}
+
+// GetRoomStatus is a helper method to define mock.On call
+//   - id string
+func (_e *MockRoomService_Expecter) GetRoomStatus(id interface{}) *MockRoomService_GetRoomStatus_Call {
+	return &MockRoomService_GetRoomStatus_Call{Call: _e.mock.On("GetRoomStatus", id)}
+}
+
+func (_c *MockRoomService_GetRoomStatus_Call) Run(run func(id string)) *MockRoomService_GetRoomStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockRoomService_GetRoomStatus_Call) Return(_a0 domain.RoomStatus, _a1 error) *MockRoomService_GetRoomStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoomService_GetRoomStatus_Call) RunAndReturn(run func(string) (domain.RoomStatus, error)) *MockRoomService_GetRoomStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetZoneStatus provides a mock function with given fields: zone
+func (_m *MockRoomService) GetZoneStatus(zone string) (domain.RoomStatus, error) {
+	ret := _m.Called(zone)
+
+	var r0 domain.RoomStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (domain.RoomStatus, error)); ok {
+		return rf(zone)
+	}
+	if rf, ok := ret.Get(0).(func(string) domain.RoomStatus); ok {
+		r0 = rf(zone)
+	} else {
+		r0 = ret.Get(0).(domain.RoomStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(zone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoomService_GetZoneStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetZoneStatus'
+type MockRoomService_GetZoneStatus_Call struct {
+	*mock.Call
+}
+
+// GetZoneStatus is a helper method to define mock.On call
+//   - zone string
+func (_e *MockRoomService_Expecter) GetZoneStatus(zone interface{}) *MockRoomService_GetZoneStatus_Call {
+	return &MockRoomService_GetZoneStatus_Call{Call: _e.mock.On("GetZoneStatus", zone)}
+}
+
+func (_c *MockRoomService_GetZoneStatus_Call) Run(run func(zone string)) *MockRoomService_GetZoneStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockRoomService_GetZoneStatus_Call) Return(_a0 domain.RoomStatus, _a1 error) *MockRoomService_GetZoneStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoomService_GetZoneStatus_Call) RunAndReturn(run func(string) (domain.RoomStatus, error)) *MockRoomService_GetZoneStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRooms provides a mock function with given fields:
+func (_m *MockRoomService) ListRooms() []domain.Room {
+	ret := _m.Called()
+
+	var r0 []domain.Room
+	if rf, ok := ret.Get(0).(func() []domain.Room); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Room)
+		}
+	}
+
+	return r0
+}
+
+// MockRoomService_ListRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRooms'
+type MockRoomService_ListRooms_Call struct {
+	*mock.Call
+}
+
+// ListRooms is a helper method to define mock.On call
+func (_e *MockRoomService_Expecter) ListRooms() *MockRoomService_ListRooms_Call {
+	return &MockRoomService_ListRooms_Call{Call: _e.mock.On("ListRooms")}
+}
+
+func (_c *MockRoomService_ListRooms_Call) Run(run func()) *MockRoomService_ListRooms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRoomService_ListRooms_Call) Return(_a0 []domain.Room) *MockRoomService_ListRooms_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRoomService_ListRooms_Call) RunAndReturn(run func() []domain.Room) *MockRoomService_ListRooms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRoomLights provides a mock function with given fields: id, request
+func (_m *MockRoomService) SetRoomLights(id string, request domain.RoomLightsRequest) ([]domain.LightResult, error) {
+	ret := _m.Called(id, request)
+
+	var r0 []domain.LightResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.RoomLightsRequest) ([]domain.LightResult, error)); ok {
+		return rf(id, request)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.RoomLightsRequest) []domain.LightResult); ok {
+		r0 = rf(id, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LightResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.RoomLightsRequest) error); ok {
+		r1 = rf(id, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoomService_SetRoomLights_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRoomLights'
+type MockRoomService_SetRoomLights_Call struct {
+	*mock.Call
+}
+
+// SetRoomLights is a helper method to define mock.On call
+//   - id string
+//   - request domain.RoomLightsRequest
+func (_e *MockRoomService_Expecter) SetRoomLights(id interface{}, request interface{}) *MockRoomService_SetRoomLights_Call {
+	return &MockRoomService_SetRoomLights_Call{Call: _e.mock.On("SetRoomLights", id, request)}
+}
+
+func (_c *MockRoomService_SetRoomLights_Call) Run(run func(id string, request domain.RoomLightsRequest)) *MockRoomService_SetRoomLights_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(domain.RoomLightsRequest))
+	})
+	return _c
+}
+
+func (_c *MockRoomService_SetRoomLights_Call) Return(_a0 []domain.LightResult, _a1 error) *MockRoomService_SetRoomLights_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoomService_SetRoomLights_Call) RunAndReturn(run func(string, domain.RoomLightsRequest) ([]domain.LightResult, error)) *MockRoomService_SetRoomLights_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetZoneLights provides a mock function with given fields: zone, request
+func (_m *MockRoomService) SetZoneLights(zone string, request domain.RoomLightsRequest) ([]domain.LightResult, error) {
+	ret := _m.Called(zone, request)
+
+	var r0 []domain.LightResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.RoomLightsRequest) ([]domain.LightResult, error)); ok {
+		return rf(zone, request)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.RoomLightsRequest) []domain.LightResult); ok {
+		r0 = rf(zone, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LightResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.RoomLightsRequest) error); ok {
+		r1 = rf(zone, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoomService_SetZoneLights_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetZoneLights'
+type MockRoomService_SetZoneLights_Call struct {
+	*mock.Call
+}
+
+// SetZoneLights is a helper method to define mock.On call
+//   - zone string
+//   - request domain.RoomLightsRequest
+func (_e *MockRoomService_Expecter) SetZoneLights(zone interface{}, request interface{}) *MockRoomService_SetZoneLights_Call {
+	return &MockRoomService_SetZoneLights_Call{Call: _e.mock.On("SetZoneLights", zone, request)}
+}
+
+func (_c *MockRoomService_SetZoneLights_Call) Run(run func(zone string, request domain.RoomLightsRequest)) *MockRoomService_SetZoneLights_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(domain.RoomLightsRequest))
+	})
+	return _c
+}
+
+func (_c *MockRoomService_SetZoneLights_Call) Return(_a0 []domain.LightResult, _a1 error) *MockRoomService_SetZoneLights_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoomService_SetZoneLights_Call) RunAndReturn(run func(string, domain.RoomLightsRequest) ([]domain.LightResult, error)) *MockRoomService_SetZoneLights_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockRoomService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockRoomService creates a new instance of MockRoomService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockRoomService(t mockConstructorTestingTNewMockRoomService) *MockRoomService {
+	mock := &MockRoomService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	lightService "github.com/pklimuk-eng-thesis/control-station/pkg/service/light"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+var ErrZoneNotFound = errors.New("Zone not found")
+var ErrBrightnessNotSupported = errors.New("Light can not be dimmed")
+
+//go:generate --name RoomService --output mock_roomService.go
+type RoomService interface {
+	ListRooms() []domain.Room
+	GetRoomStatus(id string) (domain.RoomStatus, error)
+	SetRoomLights(id string, request domain.RoomLightsRequest) ([]domain.LightResult, error)
+	GetZoneStatus(zone string) (domain.RoomStatus, error)
+	SetZoneLights(zone string, request domain.RoomLightsRequest) ([]domain.LightResult, error)
+}
+
+type roomService struct {
+	registry registry.Registry
+	readers  map[string]domain.StatusReader
+	switches map[string]groupService.Switch
+	lights   map[string]lightService.LightService
+}
+
+// NewRoomService takes the status readers, switches and light services keyed by
+// device name. The lights are switched on and off through the switches, so
+// that room commands are arbitrated like group commands, and dimmed through the
+// light services. Devices without a reader are reported without a state.
+func NewRoomService(registry registry.Registry, readers map[string]domain.StatusReader,
+	switches map[string]groupService.Switch, lights map[string]lightService.LightService) RoomService {
+	return &roomService{registry: registry, readers: readers, switches: switches, lights: lights}
+}
+
+func (s *roomService) ListRooms() []domain.Room {
+	return s.registry.ListRooms()
+}
+
+func (s *roomService) GetRoomStatus(id string) (domain.RoomStatus, error) {
+	room, err := s.registry.GetRoom(id)
+	if err != nil {
+		return domain.RoomStatus{}, err
+	}
+	return s.status(id, []domain.Room{room}), nil
+}
+
+func (s *roomService) SetRoomLights(id string, request domain.RoomLightsRequest) ([]domain.LightResult, error) {
+	room, err := s.registry.GetRoom(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.setLights([]domain.Room{room}, request)
+}
+
+func (s *roomService) GetZoneStatus(zone string) (domain.RoomStatus, error) {
+	rooms, err := s.zoneRooms(zone)
+	if err != nil {
+		return domain.RoomStatus{}, err
+	}
+	return s.status(zone, rooms), nil
+}
+
+func (s *roomService) SetZoneLights(zone string, request domain.RoomLightsRequest) ([]domain.LightResult, error) {
+	rooms, err := s.zoneRooms(zone)
+	if err != nil {
+		return nil, err
+	}
+	return s.setLights(rooms, request)
+}
+
+func (s *roomService) zoneRooms(zone string) ([]domain.Room, error) {
+	var rooms []domain.Room
+	for _, room := range s.registry.ListRooms() {
+		if room.Zone == zone {
+			rooms = append(rooms, room)
+		}
+	}
+	if len(rooms) == 0 {
+		return nil, ErrZoneNotFound
+	}
+	return rooms, nil
+}
+
+// status reads the devices of the rooms concurrently. A device that can not be
+// read is reported with its error instead of failing the whole status.
+func (s *roomService) status(id string, rooms []domain.Room) domain.RoomStatus {
+	devices := s.registry.ListByRooms(roomIDs(rooms)...)
+	statuses := make([]domain.DeviceStatus, len(devices))
+
+	var wg sync.WaitGroup
+	for i, device := range devices {
+		statuses[i] = domain.DeviceStatus{Name: device.Name, Kind: device.Kind, Room: device.Room}
+		reader, ok := s.readers[device.Name]
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func(status *domain.DeviceStatus, reader domain.StatusReader) {
+			defer wg.Done()
+			state, err := reader(context.Background())
+			if err != nil {
+				status.Error = err.Error()
+				return
+			}
+			status.State = state
+		}(&statuses[i], reader)
+	}
+	wg.Wait()
+
+	return domain.RoomStatus{ID: id, Rooms: rooms, Devices: statuses}
+}
+
+func (s *roomService) setLights(rooms []domain.Room, request domain.RoomLightsRequest) ([]domain.LightResult, error) {
+	if request.Enabled == nil && request.Brightness == nil {
+		validationErr := &controlStationUtils.ValidationError{}
+		validationErr.Add("enabled", "either enabled or brightness must be set")
+		return nil, validationErr
+	}
+
+	results := []domain.LightResult{}
+	for _, device := range s.registry.ListByRooms(roomIDs(rooms)...) {
+		sw, ok := s.switches[device.Name]
+		if device.Kind != domain.KindLight || !ok {
+			continue
+		}
+
+		result := domain.LightResult{Name: device.Name}
+		lightInfo, err := s.setLight(device.Name, sw, request)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.State = &lightInfo
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// setLight switches the light only when it is not in the requested power state
+// yet, as the switch offers a toggle rather than a setter.
+func (s *roomService) setLight(name string, sw groupService.Switch,
+	request domain.RoomLightsRequest) (domain.LightInfo, error) {
+	ctx := context.Background()
+	info, err := sw.GetInfo(ctx)
+	if err != nil {
+		return domain.LightInfo{}, err
+	}
+
+	if request.Enabled != nil && info.IsEnabled() != *request.Enabled {
+		info, err = sw.ToggleEnabled(ctx)
+		if err != nil {
+			return domain.LightInfo{}, err
+		}
+	}
+
+	if request.Brightness != nil {
+		light, ok := s.lights[name]
+		if !ok {
+			return domain.LightInfo{}, ErrBrightnessNotSupported
+		}
+		return light.SetBrightness(domain.LightBrightnessRequest{Brightness: *request.Brightness})
+	}
+	return lightInfoOf(info), nil
+}
+
+// lightInfoOf returns the state a switch reported for a light, which is only
+// the power state for lights not served by the light service.
+func lightInfoOf(info domain.Switchable) domain.LightInfo {
+	if lightInfo, ok := info.(domain.LightInfo); ok {
+		return lightInfo
+	}
+	return domain.LightInfo{Enabled: info.IsEnabled()}
+}
+
+func roomIDs(rooms []domain.Room) []string {
+	ids := make([]string, 0, len(rooms))
+	for _, room := range rooms {
+		ids = append(ids, room.ID)
+	}
+	return ids
+}
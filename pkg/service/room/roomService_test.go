@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	lightService "github.com/pklimuk-eng-thesis/control-station/pkg/service/light"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// lightSwitches returns the switches of the lights the way the control station
+// builds them.
+func lightSwitches(lights map[string]lightService.LightService) map[string]groupService.Switch {
+	switches := map[string]groupService.Switch{}
+	for name, light := range lights {
+		light := light
+		switches[name] = groupService.Switch{
+			GetInfo: func(ctx context.Context) (domain.Switchable, error) {
+				return light.GetInfo()
+			},
+			ToggleEnabled: func(ctx context.Context) (domain.Switchable, error) {
+				return light.ToggleEnabled(context.Background())
+			},
+		}
+	}
+	return switches
+}
+
+func newTestRegistry() registry.Registry {
+	r := registry.NewRegistry()
+	r.RegisterRoom(domain.Room{ID: "livingRoom", Name: "Living room", Zone: "downstairs"})
+	r.RegisterRoom(domain.Room{ID: "kitchen", Name: "Kitchen", Zone: "downstairs"})
+	r.RegisterRoom(domain.Room{ID: "bedroom", Name: "Bedroom", Zone: "upstairs"})
+	r.Register(domain.RegisteredDevice{Name: "smartBulb", Kind: domain.KindLight, Room: "livingRoom"})
+	r.Register(domain.RegisteredDevice{Name: "presenceSensor", Kind: domain.KindSensor, Room: "livingRoom"})
+	r.Register(domain.RegisteredDevice{Name: "gasSensor", Kind: domain.KindSensor, Room: "kitchen"})
+	r.Register(domain.RegisteredDevice{Name: "kitchenBulb", Kind: domain.KindLight, Room: "kitchen"})
+	r.Register(domain.RegisteredDevice{Name: "ac", Kind: domain.KindAC, Room: "bedroom"})
+	return r
+}
+
+func TestListRooms(t *testing.T) {
+	service := NewRoomService(newTestRegistry(), nil, nil, nil)
+
+	got := service.ListRooms()
+
+	assert.Equal(t, []domain.Room{
+		{ID: "bedroom", Name: "Bedroom", Zone: "upstairs"},
+		{ID: "kitchen", Name: "Kitchen", Zone: "downstairs"},
+		{ID: "livingRoom", Name: "Living room", Zone: "downstairs"},
+	}, got)
+}
+
+func TestGetRoomStatus(t *testing.T) {
+	readers := map[string]domain.StatusReader{
+		"smartBulb":      func(ctx context.Context) (any, error) { return domain.LightInfo{Enabled: true}, nil },
+		"presenceSensor": func(ctx context.Context) (any, error) { return nil, errors.New("unreachable") },
+		"ac":             func(ctx context.Context) (any, error) { return domain.ACInfo{Enabled: true}, nil },
+	}
+	service := NewRoomService(newTestRegistry(), readers, nil, nil)
+
+	got, err := service.GetRoomStatus("livingRoom")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RoomStatus{
+		ID:    "livingRoom",
+		Rooms: []domain.Room{{ID: "livingRoom", Name: "Living room", Zone: "downstairs"}},
+		Devices: []domain.DeviceStatus{
+			{Name: "presenceSensor", Kind: domain.KindSensor, Room: "livingRoom", Error: "unreachable"},
+			{Name: "smartBulb", Kind: domain.KindLight, Room: "livingRoom", State: domain.LightInfo{Enabled: true}},
+		},
+	}, got)
+}
+
+func TestGetRoomStatus_NotFound(t *testing.T) {
+	service := NewRoomService(newTestRegistry(), nil, nil, nil)
+
+	_, err := service.GetRoomStatus("garage")
+
+	assert.ErrorIs(t, err, registry.ErrRoomNotRegistered)
+}
+
+func TestGetZoneStatus(t *testing.T) {
+	service := NewRoomService(newTestRegistry(), map[string]domain.StatusReader{}, nil, nil)
+
+	got, err := service.GetZoneStatus("downstairs")
+
+	assert.NoError(t, err)
+	assert.Len(t, got.Rooms, 2)
+	var devices []string
+	for _, device := range got.Devices {
+		devices = append(devices, device.Name)
+	}
+	assert.Equal(t, []string{"gasSensor", "kitchenBulb", "presenceSensor", "smartBulb"}, devices)
+
+	_, err = service.GetZoneStatus("attic")
+	assert.ErrorIs(t, err, ErrZoneNotFound)
+}
+
+func TestSetRoomLights(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domain.RoomLightsRequest
+		setup   func(light *lightService.MockLightService)
+		want    []domain.LightResult
+	}{
+		{
+			name:    "SwitchOn",
+			request: domain.RoomLightsRequest{Enabled: boolPtr(true)},
+			setup: func(light *lightService.MockLightService) {
+				light.EXPECT().GetInfo().Return(domain.LightInfo{Enabled: false}, nil)
+				light.EXPECT().ToggleEnabled(mock.Anything).Return(domain.LightInfo{Enabled: true}, nil)
+			},
+			want: []domain.LightResult{{Name: "smartBulb", State: &domain.LightInfo{Enabled: true}}},
+		},
+		{
+			name:    "AlreadyOn",
+			request: domain.RoomLightsRequest{Enabled: boolPtr(true)},
+			setup: func(light *lightService.MockLightService) {
+				light.EXPECT().GetInfo().Return(domain.LightInfo{Enabled: true}, nil)
+			},
+			want: []domain.LightResult{{Name: "smartBulb", State: &domain.LightInfo{Enabled: true}}},
+		},
+		{
+			name:    "Brightness",
+			request: domain.RoomLightsRequest{Enabled: boolPtr(true), Brightness: intPtr(40)},
+			setup: func(light *lightService.MockLightService) {
+				light.EXPECT().GetInfo().Return(domain.LightInfo{Enabled: true}, nil)
+				light.EXPECT().SetBrightness(domain.LightBrightnessRequest{Brightness: 40}).
+					Return(domain.LightInfo{Enabled: true, Brightness: intPtr(40)}, nil)
+			},
+			want: []domain.LightResult{{Name: "smartBulb", State: &domain.LightInfo{Enabled: true, Brightness: intPtr(40)}}},
+		},
+		{
+			name:    "Failure",
+			request: domain.RoomLightsRequest{Enabled: boolPtr(false)},
+			setup: func(light *lightService.MockLightService) {
+				light.EXPECT().GetInfo().Return(domain.LightInfo{}, errors.New("unreachable"))
+			},
+			want: []domain.LightResult{{Name: "smartBulb", Error: "unreachable"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			light := new(lightService.MockLightService)
+			test.setup(light)
+			lights := map[string]lightService.LightService{"smartBulb": light}
+			service := NewRoomService(newTestRegistry(), nil, lightSwitches(lights), lights)
+
+			got, err := service.SetRoomLights("livingRoom", test.request)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+			light.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetRoomLights_EmptyRequest(t *testing.T) {
+	service := NewRoomService(newTestRegistry(), nil, nil, nil)
+
+	_, err := service.SetRoomLights("livingRoom", domain.RoomLightsRequest{})
+
+	var validationErr *controlStationUtils.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestSetZoneLights(t *testing.T) {
+	smartBulb := new(lightService.MockLightService)
+	smartBulb.EXPECT().GetInfo().Return(domain.LightInfo{Enabled: true}, nil)
+	smartBulb.EXPECT().ToggleEnabled(mock.Anything).Return(domain.LightInfo{Enabled: false}, nil)
+	kitchenBulb := new(lightService.MockLightService)
+	kitchenBulb.EXPECT().GetInfo().Return(domain.LightInfo{Enabled: false}, nil)
+	lights := map[string]lightService.LightService{"smartBulb": smartBulb, "kitchenBulb": kitchenBulb}
+	service := NewRoomService(newTestRegistry(), nil, lightSwitches(lights), lights)
+
+	got, err := service.SetZoneLights("downstairs", domain.RoomLightsRequest{Enabled: boolPtr(false)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.LightResult{
+		{Name: "kitchenBulb", State: &domain.LightInfo{Enabled: false}},
+		{Name: "smartBulb", State: &domain.LightInfo{Enabled: false}},
+	}, got)
+}
+
+func TestSetRoomLights_RefusedSwitch(t *testing.T) {
+	light := new(lightService.MockLightService)
+	switches := map[string]groupService.Switch{
+		"smartBulb": {
+			GetInfo: func(ctx context.Context) (domain.Switchable, error) {
+				return domain.LightInfo{Enabled: false}, nil
+			},
+			ToggleEnabled: func(ctx context.Context) (domain.Switchable, error) {
+				return nil, errors.New("Device is under user control")
+			},
+		},
+	}
+	service := NewRoomService(newTestRegistry(), nil, switches, map[string]lightService.LightService{"smartBulb": light})
+
+	got, err := service.SetRoomLights("livingRoom", domain.RoomLightsRequest{Enabled: boolPtr(true), Brightness: intPtr(40)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.LightResult{{Name: "smartBulb", Error: "Device is under user control"}}, got)
+	light.AssertNotCalled(t, "SetBrightness", mock.Anything)
+}
+
+func TestSetRoomLights_BrightnessNotSupported(t *testing.T) {
+	light := new(lightService.MockLightService)
+	light.EXPECT().GetInfo().Return(domain.LightInfo{Enabled: true}, nil)
+	switches := lightSwitches(map[string]lightService.LightService{"smartBulb": light})
+	service := NewRoomService(newTestRegistry(), nil, switches, nil)
+
+	got, err := service.SetRoomLights("livingRoom", domain.RoomLightsRequest{Brightness: intPtr(40)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.LightResult{{Name: "smartBulb", Error: ErrBrightnessNotSupported.Error()}}, got)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	acService "github.com/pklimuk-eng-thesis/control-station/pkg/service/ac"
+	plugService "github.com/pklimuk-eng-thesis/control-station/pkg/service/plug"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+// Interlock keeps the protected devices off while a safety alarm lasts. It is
+// created before the devices, so that their services can be guarded, and is
+// engaged and released by the safety service.
+type Interlock struct {
+	mu      sync.RWMutex
+	devices map[string]bool
+	guarded map[string]bool
+}
+
+func NewInterlock() *Interlock {
+	return &Interlock{devices: map[string]bool{}, guarded: map[string]bool{}}
+}
+
+// Guards reports whether the service of the device is guarded by the
+// interlock. Only guarded devices are kept off while a safety alarm lasts.
+func (i *Interlock) Guards(device string) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.guarded[device]
+}
+
+func (i *Interlock) guard(device string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.guarded[device] = true
+}
+
+func (i *Interlock) Engage(devices []string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, device := range devices {
+		i.devices[device] = true
+	}
+}
+
+func (i *Interlock) Release() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.devices = map[string]bool{}
+}
+
+func (i *Interlock) Engaged(device string) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.devices[device]
+}
+
+// checkToggle rejects toggling an interlocked device that is off. Switching off
+// a device that is still on, e.g. because cutting it failed, is allowed.
+func checkToggle[V domain.Switchable](interlock *Interlock, device string, getInfo func() (V, error)) error {
+	if !interlock.Engaged(device) {
+		return nil
+	}
+
+	info, err := getInfo()
+	if err != nil {
+		return err
+	}
+	if !info.IsEnabled() {
+		return fmt.Errorf("%w: %s", controlStationUtils.ErrInterlocked, device)
+	}
+	return nil
+}
+
+type guardedPlugService struct {
+	plugService.PlugService
+	name      string
+	interlock *Interlock
+}
+
+// GuardPlug returns the plug service that refuses to switch the plug on while
+// the interlock is engaged for it.
+func GuardPlug(service plugService.PlugService, name string, interlock *Interlock) plugService.PlugService {
+	interlock.guard(name)
+	return &guardedPlugService{PlugService: service, name: name, interlock: interlock}
+}
+
+func (s *guardedPlugService) ToggleEnabled(ctx context.Context) (domain.PlugInfo, error) {
+	if err := checkToggle(s.interlock, s.name, s.PlugService.GetInfo); err != nil {
+		return domain.PlugInfo{}, err
+	}
+	return s.PlugService.ToggleEnabled(ctx)
+}
+
+type guardedACService struct {
+	acService.ACService
+	name      string
+	interlock *Interlock
+}
+
+// GuardAC returns the AC service that refuses to switch the AC on while the
+// interlock is engaged for it.
+func GuardAC(service acService.ACService, name string, interlock *Interlock) acService.ACService {
+	interlock.guard(name)
+	return &guardedACService{ACService: service, name: name, interlock: interlock}
+}
+
+func (s *guardedACService) ToggleEnabled(ctx context.Context) (domain.ACInfo, error) {
+	if err := checkToggle(s.interlock, s.name, s.ACService.GetInfo); err != nil {
+		return domain.ACInfo{}, err
+	}
+	return s.ACService.ToggleEnabled(ctx)
+}
+
+// UpdateACSettings is refused altogether while the interlock is engaged, since
+// depending on the power-on policy any update may switch the AC on.
+func (s *guardedACService) UpdateACSettings(desiredSettings domain.ACInfo) (domain.ACInfo, error) {
+	if s.interlock.Engaged(s.name) {
+		return domain.ACInfo{}, fmt.Errorf("%w: %s", controlStationUtils.ErrInterlocked, s.name)
+	}
+	return s.ACService.UpdateACSettings(desiredSettings)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	acService "github.com/pklimuk-eng-thesis/control-station/pkg/service/ac"
+	plugService "github.com/pklimuk-eng-thesis/control-station/pkg/service/plug"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInterlock(t *testing.T) {
+	interlock := NewInterlock()
+	assert.False(t, interlock.Engaged("smartPlug"))
+
+	interlock.Engage([]string{"smartPlug", "ac"})
+	assert.True(t, interlock.Engaged("smartPlug"))
+	assert.True(t, interlock.Engaged("ac"))
+	assert.False(t, interlock.Engaged("smartBulb"))
+
+	interlock.Release()
+	assert.False(t, interlock.Engaged("smartPlug"))
+}
+
+func TestInterlock_Guards(t *testing.T) {
+	interlock := NewInterlock()
+	assert.False(t, interlock.Guards("smartPlug"))
+
+	GuardPlug(new(plugService.MockPlugService), "smartPlug", interlock)
+	GuardAC(new(acService.MockACService), "ac", interlock)
+
+	assert.True(t, interlock.Guards("smartPlug"))
+	assert.True(t, interlock.Guards("ac"))
+	assert.False(t, interlock.Guards("smartBulb"))
+}
+
+func TestGuardPlug_ToggleEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		engaged bool
+		enabled bool
+		wantErr error
+	}{
+		{name: "Released", engaged: false, enabled: false},
+		{name: "SwitchOff", engaged: true, enabled: true},
+		{name: "SwitchOn", engaged: true, enabled: false, wantErr: controlStationUtils.ErrInterlocked},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plug := new(plugService.MockPlugService)
+			plug.EXPECT().GetInfo().Return(domain.PlugInfo{Enabled: test.enabled}, nil).Maybe()
+			plug.EXPECT().ToggleEnabled(mock.Anything).Return(domain.PlugInfo{Enabled: !test.enabled}, nil).Maybe()
+			interlock := NewInterlock()
+			if test.engaged {
+				interlock.Engage([]string{"smartPlug"})
+			}
+
+			_, err := GuardPlug(plug, "smartPlug", interlock).ToggleEnabled(context.Background())
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				plug.AssertNotCalled(t, "ToggleEnabled", mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				plug.AssertCalled(t, "ToggleEnabled", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestGuardAC_UpdateACSettings(t *testing.T) {
+	ac := new(acService.MockACService)
+	ac.EXPECT().UpdateACSettings(domain.ACInfo{Temperature: 21}).Return(domain.ACInfo{Temperature: 21}, nil).Once()
+	interlock := NewInterlock()
+	guarded := GuardAC(ac, "ac", interlock)
+
+	_, err := guarded.UpdateACSettings(domain.ACInfo{Temperature: 21})
+	assert.NoError(t, err)
+
+	interlock.Engage([]string{"ac"})
+	_, err = guarded.UpdateACSettings(domain.ACInfo{Temperature: 21})
+	assert.ErrorIs(t, err, controlStationUtils.ErrInterlocked)
+	ac.AssertNumberOfCalls(t, "UpdateACSettings", 1)
+}
@@ -0,0 +1,183 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSafetyService is an autogenerated mock type for the SafetyService type
+type MockSafetyService struct {
+	mock.Mock
+}
+
+type MockSafetyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSafetyService) EXPECT() *MockSafetyService_Expecter {
+	return &MockSafetyService_Expecter{mock: &_m.Mock}
+}
+
+// Acknowledge provides a mock function with given fields: source
+func (_m *MockSafetyService) Acknowledge(source string) (domain.SafetyState, error) {
+	ret := _m.Called(source)
+
+	var r0 domain.SafetyState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (domain.SafetyState, error)); ok {
+		return rf(source)
+	}
+	if rf, ok := ret.Get(0).(func(string) domain.SafetyState); ok {
+		r0 = rf(source)
+	} else {
+		r0 = ret.Get(0).(domain.SafetyState)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSafetyService_Acknowledge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Acknowledge'
+type MockSafetyService_Acknowledge_Call struct {
+	*mock.Call
+}
+
+// Acknowledge is a helper method to define mock.On call
+//   - source string
+func (_e *MockSafetyService_Expecter) Acknowledge(source interface{}) *MockSafetyService_Acknowledge_Call {
+	return &MockSafetyService_Acknowledge_Call{Call: _e.mock.On("Acknowledge", source)}
+}
+
+func (_c *MockSafetyService_Acknowledge_Call) Run(run func(source string)) *MockSafetyService_Acknowledge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockSafetyService_Acknowledge_Call) Return(_a0 domain.SafetyState, _a1 error) *MockSafetyService_Acknowledge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSafetyService_Acknowledge_Call) RunAndReturn(run func(string) (domain.SafetyState, error)) *MockSafetyService_Acknowledge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSafetyLogsFromDataServiceLimitN provides a mock function with given fields: limit
+func (_m *MockSafetyService) GetSafetyLogsFromDataServiceLimitN(limit int) ([]domain.SafetyEvent, error) {
+	ret := _m.Called(limit)
+
+	var r0 []domain.SafetyEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]domain.SafetyEvent, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []domain.SafetyEvent); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SafetyEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSafetyService_GetSafetyLogsFromDataServiceLimitN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSafetyLogsFromDataServiceLimitN'
+type MockSafetyService_GetSafetyLogsFromDataServiceLimitN_Call struct {
+	*mock.Call
+}
+
+// GetSafetyLogsFromDataServiceLimitN is a helper method to define mock.On call
+//   - limit int
+func (_e *MockSafetyService_Expecter) GetSafetyLogsFromDataServiceLimitN(limit interface{}) *MockSafetyService_GetSafetyLogsFromDataServiceLimitN_Call {
+	return &MockSafetyService_GetSafetyLogsFromDataServiceLimitN_Call{Call: _e.mock.On("GetSafetyLogsFromDataServiceLimitN", limit)}
+}
+
+func (_c *MockSafetyService_GetSafetyLogsFromDataServiceLimitN_Call) Run(run func(limit int)) *MockSafetyService_GetSafetyLogsFromDataServiceLimitN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockSafetyService_GetSafetyLogsFromDataServiceLimitN_Call) Return(_a0 []domain.SafetyEvent, _a1 error) *MockSafetyService_GetSafetyLogsFromDataServiceLimitN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSafetyService_GetSafetyLogsFromDataServiceLimitN_Call) RunAndReturn(run func(int) ([]domain.SafetyEvent, error)) *MockSafetyService_GetSafetyLogsFromDataServiceLimitN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetState provides a mock function with given fields:
+func (_m *MockSafetyService) GetState() domain.SafetyState {
+	ret := _m.Called()
+
+	var r0 domain.SafetyState
+	if rf, ok := ret.Get(0).(func() domain.SafetyState); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.SafetyState)
+	}
+
+	return r0
+}
+
+// MockSafetyService_GetState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetState'
+type MockSafetyService_GetState_Call struct {
+	*mock.Call
+}
+
+// GetState is a helper method to define mock.On call
+func (_e *MockSafetyService_Expecter) GetState() *MockSafetyService_GetState_Call {
+	return &MockSafetyService_GetState_Call{Call: _e.mock.On("GetState")}
+}
+
+func (_c *MockSafetyService_GetState_Call) Run(run func()) *MockSafetyService_GetState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSafetyService_GetState_Call) Return(_a0 domain.SafetyState) *MockSafetyService_GetState_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSafetyService_GetState_Call) RunAndReturn(run func() domain.SafetyState) *MockSafetyService_GetState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockSafetyService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockSafetyService creates a new instance of MockSafetyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockSafetyService(t mockConstructorTestingTNewMockSafetyService) *MockSafetyService {
+	mock := &MockSafetyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/event"
+	alertService "github.com/pklimuk-eng-thesis/control-station/pkg/service/alert"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+var ErrNoActiveAlarm = errors.New("There is no active safety alarm")
+var ErrGasStillDetected = errors.New("Gas is still detected")
+
+const safetyLogName = "safety"
+
+//go:generate --name SafetyService --output mock_safetyService.go
+type SafetyService interface {
+	GetState() domain.SafetyState
+	Acknowledge(source string) (domain.SafetyState, error)
+	GetSafetyLogsFromDataServiceLimitN(limit int) ([]domain.SafetyEvent, error)
+}
+
+type safetyService struct {
+	config       domain.SafetyConfig
+	interlock    *Interlock
+	switches     map[string]groupService.Switch
+	alertService alertService.AlertService
+	now          func() time.Time
+
+	mu          sync.Mutex
+	state       domain.SafetyState
+	gasDetected map[string]bool
+}
+
+// NewSafetyService restores an unacknowledged alarm from the data service and
+// starts watching the detection events of the gas sensors.
+func NewSafetyService(config domain.SafetyConfig, interlock *Interlock, switches map[string]groupService.Switch,
+	eventBus event.Bus, alertService alertService.AlertService) SafetyService {
+	s := &safetyService{config: config, interlock: interlock, switches: switches, alertService: alertService,
+		now: time.Now, gasDetected: map[string]bool{}}
+	s.state = domain.SafetyState{Status: domain.SafetyNormal}
+	s.restore()
+	eventBus.Subscribe(s.handleEvent)
+	return s
+}
+
+func (s *safetyService) GetState() domain.SafetyState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state
+}
+
+// Acknowledge ends the alarm and releases the protected devices. It is refused
+// while any gas sensor still reports detection.
+func (s *safetyService) Acknowledge(source string) (domain.SafetyState, error) {
+	s.mu.Lock()
+	if s.state.Status != domain.SafetyAlarm {
+		state := s.state
+		s.mu.Unlock()
+		return state, ErrNoActiveAlarm
+	}
+	if s.state.GasDetected {
+		state := s.state
+		s.mu.Unlock()
+		return state, ErrGasStillDetected
+	}
+
+	now := s.now().UTC()
+	s.state.Status = domain.SafetyNormal
+	s.state.AcknowledgedBy = source
+	s.state.AcknowledgedAt = &now
+	s.interlock.Release()
+	state := s.state
+	s.mu.Unlock()
+
+	s.record(domain.SafetyEvent{Type: domain.SafetyEventAcknowledged, Source: source, Time: now})
+	return state, nil
+}
+
+func (s *safetyService) GetSafetyLogsFromDataServiceLimitN(limit int) ([]domain.SafetyEvent, error) {
+	return controlStationUtils.GetLogsFromDataServiceLimitN[domain.SafetyEvent](safetyLogName, limit)
+}
+
+func (s *safetyService) handleEvent(e domain.DeviceEvent) {
+	if !contains(s.config.GasSensors, e.Device) {
+		return
+	}
+
+	s.mu.Lock()
+	s.gasDetected[e.Device] = e.Type == domain.EventDetected
+	s.state.GasDetected = false
+	for _, detected := range s.gasDetected {
+		s.state.GasDetected = s.state.GasDetected || detected
+	}
+	if e.Type != domain.EventDetected || s.state.Status == domain.SafetyAlarm {
+		s.mu.Unlock()
+		return
+	}
+
+	// The interlock is engaged before anything else, so that nothing can switch
+	// the protected devices back on while they are being cut.
+	now := s.now().UTC()
+	s.interlock.Engage(s.config.ProtectedDevices)
+	s.state = domain.SafetyState{Status: domain.SafetyAlarm, GasDetected: true, TriggeredBy: e.Device,
+		TriggeredAt: &now}
+	s.mu.Unlock()
+
+	// The event bus delivers events synchronously, so neither switching off the
+	// protected devices nor recording the alarm may block the caller.
+	go s.protect(e.Device, now)
+	go s.record(domain.SafetyEvent{Type: domain.SafetyEventTriggered, Source: e.Device, Time: now})
+}
+
+// protect switches off the protected devices in parallel and raises the
+// critical alert once the outcome of every action is known.
+func (s *safetyService) protect(sensor string, triggeredAt time.Time) {
+	results := make([]domain.ProtectiveActionResult, len(s.config.ProtectedDevices))
+	var wg sync.WaitGroup
+	for i, device := range s.config.ProtectedDevices {
+		wg.Add(1)
+		go func(i int, device string) {
+			defer wg.Done()
+			results[i] = domain.ProtectiveActionResult{Device: device}
+			if err := s.switchOff(device); err != nil {
+				log.Printf("Failed to switch off '%s' after gas detection: %s\n", device, err)
+				results[i].Error = err.Error()
+			}
+		}(i, device)
+	}
+	wg.Wait()
+
+	s.mu.Lock()
+	if s.state.TriggeredAt != nil && s.state.TriggeredAt.Equal(triggeredAt) {
+		s.state.Actions = results
+	}
+	s.mu.Unlock()
+
+	message := fmt.Sprintf("Gas detected by '%s', protected devices switched off", sensor)
+	var failed []string
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, result.Device)
+		}
+	}
+	if len(failed) > 0 {
+		message = fmt.Sprintf("Gas detected by '%s', failed to switch off %v", sensor, failed)
+	}
+	alert := domain.Alert{Severity: domain.AlertCritical, Source: sensor, Message: message, Time: triggeredAt}
+	if err := s.alertService.Raise(alert); err != nil {
+		log.Printf("Failed to deliver the gas alert: %s\n", err)
+	}
+}
+
+func (s *safetyService) switchOff(device string) error {
+	deviceSwitch, ok := s.switches[device]
+	if !ok {
+		return groupService.ErrNotSwitchable
+	}
+
+	info, err := deviceSwitch.GetInfo(context.Background())
+	if err != nil {
+		return err
+	}
+	if !info.IsEnabled() {
+		return nil
+	}
+	_, err = deviceSwitch.ToggleEnabled(context.Background())
+	return err
+}
+
+func (s *safetyService) record(safetyEvent domain.SafetyEvent) {
+	log.Printf("AUDIT safety event=%s source=%s\n", safetyEvent.Type, safetyEvent.Source)
+
+	err := controlStationUtils.SendLogsToDataService(safetyLogName, safetyEvent)
+	if err != nil {
+		log.Printf("Failed to send '%s' logs to data service: %s\n", safetyLogName, err)
+	}
+}
+
+// restore keeps the protected devices interlocked and switches them off again
+// if the control station was restarted during an unacknowledged alarm. The gas
+// counts as detected until the sensor reports it cleared, so the alarm can not
+// be acknowledged before.
+func (s *safetyService) restore() {
+	safetyEvents, err := s.GetSafetyLogsFromDataServiceLimitN(1)
+	if err != nil {
+		log.Printf("Failed to restore the safety state: %s\n", err)
+		return
+	}
+	if len(safetyEvents) == 0 || safetyEvents[0].Type != domain.SafetyEventTriggered {
+		return
+	}
+
+	sensor := safetyEvents[0].Source
+	triggeredAt := safetyEvents[0].Time
+	s.interlock.Engage(s.config.ProtectedDevices)
+	s.mu.Lock()
+	s.gasDetected[sensor] = true
+	s.state = domain.SafetyState{Status: domain.SafetyAlarm, GasDetected: true, TriggeredBy: sensor,
+		TriggeredAt: &triggeredAt}
+	s.mu.Unlock()
+	go s.protect(sensor, triggeredAt)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/event"
+	alertService "github.com/pklimuk-eng-thesis/control-station/pkg/service/alert"
+	groupService "github.com/pklimuk-eng-thesis/control-station/pkg/service/group"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testConfig = domain.SafetyConfig{GasSensors: []string{"gasSensor"}, ProtectedDevices: []string{"smartPlug", "ac"}}
+
+// fakeSwitch is a switchable device that records how often it was toggled.
+type fakeSwitch struct {
+	mu      sync.Mutex
+	enabled bool
+	toggles int
+	err     error
+}
+
+func (f *fakeSwitch) toSwitch() groupService.Switch {
+	return groupService.Switch{
+		GetInfo: func(ctx context.Context) (domain.Switchable, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return domain.PlugInfo{Enabled: f.enabled}, f.err
+		},
+		ToggleEnabled: func(ctx context.Context) (domain.Switchable, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.toggles++
+			f.enabled = !f.enabled
+			return domain.PlugInfo{Enabled: f.enabled}, nil
+		},
+	}
+}
+
+func (f *fakeSwitch) state() (bool, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.enabled, f.toggles
+}
+
+func newDataService(t *testing.T, persisted []domain.SafetyEvent) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Path == "/safety/latest" {
+			json.NewEncoder(w).Encode(persisted)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+}
+
+func gasEvent(eventType domain.EventType) domain.DeviceEvent {
+	return domain.DeviceEvent{Device: "gasSensor", Type: eventType, Time: time.Now()}
+}
+
+func TestGasDetection_CutsProtectedDevices(t *testing.T) {
+	newDataService(t, nil)
+	plug := &fakeSwitch{enabled: true}
+	ac := &fakeSwitch{enabled: false}
+	alerts := make(chan domain.Alert, 1)
+	alert := new(alertService.MockAlertService)
+	alert.EXPECT().Raise(mock.Anything).Run(func(a domain.Alert) { alerts <- a }).Return(nil)
+	interlock := NewInterlock()
+	eventBus := event.NewBus()
+	service := NewSafetyService(testConfig, interlock,
+		map[string]groupService.Switch{"smartPlug": plug.toSwitch(), "ac": ac.toSwitch()}, eventBus, alert)
+
+	eventBus.Publish(gasEvent(domain.EventDetected))
+
+	assert.True(t, interlock.Engaged("smartPlug"))
+	assert.True(t, interlock.Engaged("ac"))
+	assert.Equal(t, domain.SafetyAlarm, service.GetState().Status)
+	select {
+	case got := <-alerts:
+		assert.Equal(t, domain.AlertCritical, got.Severity)
+		assert.Equal(t, "gasSensor", got.Source)
+	case <-time.After(time.Second):
+		t.Fatal("alert was not raised")
+	}
+
+	enabled, toggles := plug.state()
+	assert.False(t, enabled)
+	assert.Equal(t, 1, toggles)
+	_, toggles = ac.state()
+	assert.Equal(t, 0, toggles)
+	assert.Equal(t, []domain.ProtectiveActionResult{{Device: "smartPlug"}, {Device: "ac"}},
+		service.GetState().Actions)
+}
+
+func TestGasDetection_DoesNotWaitForDataService(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/safety/add" {
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(ts.Close)
+	t.Cleanup(func() { close(release) })
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+	alert := new(alertService.MockAlertService)
+	alert.EXPECT().Raise(mock.Anything).Return(nil).Maybe()
+	plug := &fakeSwitch{enabled: true}
+	eventBus := event.NewBus()
+	NewSafetyService(testConfig, NewInterlock(), map[string]groupService.Switch{"smartPlug": plug.toSwitch()},
+		eventBus, alert)
+
+	published := make(chan struct{})
+	go func() {
+		eventBus.Publish(gasEvent(domain.EventDetected))
+		close(published)
+	}()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("publishing the detection waited for the data service")
+	}
+	assert.Eventually(t, func() bool {
+		enabled, _ := plug.state()
+		return !enabled
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestGasDetection_ReportsFailedActions(t *testing.T) {
+	newDataService(t, nil)
+	alerts := make(chan domain.Alert, 1)
+	alert := new(alertService.MockAlertService)
+	alert.EXPECT().Raise(mock.Anything).Run(func(a domain.Alert) { alerts <- a }).Return(nil)
+	eventBus := event.NewBus()
+	config := domain.SafetyConfig{GasSensors: []string{"gasSensor"}, ProtectedDevices: []string{"smartPlug", "heater"}}
+	service := NewSafetyService(config, NewInterlock(),
+		map[string]groupService.Switch{"smartPlug": (&fakeSwitch{err: errors.New("unreachable")}).toSwitch()},
+		eventBus, alert)
+
+	eventBus.Publish(gasEvent(domain.EventDetected))
+
+	select {
+	case got := <-alerts:
+		assert.Contains(t, got.Message, "failed to switch off [smartPlug heater]")
+	case <-time.After(time.Second):
+		t.Fatal("alert was not raised")
+	}
+	actions := service.GetState().Actions
+	assert.Equal(t, "unreachable", actions[0].Error)
+	assert.Equal(t, groupService.ErrNotSwitchable.Error(), actions[1].Error)
+}
+
+func TestAcknowledge(t *testing.T) {
+	newDataService(t, nil)
+	alert := new(alertService.MockAlertService)
+	alert.EXPECT().Raise(mock.Anything).Return(nil).Maybe()
+	interlock := NewInterlock()
+	eventBus := event.NewBus()
+	service := NewSafetyService(testConfig, interlock, map[string]groupService.Switch{}, eventBus, alert)
+
+	_, err := service.Acknowledge("10.0.0.1")
+	assert.ErrorIs(t, err, ErrNoActiveAlarm)
+
+	eventBus.Publish(gasEvent(domain.EventDetected))
+	_, err = service.Acknowledge("10.0.0.1")
+	assert.ErrorIs(t, err, ErrGasStillDetected)
+	assert.True(t, interlock.Engaged("smartPlug"))
+
+	eventBus.Publish(gasEvent(domain.EventCleared))
+	assert.Equal(t, domain.SafetyAlarm, service.GetState().Status)
+	got, err := service.Acknowledge("10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SafetyNormal, got.Status)
+	assert.Equal(t, "10.0.0.1", got.AcknowledgedBy)
+	assert.False(t, interlock.Engaged("smartPlug"))
+}
+
+func TestNewSafetyService_Restores(t *testing.T) {
+	tests := []struct {
+		name       string
+		persisted  []domain.SafetyEvent
+		wantStatus domain.SafetyStatus
+	}{
+		{name: "Triggered", persisted: []domain.SafetyEvent{{Type: domain.SafetyEventTriggered, Source: "gasSensor"}},
+			wantStatus: domain.SafetyAlarm},
+		{name: "Acknowledged", persisted: []domain.SafetyEvent{{Type: domain.SafetyEventAcknowledged},
+			{Type: domain.SafetyEventTriggered}}, wantStatus: domain.SafetyNormal},
+		{name: "Empty", wantStatus: domain.SafetyNormal},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newDataService(t, test.persisted)
+			interlock := NewInterlock()
+			alert := new(alertService.MockAlertService)
+			alert.EXPECT().Raise(mock.Anything).Return(nil).Maybe()
+
+			service := NewSafetyService(testConfig, interlock, map[string]groupService.Switch{}, event.NewBus(), alert)
+
+			assert.Equal(t, test.wantStatus, service.GetState().Status)
+			assert.Equal(t, test.wantStatus == domain.SafetyAlarm, interlock.Engaged("ac"))
+		})
+	}
+}
+
+func TestNewSafetyService_RestoredAlarmProtectsUntilCleared(t *testing.T) {
+	newDataService(t, []domain.SafetyEvent{{Type: domain.SafetyEventTriggered, Source: "gasSensor"}})
+	alert := new(alertService.MockAlertService)
+	alert.EXPECT().Raise(mock.Anything).Return(nil)
+	plug := &fakeSwitch{enabled: true}
+	ac := &fakeSwitch{}
+	switches := map[string]groupService.Switch{"smartPlug": plug.toSwitch(), "ac": ac.toSwitch()}
+	eventBus := event.NewBus()
+
+	service := NewSafetyService(testConfig, NewInterlock(), switches, eventBus, alert)
+
+	assert.Eventually(t, func() bool {
+		enabled, _ := plug.state()
+		return !enabled
+	}, time.Second, time.Millisecond)
+	assert.True(t, service.GetState().GasDetected)
+	_, err := service.Acknowledge("10.0.0.1")
+	assert.ErrorIs(t, err, ErrGasStillDetected)
+
+	eventBus.Publish(gasEvent(domain.EventCleared))
+	_, err = service.Acknowledge("10.0.0.1")
+	assert.NoError(t, err)
+}
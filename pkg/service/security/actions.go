@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	alertService "github.com/pklimuk-eng-thesis/control-station/pkg/service/alert"
+	lightService "github.com/pklimuk-eng-thesis/control-station/pkg/service/light"
+)
+
+// LightsOnAction switches on every light that is off.
+func LightsOnAction(lights map[string]lightService.LightService) AlarmAction {
+	return AlarmAction{Name: "lightsOn", Run: func(state domain.SecurityState) error {
+		var failed []string
+		for name, light := range lights {
+			lightInfo, err := light.GetInfo()
+			if err == nil && !lightInfo.Enabled {
+				_, err = light.ToggleEnabled(context.Background())
+			}
+			if err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %s", name, err))
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("Failed to switch on lights: %v", failed)
+		}
+		return nil
+	}}
+}
+
+// NotifyAction raises a critical alert for the sensor that triggered the alarm,
+// so that it is muted like every other alert while the sensor is in
+// maintenance.
+func NotifyAction(alerts alertService.AlertService) AlarmAction {
+	return AlarmAction{Name: "notify", Run: func(state domain.SecurityState) error {
+		return alerts.Raise(domain.Alert{Severity: domain.AlertCritical, Source: state.TriggeredBy,
+			Message: fmt.Sprintf("Alarm triggered by '%s' in mode %s", state.TriggeredBy, state.Mode), Time: state.Since})
+	}}
+}
@@ -0,0 +1,237 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSecurityService is an autogenerated mock type for the SecurityService type
+type MockSecurityService struct {
+	mock.Mock
+}
+
+type MockSecurityService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSecurityService) EXPECT() *MockSecurityService_Expecter {
+	return &MockSecurityService_Expecter{mock: &_m.Mock}
+}
+
+// Arm provides a mock function with given fields: mode, source
+func (_m *MockSecurityService) Arm(mode domain.SecurityMode, source string) (domain.SecurityState, error) {
+	ret := _m.Called(mode, source)
+
+	var r0 domain.SecurityState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.SecurityMode, string) (domain.SecurityState, error)); ok {
+		return rf(mode, source)
+	}
+	if rf, ok := ret.Get(0).(func(domain.SecurityMode, string) domain.SecurityState); ok {
+		r0 = rf(mode, source)
+	} else {
+		r0 = ret.Get(0).(domain.SecurityState)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.SecurityMode, string) error); ok {
+		r1 = rf(mode, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSecurityService_Arm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Arm'
+type MockSecurityService_Arm_Call struct {
+	*mock.Call
+}
+
+// Arm is a helper method to define mock.On call
+//   - mode domain.SecurityMode
+//   - source string
+func (_e *MockSecurityService_Expecter) Arm(mode interface{}, source interface{}) *MockSecurityService_Arm_Call {
+	return &MockSecurityService_Arm_Call{Call: _e.mock.On("Arm", mode, source)}
+}
+
+func (_c *MockSecurityService_Arm_Call) Run(run func(mode domain.SecurityMode, source string)) *MockSecurityService_Arm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.SecurityMode), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSecurityService_Arm_Call) Return(_a0 domain.SecurityState, _a1 error) *MockSecurityService_Arm_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSecurityService_Arm_Call) RunAndReturn(run func(domain.SecurityMode, string) (domain.SecurityState, error)) *MockSecurityService_Arm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Disarm provides a mock function with given fields: pin, source
+func (_m *MockSecurityService) Disarm(pin string, source string) (domain.SecurityState, error) {
+	ret := _m.Called(pin, source)
+
+	var r0 domain.SecurityState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (domain.SecurityState, error)); ok {
+		return rf(pin, source)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) domain.SecurityState); ok {
+		r0 = rf(pin, source)
+	} else {
+		r0 = ret.Get(0).(domain.SecurityState)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(pin, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSecurityService_Disarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Disarm'
+type MockSecurityService_Disarm_Call struct {
+	*mock.Call
+}
+
+// Disarm is a helper method to define mock.On call
+//   - pin string
+//   - source string
+func (_e *MockSecurityService_Expecter) Disarm(pin interface{}, source interface{}) *MockSecurityService_Disarm_Call {
+	return &MockSecurityService_Disarm_Call{Call: _e.mock.On("Disarm", pin, source)}
+}
+
+func (_c *MockSecurityService_Disarm_Call) Run(run func(pin string, source string)) *MockSecurityService_Disarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSecurityService_Disarm_Call) Return(_a0 domain.SecurityState, _a1 error) *MockSecurityService_Disarm_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSecurityService_Disarm_Call) RunAndReturn(run func(string, string) (domain.SecurityState, error)) *MockSecurityService_Disarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSecurityAuditLogsFromDataServiceLimitN provides a mock function with given fields: limit
+func (_m *MockSecurityService) GetSecurityAuditLogsFromDataServiceLimitN(limit int) ([]domain.SecurityTransition, error) {
+	ret := _m.Called(limit)
+
+	var r0 []domain.SecurityTransition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]domain.SecurityTransition, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []domain.SecurityTransition); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SecurityTransition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSecurityService_GetSecurityAuditLogsFromDataServiceLimitN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecurityAuditLogsFromDataServiceLimitN'
+type MockSecurityService_GetSecurityAuditLogsFromDataServiceLimitN_Call struct {
+	*mock.Call
+}
+
+// GetSecurityAuditLogsFromDataServiceLimitN is a helper method to define mock.On call
+//   - limit int
+func (_e *MockSecurityService_Expecter) GetSecurityAuditLogsFromDataServiceLimitN(limit interface{}) *MockSecurityService_GetSecurityAuditLogsFromDataServiceLimitN_Call {
+	return &MockSecurityService_GetSecurityAuditLogsFromDataServiceLimitN_Call{Call: _e.mock.On("GetSecurityAuditLogsFromDataServiceLimitN", limit)}
+}
+
+func (_c *MockSecurityService_GetSecurityAuditLogsFromDataServiceLimitN_Call) Run(run func(limit int)) *MockSecurityService_GetSecurityAuditLogsFromDataServiceLimitN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockSecurityService_GetSecurityAuditLogsFromDataServiceLimitN_Call) Return(_a0 []domain.SecurityTransition, _a1 error) *MockSecurityService_GetSecurityAuditLogsFromDataServiceLimitN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSecurityService_GetSecurityAuditLogsFromDataServiceLimitN_Call) RunAndReturn(run func(int) ([]domain.SecurityTransition, error)) *MockSecurityService_GetSecurityAuditLogsFromDataServiceLimitN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetState provides a mock function with given fields:
+func (_m *MockSecurityService) GetState() domain.SecurityState {
+	ret := _m.Called()
+
+	var r0 domain.SecurityState
+	if rf, ok := ret.Get(0).(func() domain.SecurityState); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.SecurityState)
+	}
+
+	return r0
+}
+
+// MockSecurityService_GetState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetState'
+type MockSecurityService_GetState_Call struct {
+	*mock.Call
+}
+
+// GetState is a helper method to define mock.On call
+func (_e *MockSecurityService_Expecter) GetState() *MockSecurityService_GetState_Call {
+	return &MockSecurityService_GetState_Call{Call: _e.mock.On("GetState")}
+}
+
+func (_c *MockSecurityService_GetState_Call) Run(run func()) *MockSecurityService_GetState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSecurityService_GetState_Call) Return(_a0 domain.SecurityState) *MockSecurityService_GetState_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSecurityService_GetState_Call) RunAndReturn(run func() domain.SecurityState) *MockSecurityService_GetState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockSecurityService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockSecurityService creates a new instance of MockSecurityService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockSecurityService(t mockConstructorTestingTNewMockSecurityService) *MockSecurityService {
+	mock := &MockSecurityService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,376 @@
+package service
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/event"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+var ErrInvalidPIN = errors.New("Invalid PIN")
+var ErrDisarmRequired = errors.New("Alarm is triggered, disarm it first")
+var ErrPINLockedOut = errors.New("Too many invalid PINs, try again later")
+
+const securityLogName = "security"
+
+// securityStateLogName is the log of the applied transitions only, so that
+// rejected requests can not push the current state out of reach.
+const securityStateLogName = "securityState"
+
+// restoreLogsLimit is how many audit records are searched for the latest
+// applied transition while the state log is still empty.
+const restoreLogsLimit = 50
+
+// recordQueueSize is how many transitions may wait to be persisted before
+// changing the state waits for the data service.
+const recordQueueSize = 64
+
+// AlarmAction is run when the alarm triggers, e.g. to switch the lights on or to
+// send a notification.
+type AlarmAction struct {
+	Name string
+	Run  func(state domain.SecurityState) error
+}
+
+//go:generate --name SecurityService --output mock_securityService.go
+type SecurityService interface {
+	GetState() domain.SecurityState
+	Arm(mode domain.SecurityMode, source string) (domain.SecurityState, error)
+	Disarm(pin string, source string) (domain.SecurityState, error)
+	GetSecurityAuditLogsFromDataServiceLimitN(limit int) ([]domain.SecurityTransition, error)
+}
+
+type securityService struct {
+	config  domain.SecurityConfig
+	pin     string
+	actions []AlarmAction
+	now     func() time.Time
+
+	mu         sync.Mutex
+	state      domain.SecurityState
+	entryTimer *time.Timer
+	// entryDelays counts the started entry delays, so that a timer that fired
+	// after being replaced or cancelled can tell it is stale.
+	entryDelays int
+	// failedPINs counts the wrong PINs since the last successful disarm or
+	// lockout.
+	failedPINs  int
+	lockedUntil time.Time
+	// records are persisted one by one in the order of the transitions, so that
+	// a slow data service can not reorder the state log.
+	records chan pendingRecord
+}
+
+// pendingRecord is a transition waiting to be persisted. Done is closed once
+// it was.
+type pendingRecord struct {
+	transition domain.SecurityTransition
+	done       chan struct{}
+}
+
+// NewSecurityService restores the last applied state from the data service and
+// starts watching the detection events of the configured sensors.
+func NewSecurityService(config domain.SecurityConfig, pin string, eventBus event.Bus,
+	actions []AlarmAction) SecurityService {
+	s := &securityService{config: config, pin: pin, actions: actions, now: time.Now,
+		records: make(chan pendingRecord, recordQueueSize)}
+	go s.recordAll()
+	s.state = domain.SecurityState{Mode: domain.SecurityDisarmed, Status: domain.AlarmReady, Since: s.now()}
+	s.restore()
+	eventBus.Subscribe(s.handleEvent)
+	return s
+}
+
+func (s *securityService) GetState() domain.SecurityState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current()
+}
+
+func (s *securityService) Arm(mode domain.SecurityMode, source string) (domain.SecurityState, error) {
+	if mode != domain.SecurityArmedAway && mode != domain.SecurityArmedHome {
+		validationErr := &controlStationUtils.ValidationError{}
+		validationErr.Add("mode", fmt.Sprintf("must be one of %v", []domain.SecurityMode{domain.SecurityArmedAway,
+			domain.SecurityArmedHome}))
+		return s.GetState(), validationErr
+	}
+
+	s.mu.Lock()
+	if s.state.Status != domain.AlarmReady {
+		recorded := s.enqueue(s.rejected(mode, source, ErrDisarmRequired.Error()))
+		state := s.current()
+		s.mu.Unlock()
+		<-recorded
+		return state, ErrDisarmRequired
+	}
+	recorded := s.enqueue(s.apply(mode, domain.AlarmReady, source, ""))
+	state := s.current()
+	s.mu.Unlock()
+
+	<-recorded
+	return state, nil
+}
+
+// Disarm compares the PIN in constant time. Failed attempts are audited like
+// every transition, and too many of them in a row lock disarming out.
+func (s *securityService) Disarm(pin string, source string) (domain.SecurityState, error) {
+	s.mu.Lock()
+	if s.now().Before(s.lockedUntil) {
+		recorded := s.enqueue(s.rejected(domain.SecurityDisarmed, source, ErrPINLockedOut.Error()))
+		state := s.current()
+		s.mu.Unlock()
+		<-recorded
+		return state, ErrPINLockedOut
+	}
+	if subtle.ConstantTimeCompare([]byte(pin), []byte(s.pin)) != 1 {
+		recorded := s.enqueue(s.rejected(domain.SecurityDisarmed, source, ErrInvalidPIN.Error()))
+		s.failedPINs++
+		if s.config.MaxPINAttempts > 0 && s.failedPINs >= s.config.MaxPINAttempts {
+			s.failedPINs = 0
+			s.lockedUntil = s.now().Add(s.config.PINLockout)
+		}
+		state := s.current()
+		s.mu.Unlock()
+		<-recorded
+		return state, ErrInvalidPIN
+	}
+
+	s.failedPINs = 0
+	s.stopEntryTimer()
+	recorded := s.enqueue(s.apply(domain.SecurityDisarmed, domain.AlarmReady, source, ""))
+	state := s.current()
+	s.mu.Unlock()
+
+	<-recorded
+	return state, nil
+}
+
+func (s *securityService) GetSecurityAuditLogsFromDataServiceLimitN(limit int) ([]domain.SecurityTransition, error) {
+	return controlStationUtils.GetLogsFromDataServiceLimitN[domain.SecurityTransition](securityLogName, limit)
+}
+
+// handleEvent starts the entry delay when a watched sensor detects something
+// while the system is armed. It is called by the event bus and only schedules
+// the alarm and queues its record, so that it returns quickly.
+func (s *securityService) handleEvent(e domain.DeviceEvent) {
+	if e.Type != domain.EventDetected {
+		return
+	}
+
+	s.mu.Lock()
+	if s.state.Status != domain.AlarmReady || !contains(s.watchedSensors(s.state.Mode), e.Device) {
+		s.mu.Unlock()
+		return
+	}
+	s.enqueue(s.startEntryDelay(e.Device, s.config.EntryDelay, "detection"))
+	s.mu.Unlock()
+}
+
+func (s *securityService) watchedSensors(mode domain.SecurityMode) []string {
+	switch mode {
+	case domain.SecurityArmedAway:
+		return s.config.AwaySensors
+	case domain.SecurityArmedHome:
+		return s.config.HomeSensors
+	default:
+		return nil
+	}
+}
+
+// startEntryDelay changes the state to the entry delay, which ends after the
+// delay. It must be called with the mutex held.
+func (s *securityService) startEntryDelay(sensor string, delay time.Duration,
+	reason string) domain.SecurityTransition {
+	transition := s.apply(s.state.Mode, domain.AlarmEntryDelay, sensor, reason)
+	deadline := s.state.Since.Add(delay)
+	transition.EntryDeadline = &deadline
+	s.state.TriggeredBy = sensor
+	s.state.EntryDeadline = &deadline
+	s.scheduleTrigger(delay)
+	return transition
+}
+
+// scheduleTrigger raises the alarm after the delay unless the entry delay is
+// cancelled or restarted before. It must be called with the mutex held.
+func (s *securityService) scheduleTrigger(delay time.Duration) {
+	s.stopEntryTimer()
+	s.entryDelays++
+	entryDelay := s.entryDelays
+	s.entryTimer = time.AfterFunc(delay, func() {
+		s.trigger(entryDelay)
+	})
+}
+
+// trigger raises the alarm unless the entry delay was cancelled or restarted
+// in the meantime.
+func (s *securityService) trigger(entryDelay int) {
+	s.mu.Lock()
+	if s.entryTimer == nil || s.entryDelays != entryDelay || s.state.Status != domain.AlarmEntryDelay {
+		s.mu.Unlock()
+		return
+	}
+	s.entryTimer = nil
+	triggeredBy := s.state.TriggeredBy
+	s.enqueue(s.apply(s.state.Mode, domain.AlarmTriggered, triggeredBy, "entry delay expired"))
+	s.state.TriggeredBy = triggeredBy
+	state := s.state
+	s.mu.Unlock()
+
+	for _, action := range s.actions {
+		go func(action AlarmAction) {
+			if err := action.Run(state); err != nil {
+				log.Printf("Failed to run alarm action '%s': %s\n", action.Name, err)
+			}
+		}(action)
+	}
+}
+
+func (s *securityService) stopEntryTimer() {
+	if s.entryTimer != nil {
+		s.entryTimer.Stop()
+		s.entryTimer = nil
+	}
+}
+
+// apply changes the state and returns the audit record of the change. It must
+// be called with the mutex held.
+func (s *securityService) apply(mode domain.SecurityMode, status domain.AlarmStatus, source string,
+	reason string) domain.SecurityTransition {
+	transition := domain.SecurityTransition{FromMode: s.state.Mode, FromStatus: s.state.Status, ToMode: mode,
+		ToStatus: status, Result: domain.SecurityTransitionApplied, Source: source, Reason: reason, Time: s.now().UTC()}
+	s.state = domain.SecurityState{Mode: mode, Status: status, Since: transition.Time}
+	return transition
+}
+
+// current returns the state together with the PIN lockout. It must be called
+// with the mutex held.
+func (s *securityService) current() domain.SecurityState {
+	state := s.state
+	if s.now().Before(s.lockedUntil) {
+		lockedUntil := s.lockedUntil
+		state.PINLockedUntil = &lockedUntil
+	}
+	return state
+}
+
+func (s *securityService) rejected(mode domain.SecurityMode, source string, reason string) domain.SecurityTransition {
+	return domain.SecurityTransition{FromMode: s.state.Mode, FromStatus: s.state.Status, ToMode: mode,
+		ToStatus: s.state.Status, Result: domain.SecurityTransitionRejected, Source: source, Reason: reason,
+		Time: s.now().UTC()}
+}
+
+// enqueue queues the transition to be recorded and returns the channel that is
+// closed once it was. It must be called with the mutex held, so that the
+// transitions are recorded in the order they happened.
+func (s *securityService) enqueue(transition domain.SecurityTransition) <-chan struct{} {
+	done := make(chan struct{})
+	s.records <- pendingRecord{transition: transition, done: done}
+	return done
+}
+
+func (s *securityService) recordAll() {
+	for pending := range s.records {
+		s.record(pending.transition)
+		close(pending.done)
+	}
+}
+
+// record audits the transition in the service log and persists it in the data
+// service. Applied transitions are persisted in the state log as well.
+func (s *securityService) record(transition domain.SecurityTransition) {
+	log.Printf("AUDIT security from=%s/%s to=%s/%s result=%s source=%s reason=%q\n", transition.FromMode,
+		transition.FromStatus, transition.ToMode, transition.ToStatus, transition.Result, transition.Source,
+		transition.Reason)
+
+	logNames := []string{securityLogName}
+	if transition.Result == domain.SecurityTransitionApplied {
+		logNames = append(logNames, securityStateLogName)
+	}
+	for _, logName := range logNames {
+		err := controlStationUtils.SendLogsToDataService(logName, transition)
+		if err != nil {
+			log.Printf("Failed to send '%s' logs to data service: %s\n", logName, err)
+		}
+	}
+}
+
+// restore continues from the latest applied transition. An interrupted entry
+// delay is restarted until its original deadline, or triggers the alarm right
+// away if the deadline has passed. A triggered alarm stays triggered without
+// running the alarm actions again.
+func (s *securityService) restore() {
+	transition, ok, err := s.latestApplied()
+	if err != nil {
+		log.Printf("Failed to restore the security state, starting disarmed: %s\n", err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = domain.SecurityState{Mode: transition.ToMode, Status: transition.ToStatus, Since: transition.Time}
+	if transition.ToStatus != domain.AlarmReady {
+		s.state.TriggeredBy = transition.Source
+	}
+	if transition.ToStatus != domain.AlarmEntryDelay {
+		return
+	}
+
+	deadline := transition.Time.Add(s.config.EntryDelay)
+	if transition.EntryDeadline != nil {
+		deadline = *transition.EntryDeadline
+	}
+	remaining := deadline.Sub(s.now())
+	if remaining <= 0 {
+		s.state.EntryDeadline = &deadline
+		s.scheduleTrigger(0)
+		return
+	}
+	s.state.Status = domain.AlarmReady
+	restarted := s.startEntryDelay(transition.Source, remaining, "restored after restart")
+	restarted.EntryDeadline = &deadline
+	s.state.EntryDeadline = &deadline
+	s.enqueue(restarted)
+}
+
+// latestApplied reads the latest applied transition from the state log. The
+// audit log is searched only while the state log is empty, e.g. right after an
+// upgrade.
+func (s *securityService) latestApplied() (domain.SecurityTransition, bool, error) {
+	states, err := controlStationUtils.GetLogsFromDataServiceLimitN[domain.SecurityTransition](securityStateLogName, 1)
+	if err != nil {
+		return domain.SecurityTransition{}, false, err
+	}
+	if len(states) > 0 {
+		return states[0], true, nil
+	}
+
+	transitions, err := s.GetSecurityAuditLogsFromDataServiceLimitN(restoreLogsLimit)
+	if err != nil {
+		return domain.SecurityTransition{}, false, err
+	}
+	for _, transition := range transitions {
+		if transition.Result == domain.SecurityTransitionApplied {
+			return transition, true, nil
+		}
+	}
+	return domain.SecurityTransition{}, false, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,401 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/event"
+	alertService "github.com/pklimuk-eng-thesis/control-station/pkg/service/alert"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDataService serves the persisted transitions and records the new ones.
+type fakeDataService struct {
+	mu          sync.Mutex
+	persisted   []domain.SecurityTransition
+	states      []domain.SecurityTransition
+	transitions []domain.SecurityTransition
+}
+
+func (f *fakeDataService) server() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch r.URL.Path {
+		case "/security/add":
+			var transition domain.SecurityTransition
+			json.NewDecoder(r.Body).Decode(&transition)
+			f.transitions = append(f.transitions, transition)
+			w.WriteHeader(http.StatusOK)
+		case "/security/latest":
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(f.persisted)
+		case "/securityState/add":
+			var transition domain.SecurityTransition
+			json.NewDecoder(r.Body).Decode(&transition)
+			f.states = append([]domain.SecurityTransition{transition}, f.states...)
+			w.WriteHeader(http.StatusOK)
+		case "/securityState/latest":
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(f.states)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func (f *fakeDataService) recorded() []domain.SecurityTransition {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]domain.SecurityTransition{}, f.transitions...)
+}
+
+var testConfig = domain.SecurityConfig{
+	AwaySensors: []string{"doorsSensor", "presenceSensor"},
+	HomeSensors: []string{"doorsSensor"},
+	EntryDelay:  20 * time.Millisecond,
+}
+
+func newTestSecurityService(t *testing.T, dataService *fakeDataService, actions ...AlarmAction) (*securityService, event.Bus) {
+	ts := dataService.server()
+	t.Cleanup(ts.Close)
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	eventBus := event.NewBus()
+	service := NewSecurityService(testConfig, "1234", eventBus, actions).(*securityService)
+	return service, eventBus
+}
+
+func detected(device string) domain.DeviceEvent {
+	return domain.DeviceEvent{Device: device, Type: domain.EventDetected, Time: time.Now()}
+}
+
+func TestNewSecurityService_StartsDisarmed(t *testing.T) {
+	service, _ := newTestSecurityService(t, &fakeDataService{})
+
+	got := service.GetState()
+
+	assert.Equal(t, domain.SecurityDisarmed, got.Mode)
+	assert.Equal(t, domain.AlarmReady, got.Status)
+}
+
+func TestNewSecurityService_Restores(t *testing.T) {
+	rejected := make([]domain.SecurityTransition, 60)
+	for i := range rejected {
+		rejected[i] = domain.SecurityTransition{ToMode: domain.SecurityDisarmed, Result: domain.SecurityTransitionRejected}
+	}
+	entryDeadline := time.Now().Add(time.Hour)
+	tests := []struct {
+		name       string
+		persisted  []domain.SecurityTransition
+		states     []domain.SecurityTransition
+		wantMode   domain.SecurityMode
+		wantStatus domain.AlarmStatus
+	}{
+		{
+			name:      "StateLog",
+			persisted: rejected,
+			states: []domain.SecurityTransition{
+				{ToMode: domain.SecurityArmedAway, ToStatus: domain.AlarmReady, Result: domain.SecurityTransitionApplied},
+			},
+			wantMode:   domain.SecurityArmedAway,
+			wantStatus: domain.AlarmReady,
+		},
+		{
+			name: "AuditLogWithoutStateLog",
+			persisted: []domain.SecurityTransition{
+				{ToMode: domain.SecurityDisarmed, Result: domain.SecurityTransitionRejected},
+				{ToMode: domain.SecurityArmedAway, ToStatus: domain.AlarmReady, Result: domain.SecurityTransitionApplied},
+				{ToMode: domain.SecurityDisarmed, ToStatus: domain.AlarmReady, Result: domain.SecurityTransitionApplied},
+			},
+			wantMode:   domain.SecurityArmedAway,
+			wantStatus: domain.AlarmReady,
+		},
+		{
+			name: "Triggered",
+			persisted: []domain.SecurityTransition{
+				{ToMode: domain.SecurityArmedHome, ToStatus: domain.AlarmTriggered, Source: "doorsSensor",
+					Result: domain.SecurityTransitionApplied},
+			},
+			wantMode:   domain.SecurityArmedHome,
+			wantStatus: domain.AlarmTriggered,
+		},
+		{
+			name: "EntryDelay",
+			persisted: []domain.SecurityTransition{
+				{ToMode: domain.SecurityArmedAway, ToStatus: domain.AlarmEntryDelay, Source: "doorsSensor",
+					Result: domain.SecurityTransitionApplied, Time: time.Now(), EntryDeadline: &entryDeadline},
+			},
+			wantMode:   domain.SecurityArmedAway,
+			wantStatus: domain.AlarmEntryDelay,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, _ := newTestSecurityService(t, &fakeDataService{persisted: test.persisted, states: test.states})
+
+			got := service.GetState()
+
+			assert.Equal(t, test.wantMode, got.Mode)
+			assert.Equal(t, test.wantStatus, got.Status)
+		})
+	}
+}
+
+func TestNewSecurityService_RestoredEntryDelayKeepsDeadline(t *testing.T) {
+	deadline := time.Now().Add(time.Hour).UTC()
+	dataService := &fakeDataService{states: []domain.SecurityTransition{
+		{ToMode: domain.SecurityArmedAway, ToStatus: domain.AlarmEntryDelay, Source: "doorsSensor",
+			Result: domain.SecurityTransitionApplied, Time: time.Now().UTC(), EntryDeadline: &deadline},
+	}}
+	service, _ := newTestSecurityService(t, dataService)
+
+	got := service.GetState()
+
+	assert.Equal(t, domain.AlarmEntryDelay, got.Status)
+	assert.True(t, deadline.Equal(*got.EntryDeadline))
+	assert.Eventually(t, func() bool { return len(dataService.recorded()) == 1 }, time.Second, 10*time.Millisecond)
+	restarted := dataService.recorded()[0]
+	assert.Equal(t, domain.AlarmEntryDelay, restarted.ToStatus)
+	assert.Equal(t, "doorsSensor", restarted.Source)
+	assert.True(t, deadline.Equal(*restarted.EntryDeadline))
+}
+
+func TestNewSecurityService_RestoredEntryDelayPastDeadlineTriggers(t *testing.T) {
+	triggered := make(chan domain.SecurityState, 1)
+	action := AlarmAction{Name: "test", Run: func(state domain.SecurityState) error {
+		triggered <- state
+		return nil
+	}}
+	dataService := &fakeDataService{states: []domain.SecurityTransition{
+		{ToMode: domain.SecurityArmedAway, ToStatus: domain.AlarmEntryDelay, Source: "doorsSensor",
+			Result: domain.SecurityTransitionApplied, Time: time.Now().Add(-time.Hour).UTC()},
+	}}
+	service, _ := newTestSecurityService(t, dataService, action)
+
+	select {
+	case state := <-triggered:
+		assert.Equal(t, domain.AlarmTriggered, state.Status)
+		assert.Equal(t, "doorsSensor", state.TriggeredBy)
+	case <-time.After(testConfig.EntryDelay / 2):
+		t.Fatal("alarm did not trigger right away")
+	}
+	assert.Equal(t, domain.AlarmTriggered, service.GetState().Status)
+	assert.Eventually(t, func() bool { return len(dataService.recorded()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, domain.AlarmTriggered, dataService.recorded()[0].ToStatus)
+}
+
+func TestArm(t *testing.T) {
+	dataService := &fakeDataService{}
+	service, _ := newTestSecurityService(t, dataService)
+
+	got, err := service.Arm(domain.SecurityArmedAway, "10.0.0.1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SecurityArmedAway, got.Mode)
+	assert.Equal(t, domain.AlarmReady, got.Status)
+	recorded := dataService.recorded()
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, domain.SecurityDisarmed, recorded[0].FromMode)
+	assert.Equal(t, domain.SecurityArmedAway, recorded[0].ToMode)
+	assert.Equal(t, domain.SecurityTransitionApplied, recorded[0].Result)
+	assert.Equal(t, "10.0.0.1", recorded[0].Source)
+}
+
+func TestArm_InvalidMode(t *testing.T) {
+	service, _ := newTestSecurityService(t, &fakeDataService{})
+
+	_, err := service.Arm(domain.SecurityDisarmed, "10.0.0.1")
+
+	var validationErr *controlStationUtils.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestDisarm(t *testing.T) {
+	dataService := &fakeDataService{}
+	service, _ := newTestSecurityService(t, dataService)
+	service.Arm(domain.SecurityArmedHome, "10.0.0.1")
+
+	_, err := service.Disarm("0000", "10.0.0.2")
+	assert.ErrorIs(t, err, ErrInvalidPIN)
+	assert.Equal(t, domain.SecurityArmedHome, service.GetState().Mode)
+
+	got, err := service.Disarm("1234", "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SecurityDisarmed, got.Mode)
+
+	recorded := dataService.recorded()
+	assert.Len(t, recorded, 3)
+	assert.Equal(t, domain.SecurityTransitionRejected, recorded[1].Result)
+	assert.Equal(t, "Invalid PIN", recorded[1].Reason)
+	assert.Equal(t, domain.SecurityTransitionApplied, recorded[2].Result)
+}
+
+func TestDisarm_PersistsAppliedTransitions(t *testing.T) {
+	dataService := &fakeDataService{}
+	service, _ := newTestSecurityService(t, dataService)
+	service.Arm(domain.SecurityArmedHome, "10.0.0.1")
+	service.Disarm("0000", "10.0.0.2")
+
+	restored, _ := newTestSecurityService(t, dataService)
+
+	assert.Len(t, dataService.states, 1)
+	assert.Equal(t, domain.SecurityArmedHome, restored.GetState().Mode)
+}
+
+func TestDisarm_LockedOutAfterTooManyInvalidPINs(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	service, _ := newTestSecurityService(t, &fakeDataService{})
+	service.config.MaxPINAttempts = 3
+	service.config.PINLockout = time.Minute
+	service.now = func() time.Time { return now }
+	service.Arm(domain.SecurityArmedAway, "10.0.0.1")
+
+	for i := 0; i < 3; i++ {
+		_, err := service.Disarm("0000", "10.0.0.2")
+		assert.ErrorIs(t, err, ErrInvalidPIN)
+	}
+	got, err := service.Disarm("1234", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrPINLockedOut)
+	assert.Equal(t, domain.SecurityArmedAway, got.Mode)
+	assert.Equal(t, now.Add(time.Minute), *got.PINLockedUntil)
+
+	now = now.Add(time.Minute)
+	got, err = service.Disarm("1234", "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SecurityDisarmed, got.Mode)
+	assert.Nil(t, got.PINLockedUntil)
+}
+
+func TestDetection_TriggersAlarmAfterEntryDelay(t *testing.T) {
+	actionStates := make(chan domain.SecurityState, 2)
+	action := AlarmAction{Name: "test", Run: func(state domain.SecurityState) error {
+		actionStates <- state
+		return nil
+	}}
+	failing := AlarmAction{Name: "failing", Run: func(state domain.SecurityState) error {
+		return errors.New("unreachable")
+	}}
+	dataService := &fakeDataService{}
+	service, eventBus := newTestSecurityService(t, dataService, action, failing)
+	service.Arm(domain.SecurityArmedAway, "10.0.0.1")
+
+	eventBus.Publish(detected("presenceSensor"))
+
+	got := service.GetState()
+	assert.Equal(t, domain.AlarmEntryDelay, got.Status)
+	assert.Equal(t, "presenceSensor", got.TriggeredBy)
+	assert.NotNil(t, got.EntryDeadline)
+
+	select {
+	case state := <-actionStates:
+		assert.Equal(t, domain.AlarmTriggered, state.Status)
+		assert.Equal(t, "presenceSensor", state.TriggeredBy)
+	case <-time.After(time.Second):
+		t.Fatal("alarm action was not run")
+	}
+	assert.Equal(t, domain.AlarmTriggered, service.GetState().Status)
+
+	_, err := service.Arm(domain.SecurityArmedHome, "10.0.0.1")
+	assert.ErrorIs(t, err, ErrDisarmRequired)
+}
+
+func TestDetection_DoesNotWaitForDataService(t *testing.T) {
+	dataService := &fakeDataService{}
+	service, eventBus := newTestSecurityService(t, dataService)
+	service.config.EntryDelay = time.Hour
+	service.Arm(domain.SecurityArmedAway, "10.0.0.1")
+	dataService.mu.Lock()
+	released := false
+	release := func() {
+		if !released {
+			released = true
+			dataService.mu.Unlock()
+		}
+	}
+	defer release()
+
+	published := make(chan struct{})
+	go func() {
+		eventBus.Publish(detected("doorsSensor"))
+		close(published)
+	}()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("publishing the detection waited for the data service")
+	}
+	assert.Equal(t, domain.AlarmEntryDelay, service.GetState().Status)
+	release()
+	assert.Eventually(t, func() bool { return len(dataService.recorded()) == 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, domain.AlarmEntryDelay, dataService.recorded()[1].ToStatus)
+}
+
+func TestDetection_DisarmDuringEntryDelay(t *testing.T) {
+	triggered := make(chan struct{}, 1)
+	action := AlarmAction{Name: "test", Run: func(state domain.SecurityState) error {
+		triggered <- struct{}{}
+		return nil
+	}}
+	service, eventBus := newTestSecurityService(t, &fakeDataService{}, action)
+	service.Arm(domain.SecurityArmedAway, "10.0.0.1")
+
+	eventBus.Publish(detected("doorsSensor"))
+	_, err := service.Disarm("1234", "10.0.0.1")
+	assert.NoError(t, err)
+
+	select {
+	case <-triggered:
+		t.Fatal("alarm must not trigger after disarming")
+	case <-time.After(3 * testConfig.EntryDelay):
+	}
+	assert.Equal(t, domain.AlarmReady, service.GetState().Status)
+}
+
+func TestDetection_Ignored(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  domain.SecurityMode
+		event domain.DeviceEvent
+	}{
+		{name: "Disarmed", mode: domain.SecurityDisarmed, event: detected("doorsSensor")},
+		{name: "NotWatchedAtHome", mode: domain.SecurityArmedHome, event: detected("presenceSensor")},
+		{name: "UnknownSensor", mode: domain.SecurityArmedAway, event: detected("gasSensor")},
+		{name: "Cleared", mode: domain.SecurityArmedAway,
+			event: domain.DeviceEvent{Device: "doorsSensor", Type: domain.EventCleared}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, eventBus := newTestSecurityService(t, &fakeDataService{})
+			if test.mode != domain.SecurityDisarmed {
+				service.Arm(test.mode, "10.0.0.1")
+			}
+
+			eventBus.Publish(test.event)
+
+			assert.Equal(t, domain.AlarmReady, service.GetState().Status)
+		})
+	}
+}
+
+func TestNotifyAction(t *testing.T) {
+	since := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	alerts := new(alertService.MockAlertService)
+	alerts.EXPECT().Raise(domain.Alert{Severity: domain.AlertCritical, Source: "doorsSensor",
+		Message: "Alarm triggered by 'doorsSensor' in mode armed_away", Time: since}).Return(nil)
+
+	err := NotifyAction(alerts).Run(domain.SecurityState{Mode: domain.SecurityArmedAway, Status: domain.AlarmTriggered,
+		TriggeredBy: "doorsSensor", Since: since})
+
+	assert.NoError(t, err)
+	alerts.AssertExpectations(t)
+}
@@ -0,0 +1,78 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockStatusService is an autogenerated mock type for the StatusService type
+type MockStatusService struct {
+	mock.Mock
+}
+
+type MockStatusService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStatusService) EXPECT() *MockStatusService_Expecter {
+	return &MockStatusService_Expecter{mock: &_m.Mock}
+}
+
+// GetHomeStatus provides a mock function with given fields: cachedOnly
+func (_m *MockStatusService) GetHomeStatus(cachedOnly bool) domain.HomeStatus {
+	ret := _m.Called(cachedOnly)
+
+	var r0 domain.HomeStatus
+	if rf, ok := ret.Get(0).(func(bool) domain.HomeStatus); ok {
+		r0 = rf(cachedOnly)
+	} else {
+		r0 = ret.Get(0).(domain.HomeStatus)
+	}
+
+	return r0
+}
+
+// MockStatusService_GetHomeStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHomeStatus'
+type MockStatusService_GetHomeStatus_Call struct {
+	*mock.Call
+}
+
+// GetHomeStatus is a helper method to define mock.On call
+//   - cachedOnly bool
+func (_e *MockStatusService_Expecter) GetHomeStatus(cachedOnly interface{}) *MockStatusService_GetHomeStatus_Call {
+	return &MockStatusService_GetHomeStatus_Call{Call: _e.mock.On("GetHomeStatus", cachedOnly)}
+}
+
+func (_c *MockStatusService_GetHomeStatus_Call) Run(run func(cachedOnly bool)) *MockStatusService_GetHomeStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(bool))
+	})
+	return _c
+}
+
+func (_c *MockStatusService_GetHomeStatus_Call) Return(_a0 domain.HomeStatus) *MockStatusService_GetHomeStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStatusService_GetHomeStatus_Call) RunAndReturn(run func(bool) domain.HomeStatus) *MockStatusService_GetHomeStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockStatusService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockStatusService creates a new instance of MockStatusService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockStatusService(t mockConstructorTestingTNewMockStatusService) *MockStatusService {
+	mock := &MockStatusService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
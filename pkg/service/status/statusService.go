@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/state"
+)
+
+var ErrNoStatusReader = errors.New("Device status can not be read")
+var ErrDeviceTimeout = errors.New("Device did not respond in time")
+
+//go:generate --name StatusService --output mock_statusService.go
+type StatusService interface {
+	GetHomeStatus(cachedOnly bool) domain.HomeStatus
+}
+
+type statusService struct {
+	registry      registry.Registry
+	readers       map[string]domain.StatusReader
+	stateCache    state.Cache
+	deviceTimeout time.Duration
+	now           func() time.Time
+}
+
+func NewStatusService(registry registry.Registry, readers map[string]domain.StatusReader, stateCache state.Cache,
+	deviceTimeout time.Duration) StatusService {
+	return &statusService{registry: registry, readers: readers, stateCache: stateCache, deviceTimeout: deviceTimeout,
+		now: time.Now}
+}
+
+// GetHomeStatus reads all registered devices concurrently. A device that does
+// not respond within the device timeout is reported from the state cache and
+// its request is cancelled, so the status is never slower than the timeout.
+// With cachedOnly no device is queried at all.
+func (s *statusService) GetHomeStatus(cachedOnly bool) domain.HomeStatus {
+	devices := s.registry.List()
+	reports := make([]domain.DeviceReport, len(devices))
+
+	var wg sync.WaitGroup
+	for i, device := range devices {
+		reports[i] = domain.DeviceReport{Name: device.Name, Kind: device.Kind, Room: device.Room}
+		reader, ok := s.readers[device.Name]
+		if cachedOnly || !ok {
+			s.fromCache(&reports[i], ok)
+			continue
+		}
+
+		wg.Add(1)
+		go func(report *domain.DeviceReport, reader domain.StatusReader) {
+			defer wg.Done()
+			s.read(report, reader)
+		}(&reports[i], reader)
+	}
+	wg.Wait()
+
+	return domain.HomeStatus{GeneratedAt: s.now(), Devices: reports}
+}
+
+func (s *statusService) read(report *domain.DeviceReport, reader domain.StatusReader) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.deviceTimeout)
+	defer cancel()
+
+	start := s.now()
+	deviceState, err := reader(ctx)
+	if err != nil && ctx.Err() != nil {
+		err = fmt.Errorf("%w after %s", ErrDeviceTimeout, s.deviceTimeout)
+	}
+	if err != nil {
+		s.fromCache(report, true)
+		report.Error = err.Error()
+		return
+	}
+
+	latency := s.now().Sub(start).Milliseconds()
+	s.stateCache.Set(report.Name, deviceState)
+	cached, _ := s.stateCache.Get(report.Name)
+	report.Reachable = true
+	report.LatencyMs = &latency
+	report.State = deviceState
+	report.UpdatedAt = &cached.UpdatedAt
+}
+
+func (s *statusService) fromCache(report *domain.DeviceReport, readable bool) {
+	if !readable {
+		report.Error = ErrNoStatusReader.Error()
+	}
+
+	cached, ok := s.stateCache.Get(report.Name)
+	if !ok {
+		return
+	}
+	report.Cached = true
+	report.State = cached.State
+	report.UpdatedAt = &cached.UpdatedAt
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRegistry() registry.Registry {
+	r := registry.NewRegistry()
+	r.Register(domain.RegisteredDevice{Name: "ac", Kind: domain.KindAC})
+	r.Register(domain.RegisteredDevice{Name: "gasSensor", Kind: domain.KindSensor})
+	r.Register(domain.RegisteredDevice{Name: "smartBulb", Kind: domain.KindLight})
+	r.Register(domain.RegisteredDevice{Name: "smartPlug", Kind: domain.KindPlug})
+	return r
+}
+
+func TestGetHomeStatus(t *testing.T) {
+	stateCache := state.NewCache()
+	stateCache.Set("smartPlug", domain.PlugInfo{Enabled: true})
+	readers := map[string]domain.StatusReader{
+		"ac":        func(ctx context.Context) (any, error) { return domain.ACInfo{Enabled: true}, nil },
+		"gasSensor": func(ctx context.Context) (any, error) { return nil, errors.New("unreachable") },
+		"smartPlug": func(ctx context.Context) (any, error) {
+			select {
+			case <-time.After(500 * time.Millisecond):
+				return domain.PlugInfo{Enabled: false}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		},
+	}
+	service := NewStatusService(newTestRegistry(), readers, stateCache, 20*time.Millisecond)
+
+	start := time.Now()
+	got := service.GetHomeStatus(false)
+
+	assert.Less(t, time.Since(start), 400*time.Millisecond)
+	assert.Len(t, got.Devices, 4)
+
+	ac := got.Devices[0]
+	assert.True(t, ac.Reachable)
+	assert.False(t, ac.Cached)
+	assert.Equal(t, domain.ACInfo{Enabled: true}, ac.State)
+	assert.NotNil(t, ac.LatencyMs)
+	assert.NotNil(t, ac.UpdatedAt)
+
+	gasSensor := got.Devices[1]
+	assert.False(t, gasSensor.Reachable)
+	assert.Equal(t, "unreachable", gasSensor.Error)
+	assert.Nil(t, gasSensor.State)
+
+	smartBulb := got.Devices[2]
+	assert.False(t, smartBulb.Reachable)
+	assert.Equal(t, ErrNoStatusReader.Error(), smartBulb.Error)
+
+	smartPlug := got.Devices[3]
+	assert.False(t, smartPlug.Reachable)
+	assert.True(t, smartPlug.Cached)
+	assert.Equal(t, domain.PlugInfo{Enabled: true}, smartPlug.State)
+	assert.Equal(t, "Device did not respond in time after 20ms", smartPlug.Error)
+
+	cached, ok := stateCache.Get("ac")
+	assert.True(t, ok)
+	assert.Equal(t, domain.ACInfo{Enabled: true}, cached.State)
+}
+
+func TestGetHomeStatus_CachedOnly(t *testing.T) {
+	stateCache := state.NewCache()
+	stateCache.Set("ac", domain.ACInfo{Enabled: false})
+	readers := map[string]domain.StatusReader{
+		"ac": func(ctx context.Context) (any, error) {
+			t.Error("device must not be queried")
+			return nil, nil
+		},
+	}
+	service := NewStatusService(newTestRegistry(), readers, stateCache, time.Second)
+
+	got := service.GetHomeStatus(true)
+
+	assert.True(t, got.Devices[0].Cached)
+	assert.False(t, got.Devices[0].Reachable)
+	assert.Equal(t, domain.ACInfo{Enabled: false}, got.Devices[0].State)
+	assert.Nil(t, got.Devices[0].LatencyMs)
+	assert.Empty(t, got.Devices[0].Error)
+	assert.False(t, got.Devices[1].Cached)
+}
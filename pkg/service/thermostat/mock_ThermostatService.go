@@ -0,0 +1,118 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockThermostatService is an autogenerated mock type for the ThermostatService type
+type MockThermostatService struct {
+	mock.Mock
+}
+
+type MockThermostatService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockThermostatService) EXPECT() *MockThermostatService_Expecter {
+	return &MockThermostatService_Expecter{mock: &_m.Mock}
+}
+
+// Evaluate provides a mock function with given fields:
+func (_m *MockThermostatService) Evaluate() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockThermostatService_Evaluate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Evaluate'
+type MockThermostatService_Evaluate_Call struct {
+	*mock.Call
+}
+
+// Evaluate is a helper method to define mock.On call
+func (_e *MockThermostatService_Expecter) Evaluate() *MockThermostatService_Evaluate_Call {
+	return &MockThermostatService_Evaluate_Call{Call: _e.mock.On("Evaluate")}
+}
+
+func (_c *MockThermostatService_Evaluate_Call) Run(run func()) *MockThermostatService_Evaluate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockThermostatService_Evaluate_Call) Return(_a0 error) *MockThermostatService_Evaluate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockThermostatService_Evaluate_Call) RunAndReturn(run func() error) *MockThermostatService_Evaluate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStatus provides a mock function with given fields:
+func (_m *MockThermostatService) GetStatus() domain.ThermostatStatus {
+	ret := _m.Called()
+
+	var r0 domain.ThermostatStatus
+	if rf, ok := ret.Get(0).(func() domain.ThermostatStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.ThermostatStatus)
+	}
+
+	return r0
+}
+
+// MockThermostatService_GetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatus'
+type MockThermostatService_GetStatus_Call struct {
+	*mock.Call
+}
+
+// GetStatus is a helper method to define mock.On call
+func (_e *MockThermostatService_Expecter) GetStatus() *MockThermostatService_GetStatus_Call {
+	return &MockThermostatService_GetStatus_Call{Call: _e.mock.On("GetStatus")}
+}
+
+func (_c *MockThermostatService_GetStatus_Call) Run(run func()) *MockThermostatService_GetStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockThermostatService_GetStatus_Call) Return(_a0 domain.ThermostatStatus) *MockThermostatService_GetStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockThermostatService_GetStatus_Call) RunAndReturn(run func() domain.ThermostatStatus) *MockThermostatService_GetStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockThermostatService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockThermostatService creates a new instance of MockThermostatService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockThermostatService(t mockConstructorTestingTNewMockThermostatService) *MockThermostatService {
+	mock := &MockThermostatService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	acService "github.com/pklimuk-eng-thesis/control-station/pkg/service/ac"
+	analogSensorService "github.com/pklimuk-eng-thesis/control-station/pkg/service/analog"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+)
+
+var ErrSensorDisabled = errors.New("Temperature sensor is disabled")
+
+//go:generate --name ThermostatService --output mock_thermostatService.go
+type ThermostatService interface {
+	GetStatus() domain.ThermostatStatus
+	Evaluate() error
+}
+
+type thermostatService struct {
+	config domain.ThermostatConfig
+	sensor analogSensorService.AnalogSensorService
+	ac     acService.ACService
+	now    func() time.Time
+
+	// evaluateMu serializes the evaluations, so that the status can be read
+	// while an evaluation waits for the devices.
+	evaluateMu sync.Mutex
+	mu         sync.Mutex
+	status     domain.ThermostatStatus
+	acEnabled  *bool
+	lastSwitch time.Time
+}
+
+// NewThermostatService returns the controller of the AC. It does nothing on
+// its own, Evaluate has to be called periodically.
+func NewThermostatService(config domain.ThermostatConfig, sensor analogSensorService.AnalogSensorService,
+	ac acService.ACService) ThermostatService {
+	s := &thermostatService{config: config, sensor: sensor, ac: ac, now: time.Now}
+	s.status = domain.ThermostatStatus{Mode: config.Mode, Target: config.Target, Decision: domain.ThermostatPending,
+		Reason: "Not evaluated yet"}
+	return s
+}
+
+// ValidateConfig checks the mode and the targets of the controller against the
+// capabilities of the AC, so that it is not left retrying settings the AC
+// rejects.
+func ValidateConfig(config domain.ThermostatConfig) error {
+	validationErr := &controlStationUtils.ValidationError{}
+	capabilities := config.ACCapabilities
+	if len(capabilities.Modes) > 0 && !capabilities.SupportsMode(config.Mode) {
+		validationErr.Add("mode", fmt.Sprintf("must be one of %v", capabilities.Modes))
+	}
+	validationErr.AddRangeErrors("target", config.Target, capabilities.MinTemperature, capabilities.MaxTemperature,
+		capabilities.TemperatureStep)
+	for i, period := range config.Schedule {
+		validationErr.AddRangeErrors(fmt.Sprintf("schedule[%d].target", i), period.Target,
+			capabilities.MinTemperature, capabilities.MaxTemperature, capabilities.TemperatureStep)
+	}
+	return validationErr.ErrorOrNil()
+}
+
+func (s *thermostatService) GetStatus() domain.ThermostatStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status
+}
+
+// Evaluate reads the temperature and the AC, decides whether the AC should run
+// and applies the decision. The decision and its reason are kept as the status.
+func (s *thermostatService) Evaluate() error {
+	s.evaluateMu.Lock()
+	defer s.evaluateMu.Unlock()
+
+	now := s.now()
+	target, period := s.target(now)
+	status := domain.ThermostatStatus{Mode: s.config.Mode, Target: target, Period: period, EvaluatedAt: &now}
+
+	reading, err := s.sensor.GetInfo()
+	if err != nil {
+		return s.fail(status, fmt.Errorf("Failed to read '%s': %w", s.config.Sensor, err))
+	}
+	if !reading.Enabled {
+		return s.fail(status, ErrSensorDisabled)
+	}
+	temperature := reading.Value
+	status.Temperature = &temperature
+
+	acInfo, err := s.ac.GetInfo()
+	if err != nil {
+		return s.fail(status, fmt.Errorf("Failed to read '%s': %w", s.config.AC, err))
+	}
+	lastSwitch := s.observe(acInfo.Enabled, now)
+
+	wantOn, reason := s.demand(temperature, target, acInfo.Enabled)
+	switch {
+	case wantOn && acInfo.Enabled:
+		status.Decision = domain.ThermostatStayOn
+		if acInfo.Temperature != target || (s.controlsMode() && acInfo.Mode != s.config.Mode) {
+			acInfo, err = s.ac.UpdateACSettings(s.settings(acInfo, target))
+			reason += fmt.Sprintf(", setpoint changed to %.1f", target)
+		}
+	case !wantOn && !acInfo.Enabled:
+		status.Decision = domain.ThermostatStayOff
+	case wantOn:
+		if next := lastSwitch.Add(s.config.MinOffTime); now.Before(next) {
+			status.Decision = domain.ThermostatHold
+			status.NextSwitchAt = &next
+			reason += fmt.Sprintf(", waiting for the minimum off time of %s", s.config.MinOffTime)
+			break
+		}
+		status.Decision = domain.ThermostatTurnOn
+		acInfo, err = s.turnOn(acInfo, target)
+	default:
+		if next := lastSwitch.Add(s.config.MinOnTime); now.Before(next) {
+			status.Decision = domain.ThermostatHold
+			status.NextSwitchAt = &next
+			reason += fmt.Sprintf(", waiting for the minimum on time of %s", s.config.MinOnTime)
+			break
+		}
+		status.Decision = domain.ThermostatTurnOff
+		acInfo, err = s.ac.ToggleEnabled(context.Background())
+	}
+	if err != nil {
+		return s.fail(status, fmt.Errorf("Failed to control '%s': %w", s.config.AC, err))
+	}
+
+	lastSwitch = s.observe(acInfo.Enabled, now)
+	status.ACEnabled = acInfo.Enabled
+	status.Reason = reason
+	if !lastSwitch.IsZero() {
+		status.LastSwitchAt = &lastSwitch
+	}
+	s.setStatus(status)
+	return nil
+}
+
+// demand tells whether the AC should run. Within the hysteresis band around the
+// target it keeps running or stays off, whichever it does now.
+func (s *thermostatService) demand(temperature float32, target float32, enabled bool) (bool, string) {
+	high := target + s.config.Hysteresis
+	low := target - s.config.Hysteresis
+	switch {
+	case temperature > high:
+		return s.config.Mode == domain.ACModeCool, fmt.Sprintf("%.1f is above the target %.1f by more than %.1f",
+			temperature, target, s.config.Hysteresis)
+	case temperature < low:
+		return s.config.Mode == domain.ACModeHeat, fmt.Sprintf("%.1f is below the target %.1f by more than %.1f",
+			temperature, target, s.config.Hysteresis)
+	default:
+		return enabled, fmt.Sprintf("%.1f is within %.1f of the target %.1f", temperature, s.config.Hysteresis,
+			target)
+	}
+}
+
+// turnOn applies the target with the AC enabled. Depending on the power-on
+// policy of the AC the update alone may not switch it on.
+func (s *thermostatService) turnOn(acInfo domain.ACInfo, target float32) (domain.ACInfo, error) {
+	acInfo, err := s.ac.UpdateACSettings(s.settings(acInfo, target))
+	if err != nil || acInfo.Enabled {
+		return acInfo, err
+	}
+	return s.ac.ToggleEnabled(context.Background())
+}
+
+// settings keeps the current settings of the AC, e.g. the humidity and the fan
+// speed, and changes only what the controller is responsible for.
+func (s *thermostatService) settings(acInfo domain.ACInfo, target float32) domain.ACInfo {
+	acInfo.Enabled = true
+	if s.controlsMode() {
+		acInfo.Mode = s.config.Mode
+	}
+	acInfo.Temperature = target
+	return acInfo
+}
+
+// controlsMode tells whether the AC has modes. An AC without them only heats
+// or cools by its own setting and rejects any mode.
+func (s *thermostatService) controlsMode() bool {
+	return len(s.config.ACCapabilities.Modes) > 0
+}
+
+// observe tracks when the AC was last switched, including by someone else, and
+// returns that time. When the AC is observed for the first time, e.g. after a
+// restart, it is not known when it was switched, so it is taken to be now and
+// the minimum on and off times are kept from then on.
+func (s *thermostatService) observe(enabled bool, now time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.acEnabled == nil || *s.acEnabled != enabled {
+		s.lastSwitch = now
+	}
+	s.acEnabled = &enabled
+	return s.lastSwitch
+}
+
+// target returns the target of the schedule period active at the given time and
+// the start of that period, or the configured target without a schedule.
+func (s *thermostatService) target(now time.Time) (float32, string) {
+	if len(s.config.Schedule) == 0 {
+		return s.config.Target, ""
+	}
+
+	periods := make([]domain.ThermostatPeriod, len(s.config.Schedule))
+	copy(periods, s.config.Schedule)
+	sort.Slice(periods, func(i, j int) bool {
+		return startMinute(periods[i]) < startMinute(periods[j])
+	})
+
+	minute := now.Hour()*60 + now.Minute()
+	active := periods[len(periods)-1]
+	for _, period := range periods {
+		if startMinute(period) <= minute {
+			active = period
+		}
+	}
+	return active.Target, active.Start
+}
+
+// startMinute expects the schedule to be validated with StartMinute beforehand.
+func startMinute(period domain.ThermostatPeriod) int {
+	minute, _ := period.StartMinute()
+	return minute
+}
+
+func (s *thermostatService) fail(status domain.ThermostatStatus, err error) error {
+	s.mu.Lock()
+	if s.acEnabled != nil {
+		status.ACEnabled = *s.acEnabled
+	}
+	if !s.lastSwitch.IsZero() {
+		lastSwitch := s.lastSwitch
+		status.LastSwitchAt = &lastSwitch
+	}
+	s.mu.Unlock()
+
+	status.Decision = domain.ThermostatError
+	status.Reason = err.Error()
+	s.setStatus(status)
+	return err
+}
+
+func (s *thermostatService) setStatus(status domain.ThermostatStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status = status
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	acService "github.com/pklimuk-eng-thesis/control-station/pkg/service/ac"
+	analogSensorService "github.com/pklimuk-eng-thesis/control-station/pkg/service/analog"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testConfig = domain.ThermostatConfig{
+	Sensor:         "temperatureSensor",
+	AC:             "ac",
+	ACCapabilities: domain.ExtendedACCapabilities(),
+	Mode:           domain.ACModeCool,
+	Target:         24,
+	Hysteresis:     0.5,
+	MinOnTime:      3 * time.Minute,
+	MinOffTime:     5 * time.Minute,
+}
+
+var testNow = time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestThermostat(config domain.ThermostatConfig, sensor *analogSensorService.MockAnalogSensorService,
+	ac *acService.MockACService) *thermostatService {
+	service := NewThermostatService(config, sensor, ac).(*thermostatService)
+	service.now = func() time.Time { return testNow }
+	return service
+}
+
+// settle lets the service know the AC has not been switched for an hour, as if
+// it had been observing it for a while.
+func settle(service *thermostatService, enabled bool) {
+	service.acEnabled = &enabled
+	service.lastSwitch = testNow.Add(-time.Hour)
+}
+
+func sensorReading(value float32) *analogSensorService.MockAnalogSensorService {
+	sensor := new(analogSensorService.MockAnalogSensorService)
+	sensor.EXPECT().GetInfo().Return(domain.AnalogSensorInfo{Enabled: true, Value: value}, nil)
+	return sensor
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         domain.ACMode
+		temperature  float32
+		acInfo       domain.ACInfo
+		wantDecision domain.ThermostatDecision
+		wantUpdate   bool
+		wantToggle   bool
+	}{
+		{name: "CoolTurnOn", mode: domain.ACModeCool, temperature: 25, acInfo: domain.ACInfo{Humidity: 50},
+			wantDecision: domain.ThermostatTurnOn, wantUpdate: true},
+		{name: "CoolTurnOff", mode: domain.ACModeCool, temperature: 23,
+			acInfo:       domain.ACInfo{Enabled: true, Temperature: 24, Mode: domain.ACModeCool},
+			wantDecision: domain.ThermostatTurnOff, wantToggle: true},
+		{name: "CoolWithinBandOn", mode: domain.ACModeCool, temperature: 23.8,
+			acInfo:       domain.ACInfo{Enabled: true, Temperature: 24, Mode: domain.ACModeCool},
+			wantDecision: domain.ThermostatStayOn},
+		{name: "CoolWithinBandOff", mode: domain.ACModeCool, temperature: 24.4,
+			wantDecision: domain.ThermostatStayOff},
+		{name: "CoolAdjustSetpoint", mode: domain.ACModeCool, temperature: 26,
+			acInfo:       domain.ACInfo{Enabled: true, Temperature: 20, Mode: domain.ACModeCool},
+			wantDecision: domain.ThermostatStayOn, wantUpdate: true},
+		{name: "HeatTurnOn", mode: domain.ACModeHeat, temperature: 23, wantDecision: domain.ThermostatTurnOn,
+			wantUpdate: true},
+		{name: "HeatStayOff", mode: domain.ACModeHeat, temperature: 26, wantDecision: domain.ThermostatStayOff},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ac := new(acService.MockACService)
+			ac.EXPECT().GetInfo().Return(test.acInfo, nil)
+			ac.EXPECT().UpdateACSettings(mock.Anything).RunAndReturn(func(settings domain.ACInfo) (domain.ACInfo, error) {
+				return settings, nil
+			}).Maybe()
+			ac.EXPECT().ToggleEnabled(mock.Anything).Return(domain.ACInfo{Enabled: !test.acInfo.Enabled}, nil).Maybe()
+			config := testConfig
+			config.Mode = test.mode
+
+			service := newTestThermostat(config, sensorReading(test.temperature), ac)
+			settle(service, test.acInfo.Enabled)
+			err := service.Evaluate()
+
+			assert.NoError(t, err)
+			got := service.GetStatus()
+			assert.Equal(t, test.wantDecision, got.Decision)
+			assert.Equal(t, test.temperature, *got.Temperature)
+			assert.NotEmpty(t, got.Reason)
+			if test.wantUpdate {
+				ac.AssertCalled(t, "UpdateACSettings", domain.ACInfo{Enabled: true, Temperature: 24, Mode: test.mode,
+					Humidity: test.acInfo.Humidity})
+			} else {
+				ac.AssertNotCalled(t, "UpdateACSettings", mock.Anything)
+			}
+			if test.wantToggle {
+				ac.AssertCalled(t, "ToggleEnabled", mock.Anything)
+			} else {
+				ac.AssertNotCalled(t, "ToggleEnabled", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestEvaluate_ACWithoutModes(t *testing.T) {
+	config := testConfig
+	config.ACCapabilities = domain.DefaultACCapabilities()
+	ac := new(acService.MockACService)
+	ac.EXPECT().GetInfo().Return(domain.ACInfo{Humidity: 50}, nil).Once()
+	ac.EXPECT().UpdateACSettings(domain.ACInfo{Enabled: true, Temperature: 24, Humidity: 50}).
+		Return(domain.ACInfo{Enabled: true, Temperature: 24, Humidity: 50}, nil).Once()
+
+	service := newTestThermostat(config, sensorReading(26), ac)
+	settle(service, false)
+	assert.NoError(t, service.Evaluate())
+	assert.Equal(t, domain.ThermostatTurnOn, service.GetStatus().Decision)
+
+	ac.EXPECT().GetInfo().Return(domain.ACInfo{Enabled: true, Temperature: 24, Humidity: 50}, nil)
+	assert.NoError(t, service.Evaluate())
+	assert.Equal(t, domain.ThermostatStayOn, service.GetStatus().Decision)
+	ac.AssertNumberOfCalls(t, "UpdateACSettings", 1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.NoError(t, ValidateConfig(testConfig))
+
+	config := testConfig
+	config.ACCapabilities = domain.DefaultACCapabilities()
+	assert.NoError(t, ValidateConfig(config), "an AC without modes is controlled without sending the mode")
+
+	config = testConfig
+	config.ACCapabilities.Modes = []domain.ACMode{domain.ACModeHeat}
+	config.Target = 35
+	config.Schedule = []domain.ThermostatPeriod{{Start: "22:00", Target: 20.2}}
+	err := ValidateConfig(config)
+	var validationErr *controlStationUtils.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{"mode", "target", "schedule[0].target"}, []string{validationErr.Errors[0].Field,
+		validationErr.Errors[1].Field, validationErr.Errors[2].Field})
+}
+
+func TestEvaluate_TurnOnWithKeepPolicy(t *testing.T) {
+	ac := new(acService.MockACService)
+	ac.EXPECT().GetInfo().Return(domain.ACInfo{}, nil)
+	ac.EXPECT().UpdateACSettings(mock.Anything).Return(domain.ACInfo{Temperature: 24}, nil)
+	ac.EXPECT().ToggleEnabled(mock.Anything).Return(domain.ACInfo{Enabled: true, Temperature: 24}, nil)
+
+	service := newTestThermostat(testConfig, sensorReading(26), ac)
+	settle(service, false)
+	err := service.Evaluate()
+
+	assert.NoError(t, err)
+	assert.True(t, service.GetStatus().ACEnabled)
+	ac.AssertCalled(t, "ToggleEnabled", mock.Anything)
+}
+
+func TestEvaluate_MinimumTimes(t *testing.T) {
+	ac := new(acService.MockACService)
+	acInfo := domain.ACInfo{Enabled: true, Temperature: 24, Mode: domain.ACModeCool}
+	ac.EXPECT().GetInfo().RunAndReturn(func() (domain.ACInfo, error) { return acInfo, nil })
+	ac.EXPECT().ToggleEnabled(mock.Anything).RunAndReturn(func(ctx context.Context) (domain.ACInfo, error) {
+		acInfo.Enabled = !acInfo.Enabled
+		return acInfo, nil
+	})
+	ac.EXPECT().UpdateACSettings(mock.Anything).RunAndReturn(func(settings domain.ACInfo) (domain.ACInfo, error) {
+		acInfo = settings
+		return acInfo, nil
+	})
+	temperature := float32(23)
+	sensor := new(analogSensorService.MockAnalogSensorService)
+	sensor.EXPECT().GetInfo().RunAndReturn(func() (domain.AnalogSensorInfo, error) {
+		return domain.AnalogSensorInfo{Enabled: true, Value: temperature}, nil
+	})
+	service := newTestThermostat(testConfig, sensor, ac)
+	settle(service, true)
+	now := testNow
+	service.now = func() time.Time { return now }
+
+	assert.NoError(t, service.Evaluate())
+	assert.Equal(t, domain.ThermostatTurnOff, service.GetStatus().Decision)
+
+	temperature = 26
+	now = testNow.Add(time.Minute)
+	assert.NoError(t, service.Evaluate())
+	got := service.GetStatus()
+	assert.Equal(t, domain.ThermostatHold, got.Decision)
+	assert.Equal(t, testNow.Add(testConfig.MinOffTime), *got.NextSwitchAt)
+	assert.False(t, got.ACEnabled)
+
+	now = testNow.Add(testConfig.MinOffTime)
+	assert.NoError(t, service.Evaluate())
+	assert.Equal(t, domain.ThermostatTurnOn, service.GetStatus().Decision)
+
+	temperature = 23
+	now = now.Add(time.Minute)
+	assert.NoError(t, service.Evaluate())
+	assert.Equal(t, domain.ThermostatHold, service.GetStatus().Decision)
+	assert.True(t, service.GetStatus().ACEnabled)
+}
+
+func TestEvaluate_HoldsAfterRestart(t *testing.T) {
+	ac := new(acService.MockACService)
+	ac.EXPECT().GetInfo().Return(domain.ACInfo{}, nil)
+
+	service := newTestThermostat(testConfig, sensorReading(26), ac)
+	err := service.Evaluate()
+
+	assert.NoError(t, err)
+	got := service.GetStatus()
+	assert.Equal(t, domain.ThermostatHold, got.Decision)
+	assert.Equal(t, testNow.Add(testConfig.MinOffTime), *got.NextSwitchAt)
+	ac.AssertNotCalled(t, "UpdateACSettings", mock.Anything)
+}
+
+func TestEvaluate_Schedule(t *testing.T) {
+	config := testConfig
+	config.Schedule = []domain.ThermostatPeriod{{Start: "23:00", Target: 20}, {Start: "07:00", Target: 22}}
+
+	tests := []struct {
+		name       string
+		hour       int
+		wantTarget float32
+		wantPeriod string
+	}{
+		{name: "Night", hour: 3, wantTarget: 20, wantPeriod: "23:00"},
+		{name: "Day", hour: 12, wantTarget: 22, wantPeriod: "07:00"},
+		{name: "Evening", hour: 23, wantTarget: 20, wantPeriod: "23:00"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := NewThermostatService(config, nil, nil).(*thermostatService)
+
+			target, period := service.target(time.Date(2023, 1, 1, test.hour, 0, 0, 0, time.UTC))
+
+			assert.Equal(t, test.wantTarget, target)
+			assert.Equal(t, test.wantPeriod, period)
+		})
+	}
+}
+
+func TestEvaluate_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		sensor func() *analogSensorService.MockAnalogSensorService
+		ac     func() *acService.MockACService
+	}{
+		{
+			name: "SensorUnreachable",
+			sensor: func() *analogSensorService.MockAnalogSensorService {
+				sensor := new(analogSensorService.MockAnalogSensorService)
+				sensor.EXPECT().GetInfo().Return(domain.AnalogSensorInfo{}, errors.New("unreachable"))
+				return sensor
+			},
+			ac: func() *acService.MockACService { return new(acService.MockACService) },
+		},
+		{
+			name: "SensorDisabled",
+			sensor: func() *analogSensorService.MockAnalogSensorService {
+				sensor := new(analogSensorService.MockAnalogSensorService)
+				sensor.EXPECT().GetInfo().Return(domain.AnalogSensorInfo{Enabled: false, Value: 30}, nil)
+				return sensor
+			},
+			ac: func() *acService.MockACService { return new(acService.MockACService) },
+		},
+		{
+			name:   "ACFailure",
+			sensor: func() *analogSensorService.MockAnalogSensorService { return sensorReading(30) },
+			ac: func() *acService.MockACService {
+				ac := new(acService.MockACService)
+				ac.EXPECT().GetInfo().Return(domain.ACInfo{Humidity: 50}, nil)
+				ac.EXPECT().UpdateACSettings(mock.Anything).Return(domain.ACInfo{}, errors.New("interlocked"))
+				return ac
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ac := test.ac()
+			service := newTestThermostat(testConfig, test.sensor(), ac)
+			settle(service, false)
+
+			err := service.Evaluate()
+
+			assert.Error(t, err)
+			got := service.GetStatus()
+			assert.Equal(t, domain.ThermostatError, got.Decision)
+			assert.Equal(t, err.Error(), got.Reason)
+			ac.AssertNotCalled(t, "ToggleEnabled", mock.Anything)
+		})
+	}
+}
+
+func TestGetStatus_Pending(t *testing.T) {
+	service := NewThermostatService(testConfig, nil, nil)
+
+	got := service.GetStatus()
+
+	assert.Equal(t, domain.ThermostatPending, got.Decision)
+	assert.Nil(t, got.EvaluatedAt)
+}
@@ -0,0 +1,88 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+package service
+
+import (
+	domain "github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTimelineService is an autogenerated mock type for the TimelineService type
+type MockTimelineService struct {
+	mock.Mock
+}
+
+type MockTimelineService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTimelineService) EXPECT() *MockTimelineService_Expecter {
+	return &MockTimelineService_Expecter{mock: &_m.Mock}
+}
+
+// GetTimeline provides a mock function with given fields: query
+func (_m *MockTimelineService) GetTimeline(query domain.TimelineQuery) (domain.Timeline, error) {
+	ret := _m.Called(query)
+
+	var r0 domain.Timeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.TimelineQuery) (domain.Timeline, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(domain.TimelineQuery) domain.Timeline); ok {
+		r0 = rf(query)
+	} else {
+		r0 = ret.Get(0).(domain.Timeline)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.TimelineQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTimelineService_GetTimeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTimeline'
+type MockTimelineService_GetTimeline_Call struct {
+	*mock.Call
+}
+
+// GetTimeline is a helper method to define mock.On call
+//   - query domain.TimelineQuery
+func (_e *MockTimelineService_Expecter) GetTimeline(query interface{}) *MockTimelineService_GetTimeline_Call {
+	return &MockTimelineService_GetTimeline_Call{Call: _e.mock.On("GetTimeline", query)}
+}
+
+func (_c *MockTimelineService_GetTimeline_Call) Run(run func(query domain.TimelineQuery)) *MockTimelineService_GetTimeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.TimelineQuery))
+	})
+	return _c
+}
+
+func (_c *MockTimelineService_GetTimeline_Call) Return(_a0 domain.Timeline, _a1 error) *MockTimelineService_GetTimeline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTimelineService_GetTimeline_Call) RunAndReturn(run func(domain.TimelineQuery) (domain.Timeline, error)) *MockTimelineService_GetTimeline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+type mockConstructorTestingTNewMockTimelineService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockTimelineService creates a new instance of MockTimelineService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockTimelineService(t mockConstructorTestingTNewMockTimelineService) *MockTimelineService {
+	mock := &MockTimelineService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/pklimuk-eng-thesis/control-station/utils"
+)
+
+//go:generate --name TimelineService --output mock_timelineService.go
+type TimelineService interface {
+	GetTimeline(query domain.TimelineQuery) (domain.Timeline, error)
+}
+
+type timelineService struct {
+	registry registry.Registry
+}
+
+func NewTimelineService(registry registry.Registry) TimelineService {
+	return &timelineService{registry: registry}
+}
+
+type deviceLogs struct {
+	device domain.RegisteredDevice
+	logs   []json.RawMessage
+	err    error
+}
+
+// GetTimeline fetches the logs of the devices concurrently and merges them by
+// their creation time, devices with logs of the same time ordered by name.
+// Each device contributes at most the limit, so the merged timeline holds the
+// first logs in the order across all devices. A device whose logs can not be
+// fetched is reported in the timeline, only when no device could be fetched
+// the timeline fails.
+func (s *timelineService) GetTimeline(query domain.TimelineQuery) (domain.Timeline, error) {
+	if query.Order == "" {
+		query.Order = domain.SortDesc
+	}
+	logQuery := domain.LogQuery{From: query.From, To: query.To, Order: query.Order, Limit: query.Limit}
+	if err := controlStationUtils.ValidateLogQuery(logQuery); err != nil {
+		return domain.Timeline{}, err
+	}
+
+	devices := s.devices(query.Kinds)
+	results := make([]deviceLogs, len(devices))
+	var wg sync.WaitGroup
+	for i, device := range devices {
+		wg.Add(1)
+		go func(result *deviceLogs, device domain.RegisteredDevice) {
+			defer wg.Done()
+			page, err := controlStationUtils.QueryLogsFromDataService[json.RawMessage](device.Name, logQuery)
+			*result = deviceLogs{device: device, logs: page.Logs, err: err}
+		}(&results[i], device)
+	}
+	wg.Wait()
+
+	timeline := domain.Timeline{Entries: []domain.TimelineEntry{}}
+	var firstErr error
+	for _, result := range results {
+		entries, err := timelineEntries(result)
+		if err != nil {
+			if timeline.Errors == nil {
+				timeline.Errors = map[string]string{}
+			}
+			timeline.Errors[result.device.Name] = err.Error()
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		timeline.Entries = append(timeline.Entries, entries...)
+	}
+	if len(timeline.Errors) > 0 && len(timeline.Errors) == len(devices) {
+		return domain.Timeline{}, firstErr
+	}
+
+	sort.SliceStable(timeline.Entries, func(i, j int) bool {
+		a, b := timeline.Entries[i], timeline.Entries[j]
+		if !a.Time.Equal(b.Time) {
+			return a.Time.Before(b.Time) == (query.Order == domain.SortAsc)
+		}
+		return a.Device < b.Device
+	})
+	if len(timeline.Entries) > query.Limit {
+		timeline.Entries = timeline.Entries[:query.Limit]
+	}
+	return timeline, nil
+}
+
+func (s *timelineService) devices(kinds []domain.DeviceKind) []domain.RegisteredDevice {
+	devices := s.registry.List()
+	if len(kinds) == 0 {
+		return devices
+	}
+
+	selected := make([]domain.RegisteredDevice, 0, len(devices))
+	for _, device := range devices {
+		for _, kind := range kinds {
+			if device.Kind == kind {
+				selected = append(selected, device)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+func timelineEntries(result deviceLogs) ([]domain.TimelineEntry, error) {
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	entries := make([]domain.TimelineEntry, 0, len(result.logs))
+	for _, raw := range result.logs {
+		var log struct {
+			CreatedAt time.Time `json:"created_at"`
+		}
+		if err := json.Unmarshal(raw, &log); err != nil {
+			return nil, fmt.Errorf("%w: %s", utils.ErrParsingFailed, err)
+		}
+		entries = append(entries, domain.TimelineEntry{Time: log.CreatedAt, Device: result.device.Name,
+			Kind: result.device.Kind, Log: raw})
+	}
+	return entries, nil
+}
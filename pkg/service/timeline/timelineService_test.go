@@ -0,0 +1,107 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/pkg/registry"
+	controlStationUtils "github.com/pklimuk-eng-thesis/control-station/pkg/service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	bulbLog     = `{"id": 5, "created_at": "2023-01-01T10:00:05Z", "is_enabled": true}`
+	presenceLog = `{"id": 9, "created_at": "2023-01-01T10:00:00Z", "is_enabled": true, "detected": true}`
+	doorsLog    = `{"id": 3, "created_at": "2023-01-01T10:00:05Z", "is_enabled": true, "detected": false}`
+)
+
+func newTestService(t *testing.T, failing ...string) TimelineService {
+	logs := map[string]string{
+		"smartBulb":      "[" + bulbLog + "]",
+		"presenceSensor": "[" + presenceLog + "]",
+		"doorsSensor":    "[" + doorsLog + "]",
+	}
+	for _, name := range failing {
+		delete(logs, name)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, deviceLogs := range logs {
+			if r.URL.Path == "/"+name+"/query" || r.URL.Path == "/"+name+"/latest" {
+				fmt.Fprintln(w, deviceLogs)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintln(w, "Database is down")
+	}))
+	t.Cleanup(ts.Close)
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	deviceRegistry := registry.NewRegistry()
+	deviceRegistry.Register(domain.RegisteredDevice{Name: "smartBulb", Kind: domain.KindLight})
+	deviceRegistry.Register(domain.RegisteredDevice{Name: "presenceSensor", Kind: domain.KindSensor})
+	deviceRegistry.Register(domain.RegisteredDevice{Name: "doorsSensor", Kind: domain.KindSensor})
+	return NewTimelineService(deviceRegistry)
+}
+
+func entry(hour, minute, second int, device string, kind domain.DeviceKind, log string) domain.TimelineEntry {
+	return domain.TimelineEntry{Time: time.Date(2023, 1, 1, hour, minute, second, 0, time.UTC), Device: device,
+		Kind: kind, Log: json.RawMessage(log)}
+}
+
+func TestGetTimeline(t *testing.T) {
+	service := newTestService(t)
+
+	timeline, err := service.GetTimeline(domain.TimelineQuery{Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Timeline{Entries: []domain.TimelineEntry{
+		entry(10, 0, 5, "doorsSensor", domain.KindSensor, doorsLog),
+		entry(10, 0, 5, "smartBulb", domain.KindLight, bulbLog),
+	}}, timeline)
+}
+
+func TestGetTimeline_KindsAscending(t *testing.T) {
+	service := newTestService(t)
+
+	timeline, err := service.GetTimeline(domain.TimelineQuery{Kinds: []domain.DeviceKind{domain.KindSensor},
+		Order: domain.SortAsc, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Timeline{Entries: []domain.TimelineEntry{
+		entry(10, 0, 0, "presenceSensor", domain.KindSensor, presenceLog),
+		entry(10, 0, 5, "doorsSensor", domain.KindSensor, doorsLog),
+	}}, timeline)
+}
+
+func TestGetTimeline_DeviceFailure(t *testing.T) {
+	service := newTestService(t, "smartBulb")
+
+	timeline, err := service.GetTimeline(domain.TimelineQuery{Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Len(t, timeline.Entries, 2)
+	assert.Equal(t, map[string]string{"smartBulb": "smartBulb: Database is down"}, timeline.Errors)
+}
+
+func TestGetTimeline_Failure(t *testing.T) {
+	service := newTestService(t, "smartBulb")
+
+	_, err := service.GetTimeline(domain.TimelineQuery{Kinds: []domain.DeviceKind{domain.KindLight}, Limit: 10})
+
+	assert.Equal(t, controlStationUtils.CodeDataServiceUnavailable, controlStationUtils.ErrorCodeOf(err))
+}
+
+func TestGetTimeline_InvalidQuery(t *testing.T) {
+	service := newTestService(t)
+
+	_, err := service.GetTimeline(domain.TimelineQuery{Order: "sideways", Limit: 10})
+
+	var validationErr *controlStationUtils.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
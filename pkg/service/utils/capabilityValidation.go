@@ -0,0 +1,112 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+)
+
+// The validators below check the requests of a capability against what the
+// device supports. They are shared by the services of the device kinds and the
+// generic device service, so that a capability is validated the same way
+// whichever stack serves it.
+
+func ValidateBrightness(validationErr *ValidationError, request domain.LightBrightnessRequest, capabilities domain.LightCapabilities) {
+	if !capabilities.Dimmable {
+		validationErr.Add("brightness", "is not supported")
+	} else {
+		validationErr.AddRangeErrors("brightness", float32(request.Brightness), 0, 100, 1)
+	}
+	ValidateTransition(validationErr, request.TransitionMs, capabilities)
+}
+
+func ValidateColor(validationErr *ValidationError, request domain.LightColorRequest, capabilities domain.LightCapabilities) {
+	colorsSet := 0
+	if request.ColorTemperature != nil {
+		colorsSet++
+		if !capabilities.SupportsColorTemperature() {
+			validationErr.Add("color_temperature", "is not supported")
+		} else {
+			validationErr.AddRangeErrors("color_temperature", float32(*request.ColorTemperature),
+				float32(capabilities.MinColorTemperature), float32(capabilities.MaxColorTemperature), 1)
+		}
+	}
+	if request.RGB != nil {
+		colorsSet++
+		if !capabilities.SupportsRGB {
+			validationErr.Add("rgb", "is not supported")
+		} else {
+			validationErr.AddRangeErrors("rgb.red", float32(request.RGB.Red), 0, 255, 1)
+			validationErr.AddRangeErrors("rgb.green", float32(request.RGB.Green), 0, 255, 1)
+			validationErr.AddRangeErrors("rgb.blue", float32(request.RGB.Blue), 0, 255, 1)
+		}
+	}
+	if request.HSV != nil {
+		colorsSet++
+		if !capabilities.SupportsHSV {
+			validationErr.Add("hsv", "is not supported")
+		} else {
+			validationErr.AddRangeErrors("hsv.hue", request.HSV.Hue, 0, 360, 0)
+			validationErr.AddRangeErrors("hsv.saturation", request.HSV.Saturation, 0, 100, 0)
+			validationErr.AddRangeErrors("hsv.value", request.HSV.Value, 0, 100, 0)
+		}
+	}
+	if colorsSet != 1 {
+		validationErr.Add("color", "exactly one of color_temperature, rgb or hsv must be set")
+	}
+	ValidateTransition(validationErr, request.TransitionMs, capabilities)
+}
+
+func ValidateTransition(validationErr *ValidationError, transitionMs int, capabilities domain.LightCapabilities) {
+	if transitionMs == 0 {
+		return
+	}
+	if capabilities.MaxTransitionMs == 0 {
+		validationErr.Add("transition_ms", "is not supported")
+		return
+	}
+	validationErr.AddRangeErrors("transition_ms", float32(transitionMs), 0, float32(capabilities.MaxTransitionMs), 1)
+}
+
+func ValidateACSettings(validationErr *ValidationError, settings domain.ACInfo, capabilities domain.ACCapabilities) {
+	validationErr.AddRangeErrors("temperature", settings.Temperature,
+		capabilities.MinTemperature, capabilities.MaxTemperature, capabilities.TemperatureStep)
+	validationErr.AddRangeErrors("humidity", settings.Humidity,
+		capabilities.MinHumidity, capabilities.MaxHumidity, capabilities.HumidityStep)
+
+	if settings.Mode != "" {
+		if len(capabilities.Modes) == 0 {
+			validationErr.Add("mode", "is not supported")
+		} else if !capabilities.SupportsMode(settings.Mode) {
+			validationErr.Add("mode", fmt.Sprintf("must be one of %v", capabilities.Modes))
+		}
+	}
+	if settings.FanSpeed != 0 {
+		if capabilities.FanSpeeds == 0 {
+			validationErr.Add("fan_speed", "is not supported")
+		} else if settings.FanSpeed < 1 || settings.FanSpeed > capabilities.FanSpeeds {
+			validationErr.Add("fan_speed", fmt.Sprintf("must be between 1 and %d", capabilities.FanSpeeds))
+		}
+	}
+	if settings.Swing != nil && !capabilities.SupportsSwing {
+		validationErr.Add("swing", "is not supported")
+	}
+	if settings.Eco != nil && !capabilities.SupportsEco {
+		validationErr.Add("eco", "is not supported")
+	}
+	if settings.Turbo != nil && !capabilities.SupportsTurbo {
+		validationErr.Add("turbo", "is not supported")
+	}
+	if settings.Eco != nil && *settings.Eco && settings.Turbo != nil && *settings.Turbo {
+		validationErr.Add("turbo", "cannot be enabled together with eco")
+	}
+}
+
+func ValidatePosition(validationErr *ValidationError, request domain.CoverPositionRequest) {
+	if request.Position == nil {
+		validationErr.Add("position", "is required")
+	} else if *request.Position < domain.CoverPositionClosed || *request.Position > domain.CoverPositionOpen {
+		validationErr.Add("position", fmt.Sprintf("must be between %d and %d",
+			domain.CoverPositionClosed, domain.CoverPositionOpen))
+	}
+}
@@ -2,12 +2,15 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
 	"github.com/pklimuk-eng-thesis/control-station/utils"
@@ -17,38 +20,121 @@ const InfoEndpoint = "/info"
 const EnabledEndpoint = "/enabled"
 const DetectedEndpoint = "/detected"
 const UpdateEndpoint = "/update"
+const BrightnessEndpoint = "/brightness"
+const ColorEndpoint = "/color"
+const CapabilitiesEndpoint = "/capabilities"
+const LockEndpoint = "/lock"
+const UnlockEndpoint = "/unlock"
+const OpenEndpoint = "/open"
+const CloseEndpoint = "/close"
+const StopEndpoint = "/stop"
+const PositionEndpoint = "/position"
 
-type SmartHomeDeviceInfo interface {
-	domain.SensorInfo | domain.DeviceInfo | domain.ACInfo
+// capabilityEndpoints lists the device endpoints that change the state covered
+// by a capability, the first being the one the capability is invoked through
+// by default. Read-only capabilities have no endpoints.
+var capabilityEndpoints = map[domain.Capability][]string{
+	domain.CapabilitySwitchable:   {EnabledEndpoint},
+	domain.CapabilityDetectable:   {DetectedEndpoint},
+	domain.CapabilityThermostat:   {UpdateEndpoint},
+	domain.CapabilityDimmable:     {BrightnessEndpoint},
+	domain.CapabilityColor:        {ColorEndpoint},
+	domain.CapabilityLockable:     {LockEndpoint, UnlockEndpoint},
+	domain.CapabilityPositionable: {PositionEndpoint, OpenEndpoint, CloseEndpoint, StopEndpoint},
 }
 
-type SmartHomeDeviceData interface {
-	domain.SensorData | domain.DeviceData | domain.ACData
+// confirmedEndpoints are invoked only with the token issued for an earlier
+// request of the same requester.
+var confirmedEndpoints = map[string]bool{
+	UnlockEndpoint: true,
 }
 
-func MakeGetRequest[V SmartHomeDeviceInfo](address string, deviceName string, defaultValueOnError V) (V, error) {
-	resp, err := http.Get(address)
-	if err != nil {
-		return defaultValueOnError, err
-	}
-	defer resp.Body.Close()
+// auditedCapabilities are refused any command that can not be audited.
+var auditedCapabilities = map[domain.Capability]bool{
+	domain.CapabilityLockable: true,
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return defaultValueOnError, utils.ErrParsingFailed
+func CapabilityEndpoints(capability domain.Capability) []string {
+	return capabilityEndpoints[capability]
+}
+
+// CapabilityEndpoint returns the endpoint the capability is invoked through by
+// default.
+func CapabilityEndpoint(capability domain.Capability) (string, bool) {
+	endpoints := capabilityEndpoints[capability]
+	if len(endpoints) == 0 {
+		return "", false
 	}
+	return endpoints[0], true
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return defaultValueOnError, fmt.Errorf("%s: %s", deviceName, string(body))
+func HasCapabilityEndpoint(capability domain.Capability, endpoint string) bool {
+	for _, e := range capabilityEndpoints[capability] {
+		if e == endpoint {
+			return true
+		}
 	}
+	return false
+}
+
+func RequiresConfirmation(endpoint string) bool {
+	return confirmedEndpoints[endpoint]
+}
+
+func IsAudited(capability domain.Capability) bool {
+	return auditedCapabilities[capability]
+}
+
+const DefaultDeviceRequestTimeout = 10 * time.Second
+
+var deviceClientMu sync.RWMutex
+var deviceClient = &http.Client{Timeout: DefaultDeviceRequestTimeout}
+
+// SetDeviceRequestTimeout bounds every request to a device, so that a device
+// that does not respond can not hold up its caller indefinitely.
+func SetDeviceRequestTimeout(timeout time.Duration) {
+	deviceClientMu.Lock()
+	defer deviceClientMu.Unlock()
 
-	var deviceInfo V
-	err = json.Unmarshal(body, &deviceInfo)
+	deviceClient = &http.Client{Timeout: timeout}
+}
+
+func deviceHTTPClient() *http.Client {
+	deviceClientMu.RLock()
+	defer deviceClientMu.RUnlock()
+
+	return deviceClient
+}
+
+const DefaultDataServiceRequestTimeout = 10 * time.Second
+
+var dataServiceClientMu sync.RWMutex
+var dataServiceClient = &http.Client{Timeout: DefaultDataServiceRequestTimeout}
+
+// SetDataServiceRequestTimeout bounds every request to the data service, so
+// that logging can not hold up its caller indefinitely when the data service
+// does not respond.
+func SetDataServiceRequestTimeout(timeout time.Duration) {
+	dataServiceClientMu.Lock()
+	defer dataServiceClientMu.Unlock()
+
+	dataServiceClient = &http.Client{Timeout: timeout}
+}
+
+func dataServiceHTTPClient() *http.Client {
+	dataServiceClientMu.RLock()
+	defer dataServiceClientMu.RUnlock()
+
+	return dataServiceClient
+}
+
+func MakeGetRequest[V any](address string, deviceName string, defaultValueOnError V) (V, error) {
+	deviceInfo, err := FetchJSON[V](address, deviceName)
 	if err != nil {
-		return defaultValueOnError, utils.ErrParsingFailed
+		return defaultValueOnError, err
 	}
 
-	err = sendLogsToDataService(deviceName, deviceInfo)
+	err = SendLogsToDataService(deviceName, deviceInfo)
 	if err != nil {
 		errStr := fmt.Sprintf("Failed to send '%s' logs to data service: %s", deviceName, err)
 		log.Println(errStr)
@@ -57,44 +143,91 @@ func MakeGetRequest[V SmartHomeDeviceInfo](address string, deviceName string, de
 	return deviceInfo, nil
 }
 
-func MakePatchRequest[V SmartHomeDeviceInfo](address string, deviceName string, reqBody *V, defaultValueOnError V) (V, error) {
-	var encodedReqBody io.Reader = nil
-	if reqBody != nil {
-		jsonBody, err := json.Marshal(reqBody)
-		if err != nil {
-			return defaultValueOnError, err
-		}
-		encodedReqBody = bytes.NewBuffer(jsonBody)
-	}
+// FetchJSON reads a JSON document from a device without logging it to the data
+// service, e.g. for metadata that is not part of the device state.
+func FetchJSON[V any](address string, deviceName string) (V, error) {
+	return FetchJSONContext[V](context.Background(), address, deviceName)
+}
 
-	req, err := http.NewRequest(http.MethodPatch, address, encodedReqBody)
+// FetchJSONContext is FetchJSON bound to a context, so that a caller that
+// stops waiting also aborts the request.
+func FetchJSONContext[V any](ctx context.Context, address string, deviceName string) (V, error) {
+	var value V
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
 	if err != nil {
-		return defaultValueOnError, err
+		return value, err
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := deviceHTTPClient().Do(req)
 	if err != nil {
-		return defaultValueOnError, err
+		return value, deviceRequestError(deviceName, err)
 	}
 	defer resp.Body.Close()
 
+	return decodeResponse[V](resp, deviceName, CodeDeviceError)
+}
+
+// decodeResponse reads the JSON body of an upstream response. A status other
+// than 200 is reported with the given code, a body that can not be decoded as
+// an invalid response, both keeping the upstream status and body.
+func decodeResponse[V any](resp *http.Response, name string, statusCode ErrorCode) (V, error) {
+	var value V
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return defaultValueOnError, utils.ErrParsingFailed
+		return value, NewServiceError(CodeInvalidResponse,
+			newUpstreamError(name, resp.StatusCode, nil, fmt.Errorf("%w: %w", utils.ErrParsingFailed, err)))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return defaultValueOnError, fmt.Errorf("%s: %s", deviceName, string(body))
+		return value, NewServiceError(statusCode, newUpstreamError(name, resp.StatusCode, body, nil))
+	}
+
+	err = json.Unmarshal(body, &value)
+	if err != nil {
+		return value, NewServiceError(CodeInvalidResponse,
+			newUpstreamError(name, resp.StatusCode, body, fmt.Errorf("%w: %w", utils.ErrParsingFailed, err)))
 	}
 
-	var deviceInfo V
-	err = json.Unmarshal(body, &deviceInfo)
+	return value, nil
+}
+
+func MakePatchRequest[V any](address string, deviceName string, reqBody *V, defaultValueOnError V) (V, error) {
+	return MakePatchRequestContext(context.Background(), address, deviceName, reqBody, defaultValueOnError)
+}
+
+// MakePatchRequestContext is MakePatchRequest bound to a context, so that a
+// caller that stops waiting also aborts the request.
+func MakePatchRequestContext[V any](ctx context.Context, address string, deviceName string, reqBody *V,
+	defaultValueOnError V) (V, error) {
+	if reqBody == nil {
+		return makePatchRequest(ctx, address, deviceName, nil, defaultValueOnError)
+	}
+	return MakePatchRequestWithBodyContext(ctx, address, deviceName, reqBody, defaultValueOnError)
+}
+
+func MakePatchRequestWithBody[V any](address string, deviceName string, reqBody any, defaultValueOnError V) (V, error) {
+	return MakePatchRequestWithBodyContext(context.Background(), address, deviceName, reqBody, defaultValueOnError)
+}
+
+// MakePatchRequestWithBodyContext is MakePatchRequestWithBody bound to a
+// context.
+func MakePatchRequestWithBodyContext[V any](ctx context.Context, address string, deviceName string, reqBody any,
+	defaultValueOnError V) (V, error) {
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return defaultValueOnError, err
+	}
+	return makePatchRequest(ctx, address, deviceName, bytes.NewBuffer(jsonBody), defaultValueOnError)
+}
+
+func makePatchRequest[V any](ctx context.Context, address string, deviceName string, encodedReqBody io.Reader,
+	defaultValueOnError V) (V, error) {
+	deviceInfo, err := patchJSON[V](ctx, address, deviceName, encodedReqBody)
 	if err != nil {
-		return defaultValueOnError, utils.ErrParsingFailed
+		return defaultValueOnError, err
 	}
 
-	err = sendLogsToDataService(deviceName, deviceInfo)
+	err = SendLogsToDataService(deviceName, deviceInfo)
 	if err != nil {
 		errStr := fmt.Sprintf("Failed to send '%s' logs to data service: %s", deviceName, err)
 		log.Println(errStr)
@@ -103,34 +236,55 @@ func MakePatchRequest[V SmartHomeDeviceInfo](address string, deviceName string,
 	return deviceInfo, nil
 }
 
-func GetLogsFromDataServiceLimitN[K SmartHomeDeviceData](deviceName string, limit int) ([]K, error) {
-	dataServiceAddress := utils.GetEnvVariableOrDefault("DATA_SERVICE_ADDRESS", "http://localhost:8087")
-	url := fmt.Sprintf("%s/%s/latest?limit=%d", dataServiceAddress, deviceName, limit)
-	resp, err := http.Get(url)
+// PatchJSON sends a PATCH request to a device without logging the response to
+// the data service, for device kinds that post-process the device state first.
+func PatchJSON[V any](address string, deviceName string, reqBody any) (V, error) {
+	return PatchJSONContext[V](context.Background(), address, deviceName, reqBody)
+}
+
+// PatchJSONContext is PatchJSON bound to a context.
+func PatchJSONContext[V any](ctx context.Context, address string, deviceName string, reqBody any) (V, error) {
+	if reqBody == nil {
+		return patchJSON[V](ctx, address, deviceName, nil)
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
-		return nil, err
+		var value V
+		return value, err
 	}
-	defer resp.Body.Close()
+	return patchJSON[V](ctx, address, deviceName, bytes.NewBuffer(jsonBody))
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
+func patchJSON[V any](ctx context.Context, address string, deviceName string, encodedReqBody io.Reader) (V, error) {
+	var value V
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, address, encodedReqBody)
 	if err != nil {
-		return nil, utils.ErrParsingFailed
+		return value, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%s: %s", deviceName, string(body))
+	resp, err := deviceHTTPClient().Do(req)
+	if err != nil {
+		return value, deviceRequestError(deviceName, err)
 	}
+	defer resp.Body.Close()
+
+	return decodeResponse[V](resp, deviceName, CodeDeviceError)
+}
 
-	var deviceData []K
-	err = json.Unmarshal(body, &deviceData)
+func GetLogsFromDataServiceLimitN[K any](deviceName string, limit int) ([]K, error) {
+	dataServiceAddress := utils.GetEnvVariableOrDefault("DATA_SERVICE_ADDRESS", "http://localhost:8087")
+	url := fmt.Sprintf("%s/%s/latest?limit=%d", dataServiceAddress, deviceName, limit)
+	resp, err := dataServiceHTTPClient().Get(url)
 	if err != nil {
-		return nil, utils.ErrParsingFailed
+		return nil, NewServiceError(CodeDataServiceUnavailable, newUpstreamError(deviceName, 0, nil, err))
 	}
+	defer resp.Body.Close()
 
-	return deviceData, nil
+	return decodeResponse[[]K](resp, deviceName, CodeDataServiceUnavailable)
 }
 
-func sendLogsToDataService[V SmartHomeDeviceInfo](deviceName string, deviceInfo V) error {
+func SendLogsToDataService[V any](deviceName string, deviceInfo V) error {
 	dataServiceAddress := utils.GetEnvVariableOrDefault("DATA_SERVICE_ADDRESS", "http://localhost:8087")
 	jsonValue, err := json.Marshal(deviceInfo)
 	if err != nil {
@@ -138,19 +292,20 @@ func sendLogsToDataService[V SmartHomeDeviceInfo](deviceName string, deviceInfo
 	}
 
 	url := fmt.Sprintf("%s/%s/add", dataServiceAddress, deviceName)
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonValue))
+	resp, err := dataServiceHTTPClient().Post(url, "application/json", bytes.NewBuffer(tagLog(deviceName, jsonValue)))
 	if err != nil {
-		return err
+		return NewServiceError(CodeDataServiceUnavailable, newUpstreamError(deviceName, 0, nil, err))
 	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return utils.ErrParsingFailed
+		return NewServiceError(CodeDataServiceUnavailable,
+			newUpstreamError(deviceName, resp.StatusCode, nil, fmt.Errorf("%w: %w", utils.ErrParsingFailed, err)))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%s: %s", deviceName, string(body))
+		return NewServiceError(CodeDataServiceUnavailable, newUpstreamError(deviceName, resp.StatusCode, body, nil))
 	}
 
 	return nil
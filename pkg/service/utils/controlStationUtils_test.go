@@ -173,6 +173,50 @@ func TestMakeGetRequestAC(t *testing.T) {
 	}
 }
 
+func TestMakeGetRequestAC_ExtendedFirmware(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, `{"enabled": true, "temperature": 20, "humidity": 50, "mode": "cool", "fan_speed": 2, "swing": false, "turbo": true}`)
+	}))
+	defer ts.Close()
+	swing := false
+	turbo := true
+
+	acInfo, err := MakeGetRequest(ts.URL, "test-ac", domain.ACInfo{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ACInfo{Enabled: true, Temperature: 20, Humidity: 50, Mode: domain.ACModeCool, FanSpeed: 2, Swing: &swing, Turbo: &turbo}, acInfo)
+}
+
+func TestSetDeviceRequestTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	SetDeviceRequestTimeout(10 * time.Millisecond)
+	defer SetDeviceRequestTimeout(DefaultDeviceRequestTimeout)
+
+	_, err := PatchJSON[domain.DeviceInfo](ts.URL, "test-device", nil)
+
+	assert.Equal(t, CodeDeviceTimeout, ErrorCodeOf(err))
+}
+
+func TestSetDataServiceRequestTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+	SetDataServiceRequestTimeout(10 * time.Millisecond)
+	defer SetDataServiceRequestTimeout(DefaultDataServiceRequestTimeout)
+
+	err := SendLogsToDataService("test-device", domain.DeviceInfo{})
+
+	assert.Equal(t, CodeDataServiceUnavailable, ErrorCodeOf(err))
+}
+
 func TestMakeGetRequestAC_FailureConnection(t *testing.T) {
 	acInfo, err := MakeGetRequest("http://localhost:1234",
 		"test-ac",
@@ -383,7 +427,7 @@ func TestSendLogsToDataService_Sensor(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			t.Setenv("DATA_SERVICE_ADDRESS", test.ts.URL)
-			err := sendLogsToDataService("test-sensor", test.sensorInfo)
+			err := SendLogsToDataService("test-sensor", test.sensorInfo)
 			assert.Equal(t, test.wantErr, err != nil)
 		})
 	}
@@ -418,7 +462,7 @@ func TestSendLogsToDataService_Device(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			t.Setenv("DATA_SERVICE_ADDRESS", test.ts.URL)
-			err := sendLogsToDataService("test-device", test.deviceInfo)
+			err := SendLogsToDataService("test-device", test.deviceInfo)
 			assert.Equal(t, test.wantErr, err != nil)
 		})
 	}
@@ -453,7 +497,7 @@ func TestSendLogsToDataService_AC(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			t.Setenv("DATA_SERVICE_ADDRESS", test.ts.URL)
-			err := sendLogsToDataService("test-ac", test.acInfo)
+			err := SendLogsToDataService("test-ac", test.acInfo)
 			assert.Equal(t, test.wantErr, err != nil)
 		})
 	}
@@ -623,3 +667,62 @@ func TestGetLogsFromDataServiceLimitN_AccessControl(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, `{"dimmable": true, "max_transition_ms": 1000}`)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", "http://localhost:1234")
+
+	capabilities, err := FetchJSON[domain.LightCapabilities](ts.URL, "test-light")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.LightCapabilities{Dimmable: true, MaxTransitionMs: 1000}, capabilities)
+}
+
+func TestMakePatchRequestWithBody(t *testing.T) {
+	var gotBody domain.LightBrightnessRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, `{"enabled": true, "brightness": 10}`)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	request := domain.LightBrightnessRequest{Brightness: 10, TransitionMs: 100}
+	lightInfo, err := MakePatchRequestWithBody(ts.URL, "test-light", &request, domain.LightInfo{})
+
+	brightness := 10
+	assert.NoError(t, err)
+	assert.Equal(t, domain.LightInfo{Enabled: true, Brightness: &brightness}, lightInfo)
+	assert.Equal(t, request, gotBody)
+}
+
+func TestPatchJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, `{"enabled": true, "value": 20.5, "unit": "°C"}`)
+	}))
+	defer ts.Close()
+
+	sensorInfo, err := PatchJSON[domain.AnalogSensorInfo](ts.URL, "test-sensor", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AnalogSensorInfo{Enabled: true, Value: 20.5, Unit: "°C"}, sensorInfo)
+}
+
+func TestPatchJSON_Failure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, "Sensor is disabled")
+	}))
+	defer ts.Close()
+
+	_, err := PatchJSON[domain.AnalogSensorInfo](ts.URL, "test-sensor", nil)
+
+	assert.EqualError(t, err, "test-sensor: Sensor is disabled")
+}
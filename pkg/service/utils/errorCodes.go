@@ -0,0 +1,72 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/pklimuk-eng-thesis/control-station/utils"
+)
+
+// ErrorCode identifies the kind of a failure, so that clients can branch on it
+// instead of matching error messages.
+type ErrorCode string
+
+const (
+	CodeDeviceUnreachable      ErrorCode = "DEVICE_UNREACHABLE"
+	CodeDeviceTimeout          ErrorCode = "DEVICE_TIMEOUT"
+	CodeDeviceError            ErrorCode = "DEVICE_ERROR"
+	CodeInvalidResponse        ErrorCode = "INVALID_RESPONSE"
+	CodeDataServiceUnavailable ErrorCode = "DATA_SERVICE_UNAVAILABLE"
+	CodeValidationFailed       ErrorCode = "VALIDATION_FAILED"
+	CodeInterlocked            ErrorCode = "INTERLOCKED"
+	CodeInMaintenance          ErrorCode = "IN_MAINTENANCE"
+	CodeOverridden             ErrorCode = "OVERRIDDEN"
+	CodeBadRequest             ErrorCode = "BAD_REQUEST"
+	CodeForbidden              ErrorCode = "FORBIDDEN"
+	CodeNotFound               ErrorCode = "NOT_FOUND"
+	CodeConflict               ErrorCode = "CONFLICT"
+	CodePreconditionFailed     ErrorCode = "PRECONDITION_FAILED"
+	CodeLocked                 ErrorCode = "LOCKED"
+	CodeTooManyRequests        ErrorCode = "TOO_MANY_REQUESTS"
+	CodeInternal               ErrorCode = "INTERNAL_ERROR"
+)
+
+// ServiceError attaches an error code to the error that caused it.
+type ServiceError struct {
+	Code ErrorCode
+	Err  error
+}
+
+func (e *ServiceError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ServiceError) Unwrap() error {
+	return e.Err
+}
+
+func NewServiceError(code ErrorCode, err error) error {
+	return &ServiceError{Code: code, Err: err}
+}
+
+// ErrorCodeOf returns the code of the error, falling back to the code of the
+// known sentinel errors it wraps and to CodeInternal for anything else.
+func ErrorCodeOf(err error) ErrorCode {
+	var serviceErr *ServiceError
+	var validationErr *ValidationError
+	switch {
+	case errors.As(err, &serviceErr):
+		return serviceErr.Code
+	case errors.As(err, &validationErr):
+		return CodeValidationFailed
+	case errors.Is(err, ErrInterlocked):
+		return CodeInterlocked
+	case errors.Is(err, ErrInMaintenance):
+		return CodeInMaintenance
+	case errors.Is(err, ErrOverridden):
+		return CodeOverridden
+	case errors.Is(err, utils.ErrParsingFailed):
+		return CodeInvalidResponse
+	default:
+		return CodeInternal
+	}
+}
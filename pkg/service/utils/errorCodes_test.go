@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchJSON_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantCode ErrorCode
+	}{
+		{name: "DeviceError", status: http.StatusInternalServerError, body: "failure", wantCode: CodeDeviceError},
+		{name: "InvalidResponse", status: http.StatusOK, body: "{", wantCode: CodeInvalidResponse},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+				w.Write([]byte(test.body))
+			}))
+			defer ts.Close()
+
+			_, err := FetchJSON[domain.PlugInfo](ts.URL, "smartPlug")
+
+			assert.Equal(t, test.wantCode, ErrorCodeOf(err))
+		})
+	}
+}
+
+func TestFetchJSON_Unreachable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+
+	_, err := FetchJSON[domain.PlugInfo](ts.URL, "smartPlug")
+
+	assert.Equal(t, CodeDeviceUnreachable, ErrorCodeOf(err))
+}
+
+func TestGetLogsFromDataServiceLimitN_Unavailable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	_, err := GetLogsFromDataServiceLimitN[domain.PlugData]("smartPlug", 10)
+
+	assert.Equal(t, CodeDataServiceUnavailable, ErrorCodeOf(err))
+}
+
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode ErrorCode
+	}{
+		{name: "ServiceError", err: fmt.Errorf("wrapped: %w", NewServiceError(CodeDeviceError, errors.New("failure"))),
+			wantCode: CodeDeviceError},
+		{name: "Validation", err: &ValidationError{}, wantCode: CodeValidationFailed},
+		{name: "Interlocked", err: fmt.Errorf("%w: ac", ErrInterlocked), wantCode: CodeInterlocked},
+		{name: "InMaintenance", err: ErrInMaintenance, wantCode: CodeInMaintenance},
+		{name: "Overridden", err: ErrOverridden, wantCode: CodeOverridden},
+		{name: "ParsingFailed", err: utils.ErrParsingFailed, wantCode: CodeInvalidResponse},
+		{name: "Other", err: errors.New("failure"), wantCode: CodeInternal},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantCode, ErrorCodeOf(test.err))
+		})
+	}
+}
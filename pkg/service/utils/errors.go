@@ -0,0 +1,15 @@
+package service
+
+import "errors"
+
+// ErrInterlocked is returned when a device may not be switched on because an
+// unacknowledged safety alarm keeps it off.
+var ErrInterlocked = errors.New("Device is interlocked by an unacknowledged safety alarm")
+
+// ErrInMaintenance is returned when automation or a bulk command skips a device
+// that is in maintenance.
+var ErrInMaintenance = errors.New("Device is in maintenance")
+
+// ErrOverridden is returned when automation may not command a device because a
+// user override is active for it.
+var ErrOverridden = errors.New("Device is manually overridden")
@@ -0,0 +1,414 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/utils"
+)
+
+const DefaultMaxLogQueryLimit = 500
+const DefaultLogQueryScanLimit = 5000
+
+var logQueryMu sync.RWMutex
+var maxLogQueryLimit = DefaultMaxLogQueryLimit
+var logQueryScanLimit = DefaultLogQueryScanLimit
+
+// queryRecheckInterval is how long a device whose logs could not be queried
+// is filtered client-side before the data service is asked again.
+const queryRecheckInterval = 10 * time.Minute
+
+// logScanTTL is how long the latest logs scanned for a client-side query are
+// reused by the queries continuing it with a cursor.
+const logScanTTL = 30 * time.Second
+
+// queryUnsupportedAt records per device when the data service turned out not
+// to offer the query endpoint for its logs.
+var queryUnsupportedAt = map[string]time.Time{}
+
+// logScans holds the latest logs scanned per device for client-side queries.
+var logScans = map[string]logScan{}
+
+var logQueryNow = time.Now
+
+type logScan struct {
+	scannedAt time.Time
+	entries   []scannedLog
+	// full is set when the scan limit was reached, so older logs may exist.
+	full bool
+}
+
+type scannedLog struct {
+	raw    json.RawMessage
+	fields logFields
+}
+
+var errQueryUnsupported = errors.New("Data service does not support log queries")
+
+// SetLogQueryLimits sets the largest page a log query may ask for and how many
+// of the latest logs are scanned when the query has to be filtered
+// client-side.
+func SetLogQueryLimits(maxLimit int, scanLimit int) {
+	logQueryMu.Lock()
+	defer logQueryMu.Unlock()
+
+	maxLogQueryLimit = maxLimit
+	logQueryScanLimit = scanLimit
+}
+
+// MaxLogQueryLimit returns the largest page a log query may ask for.
+func MaxLogQueryLimit() int {
+	logQueryMu.RLock()
+	defer logQueryMu.RUnlock()
+
+	return maxLogQueryLimit
+}
+
+// logPosition is the sort key of a log, which the data service orders by
+// creation time and then by id.
+type logPosition struct {
+	Time time.Time `json:"t"`
+	ID   int       `json:"id"`
+}
+
+type logCursor struct {
+	logPosition
+	Order domain.SortOrder `json:"o"`
+}
+
+// logFields are the fields of a device log that queries filter on.
+type logFields struct {
+	ID        int       `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	IsEnabled *bool     `json:"is_enabled"`
+	Detected  *bool     `json:"detected"`
+}
+
+func (f logFields) position() logPosition {
+	return logPosition{Time: f.CreatedAt, ID: f.ID}
+}
+
+// QueryLogsFromDataService returns a page of the logs of the device matching
+// the query. The first page of the latest logs is read from the latest logs
+// endpoint. Other queries are passed on to the data service; when it
+// does not support queries for the device, the latest logs are filtered
+// client-side, which only covers the configured scan limit. Such a page is
+// marked as truncated when the queried range reaches past the scanned logs,
+// and a range lying entirely before them is rejected.
+func QueryLogsFromDataService[K any](deviceName string, query domain.LogQuery) (domain.LogPage[K], error) {
+	cursor, err := validateLogQuery(&query)
+	if err != nil {
+		return domain.LogPage[K]{}, err
+	}
+
+	var logs []json.RawMessage
+	if isLatestLogsQuery(query, cursor) {
+		logs, err = GetLogsFromDataServiceLimitN[json.RawMessage](deviceName, query.Limit+1)
+		if err != nil {
+			return domain.LogPage[K]{}, err
+		}
+		return newLogPage[K](deviceName, logs, query)
+	}
+
+	supported := querySupported(deviceName)
+	if supported {
+		logs, err = queryDataService(deviceName, query, cursor)
+		if errors.Is(err, errQueryUnsupported) {
+			setQueryUnsupported(deviceName)
+			supported = false
+		}
+	}
+	if supported {
+		if err != nil {
+			return domain.LogPage[K]{}, err
+		}
+		return newLogPage[K](deviceName, logs, query)
+	}
+
+	scan, err := scanLatestLogs(deviceName, cursor != nil)
+	if err != nil {
+		return domain.LogPage[K]{}, err
+	}
+	scannedFrom, truncated, err := scanCoverage(scan, query)
+	if err != nil {
+		return domain.LogPage[K]{}, err
+	}
+
+	page, err := newLogPage[K](deviceName, filterLogs(scan, query, cursor), query)
+	if err != nil {
+		return page, err
+	}
+	page.Truncated = truncated
+	page.ScannedFrom = scannedFrom
+	return page, nil
+}
+
+// isLatestLogsQuery reports whether the query asks for the first page of the
+// latest logs without filtering them.
+func isLatestLogsQuery(query domain.LogQuery, cursor *logCursor) bool {
+	return cursor == nil && query.Order == domain.SortDesc && query.From == nil && query.To == nil &&
+		query.IsEnabled == nil && query.Detected == nil
+}
+
+func querySupported(deviceName string) bool {
+	logQueryMu.RLock()
+	defer logQueryMu.RUnlock()
+
+	unsupportedAt, ok := queryUnsupportedAt[deviceName]
+	return !ok || logQueryNow().Sub(unsupportedAt) >= queryRecheckInterval
+}
+
+func setQueryUnsupported(deviceName string) {
+	logQueryMu.Lock()
+	defer logQueryMu.Unlock()
+
+	queryUnsupportedAt[deviceName] = logQueryNow()
+}
+
+// ValidateLogQuery checks the query the way QueryLogsFromDataService does.
+func ValidateLogQuery(query domain.LogQuery) error {
+	_, err := validateLogQuery(&query)
+	return err
+}
+
+func validateLogQuery(query *domain.LogQuery) (*logCursor, error) {
+	maxLimit := MaxLogQueryLimit()
+
+	validationErr := &ValidationError{}
+	if query.Limit < 1 || query.Limit > maxLimit {
+		validationErr.Add("limit", fmt.Sprintf("must be between 1 and %d", maxLimit))
+	}
+	if query.Order == "" {
+		query.Order = domain.SortDesc
+	}
+	if query.Order != domain.SortDesc && query.Order != domain.SortAsc {
+		validationErr.Add("order", fmt.Sprintf("must be one of %v", []domain.SortOrder{domain.SortDesc, domain.SortAsc}))
+	}
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		validationErr.Add("to", "must be after from")
+	}
+
+	var cursor *logCursor
+	if query.Cursor != "" {
+		decoded, err := decodeLogCursor(query.Cursor)
+		if err != nil || decoded.Order != query.Order {
+			validationErr.Add("cursor", "is invalid for this query")
+		}
+		cursor = decoded
+	}
+	return cursor, validationErr.ErrorOrNil()
+}
+
+// queryDataService asks the data service for one log more than the limit, to
+// tell whether there is a next page.
+func queryDataService(deviceName string, query domain.LogQuery, cursor *logCursor) ([]json.RawMessage, error) {
+	params := url.Values{}
+	if query.From != nil {
+		params.Set("from", query.From.UTC().Format(time.RFC3339Nano))
+	}
+	if query.To != nil {
+		params.Set("to", query.To.UTC().Format(time.RFC3339Nano))
+	}
+	if query.IsEnabled != nil {
+		params.Set("is_enabled", strconv.FormatBool(*query.IsEnabled))
+	}
+	if query.Detected != nil {
+		params.Set("detected", strconv.FormatBool(*query.Detected))
+	}
+	if cursor != nil {
+		params.Set("after_time", cursor.Time.UTC().Format(time.RFC3339Nano))
+		params.Set("after_id", strconv.Itoa(cursor.ID))
+	}
+	params.Set("order", string(query.Order))
+	params.Set("limit", strconv.Itoa(query.Limit+1))
+
+	dataServiceAddress := utils.GetEnvVariableOrDefault("DATA_SERVICE_ADDRESS", "http://localhost:8087")
+	resp, err := dataServiceHTTPClient().Get(fmt.Sprintf("%s/%s/query?%s", dataServiceAddress, deviceName, params.Encode()))
+	if err != nil {
+		return nil, NewServiceError(CodeDataServiceUnavailable, newUpstreamError(deviceName, 0, nil, err))
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return nil, errQueryUnsupported
+	case http.StatusNotFound:
+		// A data service without the query endpoint answers with its plain
+		// route-not-found page, while a JSON body is an error of the endpoint
+		// itself, e.g. an unknown device, and is reported as such.
+		body, err := io.ReadAll(resp.Body)
+		if err != nil || !json.Valid(body) {
+			return nil, errQueryUnsupported
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return decodeResponse[[]json.RawMessage](resp, deviceName, CodeDataServiceUnavailable)
+}
+
+// scanLatestLogs reads the latest logs of the device up to the scan limit. A
+// query continuing an earlier one reuses the recent scan of the device, so
+// that paging does not download the logs again.
+func scanLatestLogs(deviceName string, continued bool) (logScan, error) {
+	now := logQueryNow()
+	logQueryMu.RLock()
+	scanLimit := logQueryScanLimit
+	scan, ok := logScans[deviceName]
+	logQueryMu.RUnlock()
+	if continued && ok && now.Sub(scan.scannedAt) < logScanTTL {
+		return scan, nil
+	}
+
+	latest, err := GetLogsFromDataServiceLimitN[json.RawMessage](deviceName, scanLimit)
+	if err != nil {
+		return logScan{}, err
+	}
+
+	scan = logScan{scannedAt: now, entries: make([]scannedLog, 0, len(latest)), full: len(latest) >= scanLimit}
+	for _, raw := range latest {
+		var fields logFields
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return logScan{}, NewServiceError(CodeInvalidResponse,
+				newUpstreamError(deviceName, http.StatusOK, raw, fmt.Errorf("%w: %w", utils.ErrParsingFailed, err)))
+		}
+		scan.entries = append(scan.entries, scannedLog{raw: raw, fields: fields})
+	}
+
+	logQueryMu.Lock()
+	defer logQueryMu.Unlock()
+	for name, cached := range logScans {
+		if now.Sub(cached.scannedAt) >= logScanTTL {
+			delete(logScans, name)
+		}
+	}
+	logScans[deviceName] = scan
+	return scan, nil
+}
+
+// scanCoverage returns the time of the oldest scanned log and whether the
+// query reaches before it while older logs may exist. A query that lies
+// entirely before the scanned logs can not be answered at all.
+func scanCoverage(scan logScan, query domain.LogQuery) (*time.Time, bool, error) {
+	if !scan.full || len(scan.entries) == 0 {
+		return nil, false, nil
+	}
+
+	scannedFrom := scan.entries[0].fields.CreatedAt
+	for _, entry := range scan.entries[1:] {
+		if entry.fields.CreatedAt.Before(scannedFrom) {
+			scannedFrom = entry.fields.CreatedAt
+		}
+	}
+
+	if query.To != nil && !query.To.After(scannedFrom) {
+		validationErr := &ValidationError{}
+		validationErr.Add("to", fmt.Sprintf("must be after %s, the oldest of the %d latest logs that can be queried",
+			scannedFrom.Format(time.RFC3339), len(scan.entries)))
+		return nil, false, validationErr
+	}
+	truncated := query.From == nil || query.From.Before(scannedFrom)
+	return &scannedFrom, truncated, nil
+}
+
+// filterLogs applies the query to the scanned logs.
+func filterLogs(scan logScan, query domain.LogQuery, cursor *logCursor) []json.RawMessage {
+	var entries []scannedLog
+	for _, entry := range scan.entries {
+		if matchesLogQuery(entry.fields, query, cursor) {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return before(entries[i].fields.position(), entries[j].fields.position(), query.Order)
+	})
+	if len(entries) > query.Limit+1 {
+		entries = entries[:query.Limit+1]
+	}
+
+	logs := make([]json.RawMessage, len(entries))
+	for i, entry := range entries {
+		logs[i] = entry.raw
+	}
+	return logs
+}
+
+func matchesLogQuery(fields logFields, query domain.LogQuery, cursor *logCursor) bool {
+	if query.From != nil && fields.CreatedAt.Before(*query.From) {
+		return false
+	}
+	if query.To != nil && !fields.CreatedAt.Before(*query.To) {
+		return false
+	}
+	if query.IsEnabled != nil && (fields.IsEnabled == nil || *fields.IsEnabled != *query.IsEnabled) {
+		return false
+	}
+	if query.Detected != nil && (fields.Detected == nil || *fields.Detected != *query.Detected) {
+		return false
+	}
+	return cursor == nil || before(cursor.logPosition, fields.position(), query.Order)
+}
+
+// before reports whether a comes before b in the given order.
+func before(a logPosition, b logPosition, order domain.SortOrder) bool {
+	if !a.Time.Equal(b.Time) {
+		return a.Time.Before(b.Time) == (order == domain.SortAsc)
+	}
+	return (a.ID < b.ID) == (order == domain.SortAsc)
+}
+
+// newLogPage decodes the logs up to the limit and sets the cursor when one more
+// log was found.
+func newLogPage[K any](deviceName string, logs []json.RawMessage, query domain.LogQuery) (domain.LogPage[K], error) {
+	page := domain.LogPage[K]{Logs: make([]K, 0, len(logs))}
+	hasNext := len(logs) > query.Limit
+	if hasNext {
+		logs = logs[:query.Limit]
+	}
+
+	for _, raw := range logs {
+		var value K
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return domain.LogPage[K]{}, NewServiceError(CodeInvalidResponse,
+				newUpstreamError(deviceName, http.StatusOK, raw, fmt.Errorf("%w: %w", utils.ErrParsingFailed, err)))
+		}
+		page.Logs = append(page.Logs, value)
+	}
+
+	if hasNext {
+		var fields logFields
+		if err := json.Unmarshal(logs[len(logs)-1], &fields); err != nil {
+			return domain.LogPage[K]{}, NewServiceError(CodeInvalidResponse,
+				newUpstreamError(deviceName, http.StatusOK, logs[len(logs)-1], fmt.Errorf("%w: %w", utils.ErrParsingFailed, err)))
+		}
+		page.NextCursor = encodeLogCursor(logCursor{logPosition: fields.position(), Order: query.Order})
+	}
+	return page, nil
+}
+
+func encodeLogCursor(cursor logCursor) string {
+	encoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func decodeLogCursor(cursor string) (*logCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var logCursor logCursor
+	if err := json.Unmarshal(decoded, &logCursor); err != nil {
+		return nil, err
+	}
+	return &logCursor, nil
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type testLog = domain.DeviceData
+
+const latestTestLogs = `[
+	{"id": 4, "created_at": "2023-01-01T04:00:00Z", "is_enabled": true},
+	{"id": 3, "created_at": "2023-01-01T03:00:00Z", "is_enabled": false},
+	{"id": 2, "created_at": "2023-01-01T02:00:00Z", "is_enabled": true},
+	{"id": 1, "created_at": "2023-01-01T01:00:00Z", "is_enabled": true}
+]`
+
+func resetLogQuery(t *testing.T) {
+	queryUnsupportedAt = map[string]time.Time{}
+	logScans = map[string]logScan{}
+	t.Cleanup(func() {
+		queryUnsupportedAt = map[string]time.Time{}
+		logScans = map[string]logScan{}
+		logQueryNow = time.Now
+		SetLogQueryLimits(DefaultMaxLogQueryLimit, DefaultLogQueryScanLimit)
+	})
+}
+
+func logAt(hour int) time.Time {
+	return time.Date(2023, 1, 1, hour, 0, 0, 0, time.UTC)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestQueryLogsFromDataService_DataServiceQuery(t *testing.T) {
+	resetLogQuery(t)
+	var queries []url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/smartPlug/query", r.URL.Path)
+		queries = append(queries, r.URL.Query())
+		fmt.Fprintln(w, `[
+			{"id": 4, "created_at": "2023-01-01T04:00:00Z", "is_enabled": true},
+			{"id": 2, "created_at": "2023-01-01T02:00:00Z", "is_enabled": true}
+		]`)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	from := logAt(0)
+	page, err := QueryLogsFromDataService[testLog]("smartPlug",
+		domain.LogQuery{From: &from, IsEnabled: boolPtr(true), Limit: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []testLog{{ID: 4, CreatedAt: logAt(4), IsEnabled: true}}, page.Logs)
+	assert.NotEmpty(t, page.NextCursor)
+	assert.Equal(t, "2023-01-01T00:00:00Z", queries[0].Get("from"))
+	assert.Equal(t, "true", queries[0].Get("is_enabled"))
+	assert.Equal(t, "desc", queries[0].Get("order"))
+	assert.Equal(t, "2", queries[0].Get("limit"))
+
+	_, err = QueryLogsFromDataService[testLog]("smartPlug",
+		domain.LogQuery{From: &from, IsEnabled: boolPtr(true), Limit: 1, Cursor: page.NextCursor})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-01-01T04:00:00Z", queries[1].Get("after_time"))
+	assert.Equal(t, "4", queries[1].Get("after_id"))
+}
+
+func TestQueryLogsFromDataService_FallbackFiltersLatestLogs(t *testing.T) {
+	resetLogQuery(t)
+	SetLogQueryLimits(DefaultMaxLogQueryLimit, 100)
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.String())
+		if r.URL.Path != "/smartPlug/latest" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, latestTestLogs)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	to := logAt(4)
+	query := domain.LogQuery{To: &to, IsEnabled: boolPtr(true), Order: domain.SortAsc, Limit: 1}
+	page, err := QueryLogsFromDataService[testLog]("smartPlug", query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []testLog{{ID: 1, CreatedAt: logAt(1), IsEnabled: true}}, page.Logs)
+	assert.NotEmpty(t, page.NextCursor)
+
+	query.Cursor = page.NextCursor
+	page, err = QueryLogsFromDataService[testLog]("smartPlug", query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []testLog{{ID: 2, CreatedAt: logAt(2), IsEnabled: true}}, page.Logs)
+	assert.Empty(t, page.NextCursor)
+	assert.False(t, page.Truncated)
+	assert.Equal(t, []string{"/smartPlug/query?is_enabled=true&limit=2&order=asc&to=2023-01-01T04%3A00%3A00Z",
+		"/smartPlug/latest?limit=100"}, paths)
+}
+
+func TestQueryLogsFromDataService_FallbackDetectedPerDevice(t *testing.T) {
+	resetLogQuery(t)
+	now := logAt(12)
+	logQueryNow = func() time.Time { return now }
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/smartPlug/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, latestTestLogs)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	query := domain.LogQuery{IsEnabled: boolPtr(true), Limit: 1}
+	for _, device := range []string{"smartPlug", "ac", "smartPlug"} {
+		_, err := QueryLogsFromDataService[testLog](device, query)
+		assert.NoError(t, err)
+	}
+	now = now.Add(queryRecheckInterval)
+	_, err := QueryLogsFromDataService[testLog]("smartPlug", query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/smartPlug/query", "/smartPlug/latest", "/ac/query", "/smartPlug/latest",
+		"/smartPlug/query", "/smartPlug/latest"}, paths)
+}
+
+func TestQueryLogsFromDataService_LatestLogs(t *testing.T) {
+	resetLogQuery(t)
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.String())
+		fmt.Fprintln(w, `[
+			{"id": 4, "created_at": "2023-01-01T04:00:00Z", "is_enabled": true},
+			{"id": 3, "created_at": "2023-01-01T03:00:00Z", "is_enabled": false}
+		]`)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	page, err := QueryLogsFromDataService[testLog]("smartPlug", domain.LogQuery{Limit: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []testLog{{ID: 4, CreatedAt: logAt(4), IsEnabled: true}}, page.Logs)
+	assert.NotEmpty(t, page.NextCursor)
+	assert.Equal(t, []string{"/smartPlug/latest?limit=2"}, paths)
+}
+
+func TestQueryLogsFromDataService_QueryNotFound(t *testing.T) {
+	resetLogQuery(t)
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, `{"error": "device not found"}`)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	query := domain.LogQuery{IsEnabled: boolPtr(true), Limit: 1}
+	_, err := QueryLogsFromDataService[testLog]("smartPlug", query)
+
+	assert.Equal(t, CodeDataServiceUnavailable, ErrorCodeOf(err))
+	assert.True(t, querySupported("smartPlug"))
+	assert.Equal(t, []string{"/smartPlug/query"}, paths)
+}
+
+func TestQueryLogsFromDataService_FallbackTruncated(t *testing.T) {
+	resetLogQuery(t)
+	SetLogQueryLimits(DefaultMaxLogQueryLimit, 4)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/smartPlug/latest" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintln(w, latestTestLogs)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	t.Run("WithinScannedLogs", func(t *testing.T) {
+		from := logAt(2)
+		page, err := QueryLogsFromDataService[testLog]("smartPlug", domain.LogQuery{From: &from, Limit: 10})
+
+		assert.NoError(t, err)
+		assert.Len(t, page.Logs, 3)
+		assert.False(t, page.Truncated)
+	})
+
+	t.Run("ReachingPastScannedLogs", func(t *testing.T) {
+		from := logAt(0)
+		page, err := QueryLogsFromDataService[testLog]("smartPlug", domain.LogQuery{From: &from, Limit: 10})
+
+		assert.NoError(t, err)
+		assert.Len(t, page.Logs, 4)
+		assert.True(t, page.Truncated)
+		assert.Equal(t, logAt(1), *page.ScannedFrom)
+	})
+
+	t.Run("BeforeScannedLogs", func(t *testing.T) {
+		from, to := logAt(0), logAt(1)
+		_, err := QueryLogsFromDataService[testLog]("smartPlug", domain.LogQuery{From: &from, To: &to, Limit: 10})
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "to", validationErr.Errors[0].Field)
+	})
+}
+
+func TestQueryLogsFromDataService_Validation(t *testing.T) {
+	resetLogQuery(t)
+	from, to := logAt(2), logAt(1)
+	ascCursor := encodeLogCursor(logCursor{logPosition: logPosition{Time: logAt(1), ID: 1}, Order: domain.SortAsc})
+	tests := []struct {
+		name      string
+		query     domain.LogQuery
+		wantField string
+	}{
+		{name: "LimitTooSmall", query: domain.LogQuery{Limit: 0}, wantField: "limit"},
+		{name: "LimitTooLarge", query: domain.LogQuery{Limit: DefaultMaxLogQueryLimit + 1}, wantField: "limit"},
+		{name: "InvalidOrder", query: domain.LogQuery{Limit: 1, Order: "sideways"}, wantField: "order"},
+		{name: "FromAfterTo", query: domain.LogQuery{Limit: 1, From: &from, To: &to}, wantField: "to"},
+		{name: "MalformedCursor", query: domain.LogQuery{Limit: 1, Cursor: "not a cursor"}, wantField: "cursor"},
+		{name: "CursorOfOtherOrder", query: domain.LogQuery{Limit: 1, Cursor: ascCursor}, wantField: "cursor"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := QueryLogsFromDataService[testLog]("smartPlug", test.query)
+
+			var validationErr *ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, CodeValidationFailed, ErrorCodeOf(err))
+			assert.Equal(t, test.wantField, validationErr.Errors[0].Field)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+var logTaggerMu sync.RWMutex
+var logTagger func(deviceName string) []string
+
+// SetLogTagger sets the function returning the tags added to the logs sent to
+// the data service for a device, e.g. while it is in maintenance.
+func SetLogTagger(tagger func(deviceName string) []string) {
+	logTaggerMu.Lock()
+	defer logTaggerMu.Unlock()
+
+	logTagger = tagger
+}
+
+// tagLog adds the tags of the device to the log as the "tags" field. Logs that
+// are not JSON objects are sent unchanged.
+func tagLog(deviceName string, jsonValue []byte) []byte {
+	logTaggerMu.RLock()
+	tagger := logTagger
+	logTaggerMu.RUnlock()
+	if tagger == nil {
+		return jsonValue
+	}
+
+	tags := tagger(deviceName)
+	if len(tags) == 0 {
+		return jsonValue
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(jsonValue, &fields); err != nil {
+		return jsonValue
+	}
+	fields["tags"] = tags
+	tagged, err := json.Marshal(fields)
+	if err != nil {
+		return jsonValue
+	}
+	return tagged
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendLogsToDataService_Tagged(t *testing.T) {
+	var got map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = nil
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	t.Setenv("DATA_SERVICE_ADDRESS", ts.URL)
+
+	SetLogTagger(func(deviceName string) []string {
+		if deviceName == "smartPlug" {
+			return []string{"maintenance"}
+		}
+		return nil
+	})
+	defer SetLogTagger(nil)
+
+	err := SendLogsToDataService("smartPlug", domain.PlugInfo{Enabled: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"enabled": true, "tags": []any{"maintenance"}}, got)
+
+	err = SendLogsToDataService("smartBulb", domain.LightInfo{Enabled: true})
+	assert.NoError(t, err)
+	assert.NotContains(t, got, "tags")
+}
+
+func TestTagLog_NotAnObject(t *testing.T) {
+	SetLogTagger(func(deviceName string) []string { return []string{"maintenance"} })
+	defer SetLogTagger(nil)
+
+	assert.Equal(t, []byte(`[1,2]`), tagLog("smartPlug", []byte(`[1,2]`)))
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"unicode/utf8"
+)
+
+// upstreamBodySnippetLength bounds the part of an upstream response body kept
+// in an error, so that a misbehaving device can not flood the logs.
+const upstreamBodySnippetLength = 256
+
+// UpstreamError describes a failed request to a device or to the data service.
+// Status is 0 when no response was received. The message keeps the "device:
+// body" form the devices' own error messages were always reported in.
+type UpstreamError struct {
+	Device string
+	Status int
+	Body   string
+	Err    error
+}
+
+func (e *UpstreamError) Error() string {
+	switch {
+	case e.Status != 0 && e.Err != nil:
+		return fmt.Sprintf("%s: invalid response with status %d: %s", e.Device, e.Status, e.Err)
+	case e.Err != nil:
+		return fmt.Sprintf("%s: %s", e.Device, e.Err)
+	default:
+		return fmt.Sprintf("%s: %s", e.Device, e.Body)
+	}
+}
+
+func (e *UpstreamError) Unwrap() error {
+	return e.Err
+}
+
+func newUpstreamError(device string, status int, body []byte, err error) *UpstreamError {
+	return &UpstreamError{Device: device, Status: status, Body: bodySnippet(body), Err: err}
+}
+
+func bodySnippet(body []byte) string {
+	snippet := strings.TrimSpace(string(body))
+	if len(snippet) <= upstreamBodySnippetLength {
+		return snippet
+	}
+
+	snippet = snippet[:upstreamBodySnippetLength]
+	for !utf8.ValidString(snippet) {
+		snippet = snippet[:len(snippet)-1]
+	}
+	return snippet + "..."
+}
+
+// deviceRequestError classifies the error of a request that did not get a
+// response from the device.
+func deviceRequestError(device string, err error) error {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return NewServiceError(CodeDeviceTimeout, newUpstreamError(device, 0, nil, err))
+	}
+	return NewServiceError(CodeDeviceUnreachable, newUpstreamError(device, 0, nil, err))
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/pklimuk-eng-thesis/control-station/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchJSON_UpstreamStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(strings.Repeat("a", 300)))
+	}))
+	defer ts.Close()
+
+	_, err := FetchJSON[domain.PlugInfo](ts.URL, "smartPlug")
+
+	var upstreamErr *UpstreamError
+	if assert.ErrorAs(t, err, &upstreamErr) {
+		assert.Equal(t, "smartPlug", upstreamErr.Device)
+		assert.Equal(t, http.StatusServiceUnavailable, upstreamErr.Status)
+		assert.Equal(t, strings.Repeat("a", upstreamBodySnippetLength)+"...", upstreamErr.Body)
+		assert.Nil(t, upstreamErr.Err)
+	}
+}
+
+func TestFetchJSON_UpstreamInvalidJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"enabled": tru}`))
+	}))
+	defer ts.Close()
+
+	_, err := FetchJSON[domain.PlugInfo](ts.URL, "smartPlug")
+
+	assert.ErrorIs(t, err, utils.ErrParsingFailed)
+	var syntaxErr *json.SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+	var upstreamErr *UpstreamError
+	if assert.ErrorAs(t, err, &upstreamErr) {
+		assert.Equal(t, http.StatusOK, upstreamErr.Status)
+		assert.Equal(t, `{"enabled": tru}`, upstreamErr.Body)
+	}
+}
+
+func TestFetchJSON_UpstreamUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+
+	_, err := FetchJSON[domain.PlugInfo](ts.URL, "smartPlug")
+
+	var upstreamErr *UpstreamError
+	if assert.ErrorAs(t, err, &upstreamErr) {
+		assert.Equal(t, 0, upstreamErr.Status)
+		assert.Error(t, upstreamErr.Err)
+	}
+}
+
+func TestUpstreamError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *UpstreamError
+		want string
+	}{
+		{name: "Status", err: &UpstreamError{Device: "smartPlug", Status: 500, Body: "Plug is disabled"},
+			want: "smartPlug: Plug is disabled"},
+		{name: "InvalidResponse", err: &UpstreamError{Device: "smartPlug", Status: 200, Err: errors.New("failure")},
+			want: "smartPlug: invalid response with status 200: failure"},
+		{name: "NoResponse", err: &UpstreamError{Device: "smartPlug", Err: errors.New("failure")},
+			want: "smartPlug: failure"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.err.Error())
+		})
+	}
+}
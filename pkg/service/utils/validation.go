@@ -0,0 +1,51 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ValidationError struct {
+	Errors []FieldError `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldError := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s: %s", fieldError.Field, fieldError.Message))
+	}
+	return "Validation failed: " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Add(field string, message string) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Message: message})
+}
+
+func (e *ValidationError) AddRangeErrors(field string, value float32, min float32, max float32, step float32) {
+	if value < min || value > max {
+		e.Add(field, fmt.Sprintf("must be between %g and %g", min, max))
+		return
+	}
+
+	if step > 0 {
+		remainder := math.Remainder(float64(value-min), float64(step))
+		if math.Abs(remainder) > 1e-3 {
+			e.Add(field, fmt.Sprintf("must be a multiple of %g starting from %g", step, min))
+		}
+	}
+}
+
+// ErrorOrNil returns nil when no field errors were collected, so that callers
+// do not end up with a non-nil error interface wrapping an empty result.
+func (e *ValidationError) ErrorOrNil() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddRangeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float32
+		want  []FieldError
+	}{
+		{name: "Valid", value: 21.5, want: nil},
+		{name: "Minimum", value: 16, want: nil},
+		{name: "Maximum", value: 30, want: nil},
+		{name: "BelowMinimum", value: -1, want: []FieldError{{Field: "temperature", Message: "must be between 16 and 30"}}},
+		{name: "AboveMaximum", value: 80, want: []FieldError{{Field: "temperature", Message: "must be between 16 and 30"}}},
+		{name: "OffStep", value: 21.2, want: []FieldError{{Field: "temperature", Message: "must be a multiple of 0.5 starting from 16"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validationErr := &ValidationError{}
+			validationErr.AddRangeErrors("temperature", test.value, 16, 30, 0.5)
+			assert.Equal(t, test.want, validationErr.Errors)
+		})
+	}
+}
+
+func TestValidationError_ErrorOrNil(t *testing.T) {
+	validationErr := &ValidationError{}
+	assert.NoError(t, validationErr.ErrorOrNil())
+
+	validationErr.Add("humidity", "must be between 30 and 70")
+	err := validationErr.ErrorOrNil()
+	assert.Error(t, err)
+	assert.Equal(t, "Validation failed: humidity: must be between 30 and 70", err.Error())
+}
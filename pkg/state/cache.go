@@ -0,0 +1,56 @@
+package state
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+)
+
+// Cache keeps the last known state of every device, so that readers do not
+// have to query the devices themselves.
+type Cache interface {
+	Set(device string, state any)
+	Get(device string) (domain.CachedState, bool)
+	List() []domain.CachedState
+}
+
+type cache struct {
+	mu     sync.RWMutex
+	states map[string]domain.CachedState
+	now    func() time.Time
+}
+
+func NewCache() Cache {
+	return &cache{states: map[string]domain.CachedState{}, now: time.Now}
+}
+
+func (c *cache) Set(device string, state any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.states[device] = domain.CachedState{Device: device, State: state, UpdatedAt: c.now()}
+}
+
+func (c *cache) Get(device string) (domain.CachedState, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	state, ok := c.states[device]
+	return state, ok
+}
+
+func (c *cache) List() []domain.CachedState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	states := make([]domain.CachedState, 0, len(c.states))
+	for _, state := range c.states {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Device < states[j].Device
+	})
+	return states
+}
@@ -0,0 +1,45 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pklimuk-eng-thesis/control-station/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAndGet(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := &cache{states: map[string]domain.CachedState{}, now: func() time.Time { return now }}
+
+	_, ok := c.Get("smartBulb")
+	assert.False(t, ok)
+
+	c.Set("smartBulb", domain.LightInfo{Enabled: true})
+	got, ok := c.Get("smartBulb")
+
+	assert.True(t, ok)
+	assert.Equal(t, domain.CachedState{Device: "smartBulb", State: domain.LightInfo{Enabled: true}, UpdatedAt: now}, got)
+}
+
+func TestSet_Overwrites(t *testing.T) {
+	c := NewCache()
+	c.Set("smartPlug", domain.PlugInfo{Enabled: false})
+	c.Set("smartPlug", domain.PlugInfo{Enabled: true})
+
+	got, _ := c.Get("smartPlug")
+	assert.Equal(t, domain.PlugInfo{Enabled: true}, got.State)
+}
+
+func TestList(t *testing.T) {
+	c := NewCache()
+	c.Set("smartPlug", domain.PlugInfo{})
+	c.Set("ac", domain.ACInfo{})
+	c.Set("gasSensor", domain.SensorInfo{})
+
+	var devices []string
+	for _, state := range c.List() {
+		devices = append(devices, state.Device)
+	}
+	assert.Equal(t, []string{"ac", "gasSensor", "smartPlug"}, devices)
+}
@@ -1,6 +1,11 @@
 package utils
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
 
 func GetEnvVariableOrDefault(identifier string, defaultAddress string) string {
 	address := os.Getenv(identifier)
@@ -9,3 +14,40 @@ func GetEnvVariableOrDefault(identifier string, defaultAddress string) string {
 	}
 	return address
 }
+
+func GetEnvVariableAsFloatOrDefault(identifier string, defaultValue float64) (float64, error) {
+	value := os.Getenv(identifier)
+	if value == "" {
+		return defaultValue, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+func GetEnvVariableAsIntOrDefault(identifier string, defaultValue int) (int, error) {
+	value := os.Getenv(identifier)
+	if value == "" {
+		return defaultValue, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func GetEnvVariableAsDurationOrDefault(identifier string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(identifier)
+	if value == "" {
+		return defaultValue, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// GetEnvVariableAsPositiveDurationOrDefault reads a duration that must be
+// positive, such as an interval used for a ticker.
+func GetEnvVariableAsPositiveDurationOrDefault(identifier string, defaultValue time.Duration) (time.Duration, error) {
+	duration, err := GetEnvVariableAsDurationOrDefault(identifier, defaultValue)
+	if err != nil {
+		return duration, err
+	}
+	if duration <= 0 {
+		return duration, fmt.Errorf("%s must be positive, got %s", identifier, duration)
+	}
+	return duration, nil
+}